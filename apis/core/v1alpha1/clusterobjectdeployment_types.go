@@ -13,6 +13,9 @@ type ClusterObjectDeploymentSpec struct {
 	Selector metav1.LabelSelector `json:"selector"`
 	// Template to create new ObjectSets from.
 	Template ObjectSetTemplate `json:"template"`
+	// Strategy to roll out new revisions with.
+	// +optional
+	RolloutStrategy *ObjectDeploymentRolloutStrategy `json:"rolloutStrategy,omitempty"`
 }
 
 // ClusterObjectDeploymentStatus defines the observed state of a ClusterObjectDeployment.
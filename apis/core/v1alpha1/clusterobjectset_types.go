@@ -68,6 +68,9 @@ type ClusterObjectSetStatus struct {
 	RemotePhases []RemotePhaseReference `json:"remotePhases,omitempty"`
 	// References all objects controlled by this instance.
 	ControllerOf []ControlledObjectReference `json:"controllerOf,omitempty"`
+	// Availability probes currently failing.
+	// Only contains failures of the first phase that is not available.
+	ProbeResults []ProbeResult `json:"probeResults,omitempty"`
 }
 
 func init() { register(&ClusterObjectSet{}, &ClusterObjectSetList{}) }
@@ -70,6 +70,8 @@ type ClusterObjectSetPhaseStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// References all objects controlled by this instance.
 	ControllerOf []ControlledObjectReference `json:"controllerOf,omitempty"`
+	// Availability probes currently failing.
+	ProbeResults []ProbeResult `json:"probeResults,omitempty"`
 }
 
 func init() { register(&ClusterObjectSetPhase{}, &ClusterObjectSetPhaseList{}) }
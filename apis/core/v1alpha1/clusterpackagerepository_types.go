@@ -0,0 +1,28 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ClusterPackageRepository makes the packages of a package repository image
+// available cluster-wide.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=clpkgrepo
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.image"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterPackageRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PackageRepositorySpec   `json:"spec,omitempty"`
+	Status PackageRepositoryStatus `json:"status,omitempty"`
+}
+
+// ClusterPackageRepositoryList contains a list of ClusterPackageRepositories.
+// +kubebuilder:object:root=true
+type ClusterPackageRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPackageRepository `json:"items"`
+}
+
+func init() { register(&ClusterPackageRepository{}, &ClusterPackageRepositoryList{}) }
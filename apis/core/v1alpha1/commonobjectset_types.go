@@ -6,6 +6,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // ObjectSetRevisionAnnotation annotations holds a revision generation number to order ObjectSets.
@@ -62,8 +63,36 @@ type ObjectSetTemplatePhase struct {
 
 	// References to ObjectSlices containing objects for this phase.
 	Slices []string `json:"slices,omitempty"`
+
+	// Marks this phase as a lifecycle hook.
+	// Hook phases are skipped during regular reconciliation.
+	// PreInstall or PreUpgrade phases are reconciled before all other phases of the first
+	// or of a later revision and must pass all availability probes before other phases are reconciled.
+	// PostUpgrade phases are reconciled after all other phases of a later revision are available.
+	// Objects of these hooks are removed once they succeeded, so they run once per revision.
+	// PreDelete phases are reconciled when the ObjectSet is deleted and
+	// must pass all availability probes before other phases are torn down.
+	// PostDelete phases are reconciled after all other phases have been torn down.
+	// +kubebuilder:validation:Enum=PreInstall;PreUpgrade;PostUpgrade;PreDelete;PostDelete
+	Hook ObjectSetPhaseHook `json:"hook,omitempty"`
 }
 
+// ObjectSetPhaseHook specifies when a hook phase is reconciled.
+type ObjectSetPhaseHook string
+
+const (
+	// ObjectSetPhaseHookPreInstall phases are reconciled before other phases of the first revision.
+	ObjectSetPhaseHookPreInstall ObjectSetPhaseHook = "PreInstall"
+	// ObjectSetPhaseHookPreUpgrade phases are reconciled before other phases of later revisions.
+	ObjectSetPhaseHookPreUpgrade ObjectSetPhaseHook = "PreUpgrade"
+	// ObjectSetPhaseHookPostUpgrade phases are reconciled after other phases of later revisions are available.
+	ObjectSetPhaseHookPostUpgrade ObjectSetPhaseHook = "PostUpgrade"
+	// ObjectSetPhaseHookPreDelete phases are reconciled before other phases are torn down.
+	ObjectSetPhaseHookPreDelete ObjectSetPhaseHook = "PreDelete"
+	// ObjectSetPhaseHookPostDelete phases are reconciled after all other phases have been torn down.
+	ObjectSetPhaseHookPostDelete ObjectSetPhaseHook = "PostDelete"
+)
+
 // ObjectSetObject is an object that is part of the phase of an ObjectSet.
 type ObjectSetObject struct {
 	// +kubebuilder:validation:EmbeddedResource
@@ -76,6 +105,18 @@ type ObjectSetObject struct {
 	CollisionProtection CollisionProtection `json:"collisionProtection,omitempty"`
 	// Maps conditions from this object into the Package Operator APIs.
 	ConditionMappings []ConditionMapping `json:"conditionMappings,omitempty"`
+	// Controls how Package Operator reconciles this object.
+	// Revert re-applies the desired state when the object has drifted.
+	// ObserveOnly only reports drift without changing the object.
+	// +kubebuilder:validation:Enum=Revert;ObserveOnly
+	Reconcile ObjectReconcileMode `json:"reconcile,omitempty"`
+	// Controls what happens to this object when it is torn down.
+	// Delete removes the object.
+	// Orphan removes the owner reference and leaves the object on the cluster.
+	// OrphanIfPopulated only deletes CustomResourceDefinitions without instances and
+	// unbound PersistentVolumeClaims, objects of all other kinds are orphaned.
+	// +kubebuilder:validation:Enum=Delete;Orphan;OrphanIfPopulated
+	DeletionPolicy ObjectDeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 func (o ObjectSetObject) String() string {
@@ -84,6 +125,33 @@ func (o ObjectSetObject) String() string {
 	return fmt.Sprintf("object %s/%s kind:%s", obj.GetNamespace(), obj.GetName(), obj.GetKind())
 }
 
+// ObjectReconcileMode specifies how PKO handles drift of an object from its desired state.
+type ObjectReconcileMode string
+
+const (
+	// ObjectReconcileModeRevert re-applies the desired state, reverting any drift.
+	// This is the default.
+	ObjectReconcileModeRevert ObjectReconcileMode = "Revert"
+	// ObjectReconcileModeObserveOnly reports drift without changing the object.
+	// Objects are still created, if they don't exist.
+	ObjectReconcileModeObserveOnly ObjectReconcileMode = "ObserveOnly"
+)
+
+// ObjectDeletionPolicy specifies how PKO tears down an object.
+type ObjectDeletionPolicy string
+
+const (
+	// ObjectDeletionPolicyDelete deletes the object. This is the default.
+	ObjectDeletionPolicyDelete ObjectDeletionPolicy = "Delete"
+	// ObjectDeletionPolicyOrphan leaves the object on the cluster.
+	ObjectDeletionPolicyOrphan ObjectDeletionPolicy = "Orphan"
+	// ObjectDeletionPolicyOrphanIfPopulated leaves the object on the cluster,
+	// if it holds data that would be lost on deletion.
+	// Applies to CustomResourceDefinitions with existing instances and bound PersistentVolumeClaims,
+	// objects of all other kinds are orphaned unconditionally.
+	ObjectDeletionPolicyOrphanIfPopulated ObjectDeletionPolicy = "OrphanIfPopulated"
+)
+
 // CollisionProtection specifies if and how PKO prevent ownership collisions.
 type CollisionProtection string
 
@@ -117,6 +185,13 @@ const (
 	// InTransition condition is True when the ObjectSet is not in control of all objects defined in spec.
 	// This holds true during rollout of the first instance or while handing over objects between two ObjectSets.
 	ObjectSetInTransition = "InTransition"
+	// Drifted condition is True when objects under management
+	// have been changed outside of Package Operator.
+	ObjectSetDrifted = "Drifted"
+	// PreHooksSucceeded condition is True after all PreInstall or PreUpgrade hooks succeeded.
+	ObjectSetPreHooksSucceeded = "PreHooksSucceeded"
+	// PostHooksSucceeded condition is True after all PostUpgrade hooks succeeded.
+	ObjectSetPostHooksSucceeded = "PostHooksSucceeded"
 )
 
 // ObjectSetStatusPhase defines the status phase of an object set.
@@ -140,9 +215,12 @@ const (
 // ObjectSetProbe define how ObjectSets check their children for their status.
 type ObjectSetProbe struct {
 	// Probe configuration parameters.
-	Probes []Probe `json:"probes"`
+	Probes []Probe `json:"probes,omitempty"`
 	// Selector specifies which objects this probe should target.
 	Selector ProbeSelector `json:"selector"`
+	// Aggregate probes check all selected objects of a phase at once,
+	// instead of checking every object individually.
+	Aggregate []ProbeAggregateSpec `json:"aggregate,omitempty"`
 }
 
 // ConditionMapping maps one condition type to another.
@@ -181,6 +259,8 @@ type Probe struct {
 	Condition   *ProbeConditionSpec   `json:"condition,omitempty"`
 	FieldsEqual *ProbeFieldsEqualSpec `json:"fieldsEqual,omitempty"`
 	CEL         *ProbeCELSpec         `json:"cel,omitempty"`
+	HTTPGet     *ProbeHTTPGetSpec     `json:"httpGet,omitempty"`
+	TCPSocket   *ProbeTCPSocketSpec   `json:"tcpSocket,omitempty"`
 }
 
 // ProbeConditionSpec checks whether or not the object reports a condition with given type and status.
@@ -205,6 +285,8 @@ type ProbeFieldsEqualSpec struct {
 
 // ProbeCELSpec uses Common Expression Language (CEL) to probe an object.
 // CEL rules have to evaluate to a boolean to be valid.
+// Rules using `time.now()`, `time.since()` or `time.until()` are re-evaluated every 15 seconds,
+// also after they succeeded.
 // See:
 // https://kubernetes.io/docs/reference/using-api/cel
 // https://github.com/google/cel-go
@@ -217,6 +299,107 @@ type ProbeCELSpec struct {
 	Message string `json:"message"`
 }
 
+// ProbeHTTPGetSpec sends an HTTP GET request to a Service or Route
+// and checks the status code and, optionally, the body of the response.
+// Services are reached through the Kubernetes API server service proxy,
+// Routes directly via their host.
+// The request is repeated every 15 seconds, also after it succeeded.
+// Requests to the same object may take 10 seconds combined,
+// further requests are reported as not probed yet.
+type ProbeHTTPGetSpec struct {
+	// Path to send the request to, without query.
+	// +kubebuilder:default="/"
+	// +example=/healthz
+	Path string `json:"path,omitempty"`
+	// Port of the Service to send the request to, by name or number.
+	// Defaults to the first port of the Service. Ignored for Routes.
+	// +example=http
+	Port *intstr.IntOrString `json:"port,omitempty"`
+	// Scheme to use for requests to Services.
+	// Requests to Routes use HTTPS when TLS is configured and HTTP otherwise.
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	// +kubebuilder:default=HTTP
+	Scheme string `json:"scheme,omitempty"`
+	// Status codes considered successful.
+	// Defaults to all status codes from 200 to 399.
+	// +example=[200]
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty"`
+	// Checks the response body.
+	Body *ProbeHTTPBodySpec `json:"body,omitempty"`
+	// Timeout of the request. Defaults to 1s.
+	// +example=5s
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ProbeHTTPBodySpec uses Common Expression Language (CEL) to probe HTTP response bodies.
+// The rule has access to the response body as string `body`
+// and, if the body is valid JSON, to the parsed body as `json`.
+type ProbeHTTPBodySpec struct {
+	// CEL rule to evaluate.
+	// +example=json.status == "ok"
+	Rule string `json:"rule"`
+	// Error message to output if rule evaluates to false.
+	// +example=Application is not ready
+	Message string `json:"message"`
+}
+
+// ProbeTCPSocketSpec checks that a TCP connection to a Service or Route can be opened.
+// Services are reached via their cluster DNS name,
+// so Package Operator needs to run within the cluster network to probe them.
+// The connection attempt is repeated every 15 seconds, also after it succeeded,
+// sharing the time budget of the object with HTTP GET probes.
+type ProbeTCPSocketSpec struct {
+	// Port of the Service to connect to, by name or number.
+	// Defaults to the first port of the Service.
+	// Routes are connected to on port 443 when TLS is configured and on port 80 otherwise.
+	// +example=5432
+	Port *intstr.IntOrString `json:"port,omitempty"`
+	// Timeout of the connection attempt. Defaults to 1s.
+	// +example=5s
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ProbeAggregateSpec uses Common Expression Language (CEL) to probe
+// all selected objects of a phase at once.
+// The rule has access to the list of selected objects as `objects`
+// and to referenced objects as `references.<alias>`,
+// which are null if the referenced object does not exist.
+// Rules accessing `objects` are skipped in phases without any selected objects.
+type ProbeAggregateSpec struct {
+	// CEL rule to evaluate.
+	// +example=objects.filter(o, o.status.availableReplicas > 0).size() >= 2
+	Rule string `json:"rule"`
+	// Error message to output if rule evaluates to false.
+	// +example=At least 2 Deployments must be available
+	Message string `json:"message"`
+	// Objects not part of the package to make available to the rule.
+	// Referenced objects are not watched,
+	// changes are picked up when the owning object is reconciled again.
+	References []ProbeObjectReference `json:"references,omitempty"`
+}
+
+// ProbeObjectReference references an object, that is not part of the package, by kind and name.
+type ProbeObjectReference struct {
+	// Alias to access the object by in CEL rules.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	// +example=database
+	Alias string `json:"alias"`
+	// API version of the object.
+	// +example=apps/v1
+	APIVersion string `json:"apiVersion"`
+	// Kind of the object.
+	// +example=Deployment
+	Kind string `json:"kind"`
+	// Name of the object.
+	// +example=example-database
+	Name string `json:"name"`
+	// Namespace of the object.
+	// Namespaced APIs can only reference objects in their own namespace
+	// and default to it.
+	// +example=example-namespace
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // PreviousRevisionReference references a previous revision of an ObjectSet or ClusterObjectSet.
 type PreviousRevisionReference struct {
 	// Name of a previous revision.
@@ -241,3 +424,26 @@ type ControlledObjectReference struct {
 	// Object Namespace.
 	Namespace string `json:"namespace,omitempty"`
 }
+
+// ProbeResult reports an availability probe failing for an object.
+type ProbeResult struct {
+	// Name of the phase containing the probed object.
+	// +example=deploy
+	Phase string `json:"phase"`
+	// Object the probe is failing for.
+	// Unset for aggregate probes, which probe all objects of a phase at once.
+	Object *ControlledObjectReference `json:"object,omitempty"`
+	// Type of the failing probe.
+	// One of Condition, FieldsEqual, CEL, HTTPGet, TCPSocket, ObservedGeneration, Aggregate or Custom
+	// and NotFound, if the object does not exist.
+	// +example=Condition
+	Type string `json:"type"`
+	// Message describing why the probe is failing.
+	// +example=wrong status
+	Message string `json:"message"`
+	// Time the probe started failing.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// ProbeResultTypeNotFound is the ProbeResult type reported for objects that do not exist.
+const ProbeResultTypeNotFound = "NotFound"
@@ -17,8 +17,29 @@ type PackageStatus struct {
 	UnpackedHash string `json:"unpackedHash,omitempty"`
 	// Package revision as reported by the ObjectDeployment.
 	Revision int64 `json:"revision,omitempty"`
+	// Version tracked from the package repository.
+	// Only set, if the package version is tracked from a repository.
+	// +optional
+	Track *PackageTrackStatus `json:"track,omitempty"`
+	// Hash of image + config that is held back by the upgrade policy.
+	// Approve it by setting the package-operator.run/approved-hash annotation to this value.
+	PendingHash string `json:"pendingHash,omitempty"`
+}
+
+// PackageTrackStatus describes the package version resolved from a package repository.
+type PackageTrackStatus struct {
+	// Semver version resolved from the repository.
+	Version string `json:"version,omitempty"`
+	// Image of the resolved version, referenced by digest.
+	Image string `json:"image,omitempty"`
+	// Time the repository was last checked for new versions.
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
 }
 
+// PackageApprovedHashAnnotation approves the pending change of a Package
+// that requires manual approval, when set to the value of .status.pendingHash.
+const PackageApprovedHashAnnotation = "package-operator.run/approved-hash"
+
 // Package condition types.
 const (
 	// A Packages "Available" condition tracks the availability of the underlying ObjectDeployment objects.
@@ -29,6 +50,12 @@ const (
 	PackageProgressing = "Progressing"
 	// Unpacked tracks the completion or failure of the image unpack operation.
 	PackageUnpacked = "Unpacked"
+	// DependenciesAvailable tracks the availability of Packages installed as dependencies.
+	PackageDependenciesAvailable = "DependenciesAvailable"
+	// VersionResolved tracks resolving the package version from a package repository.
+	PackageVersionResolved = "VersionResolved"
+	// UpgradePending is True while a change of the Package is held back by its upgrade policy.
+	PackageUpgradePending = "UpgradePending"
 	// Invalid condition tracks unrecoverable validation and loading issues of the Package.
 	// A package might be invalid because of multiple reasons:
 	// - Does not support the right scope -> Namespaced vs. Cluster
@@ -53,16 +80,145 @@ const (
 )
 
 // PackageSpec specifies a package.
+// +kubebuilder:validation:XValidation:rule="has(self.image) != has(self.track)", message="exactly one of image or track must be set"
 type PackageSpec struct {
 	// the image containing the contents of the package
 	// this image will be unpacked by the package-loader to render
 	// the ObjectDeployment for propagating the installation of the package.
-	// +kubebuilder:validation:Required
-	Image string `json:"image"`
+	// Required, unless the package version is tracked from a repository.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Tracks the latest version of a package within a version range from a package repository,
+	// instead of installing a fixed image.
+	// +optional
+	Track *PackageTrack `json:"track,omitempty"`
 	// Package configuration parameters.
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Config *runtime.RawExtension `json:"config,omitempty"`
 	// Desired component to deploy from multi-component packages.
 	// +optional
 	Component string `json:"component,omitempty"`
+	// Strategy to roll out new revisions of the package with.
+	// Passed on to the ObjectDeployment of the package.
+	// +optional
+	RolloutStrategy *ObjectDeploymentRolloutStrategy `json:"rolloutStrategy,omitempty"`
+	// Secrets holding credentials to pull the package image and its dependencies.
+	// Secrets are looked up in the namespace of the Package or,
+	// for ClusterPackages, in the namespace Package Operator is running in.
+	// Defaults to the image pull secrets configured for Package Operator.
+	// Passed on to dependencies installed into the same namespace.
+	// +optional
+	ImagePullSecrets []ImagePullSecretReference `json:"imagePullSecrets,omitempty"`
+	// ConfigMaps and Secrets to load package configuration parameters from.
+	// Sources are merged in order, later sources take precedence over earlier ones.
+	// Inline config is merged last and takes precedence over all sources.
+	// Changes to referenced objects are picked up automatically.
+	// +optional
+	ConfigFrom []PackageConfigSource `json:"configFrom,omitempty"`
+	// Controls when changes to an installed package are rolled out.
+	// Changes are rolled out immediately, if not set.
+	// +optional
+	UpgradePolicy *PackageUpgradePolicy `json:"upgradePolicy,omitempty"`
+}
+
+// PackageTrack references a package in a package repository.
+type PackageTrack struct {
+	// Image of the package repository.
+	// +example=quay.io/package-operator/repository:latest
+	Repository string `json:"repository"`
+	// Name of the package in the repository.
+	// +example=nginx
+	Package string `json:"package"`
+	// Semver range of versions to install.
+	// The latest version in the repository is installed, if empty.
+	// +example=~1.2
+	// +optional
+	Range string `json:"range,omitempty"`
+	// Interval in which the repository is checked for new versions.
+	// Defaults to 10m.
+	// +example=1h
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// PackageUpgradePolicy controls when changes to the image or configuration
+// of an installed package are rolled out.
+// The initial installation of a package is never held back.
+type PackageUpgradePolicy struct {
+	// Time windows in which changes may be rolled out.
+	// Changes made outside of all windows are held back until the next window opens.
+	// Changes may be rolled out at any time, if empty.
+	// +optional
+	MaintenanceWindows []PackageMaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// Approval mode for changes.
+	// Manual holds back changes until the pending change is approved
+	// via the package-operator.run/approved-hash annotation or `kubectl package rollout approve`.
+	// +kubebuilder:default=Automatic
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// +optional
+	Approval PackageUpgradeApproval `json:"approval,omitempty"`
+}
+
+// PackageUpgradeApproval defines if changes need to be approved before they are rolled out.
+type PackageUpgradeApproval string
+
+const (
+	// PackageUpgradeApprovalAutomatic rolls out changes without approval.
+	PackageUpgradeApprovalAutomatic PackageUpgradeApproval = "Automatic"
+	// PackageUpgradeApprovalManual holds back changes until they are approved.
+	PackageUpgradeApprovalManual PackageUpgradeApproval = "Manual"
+)
+
+// PackageMaintenanceWindow is a recurring time window.
+type PackageMaintenanceWindow struct {
+	// Cron schedule in the standard 5 field format, marking the start of the window.
+	// +example=0 2 * * SAT
+	Schedule string `json:"schedule"`
+	// Duration of the window.
+	// +example=4h
+	Duration metav1.Duration `json:"duration"`
+	// IANA time zone the schedule is interpreted in. Defaults to UTC.
+	// +example=Europe/Berlin
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// PackageConfigSource references a key of a ConfigMap or Secret
+// to load package configuration parameters from.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)", message="exactly one of configMapKeyRef or secretKeyRef must be set"
+type PackageConfigSource struct {
+	// Selects a key of a ConfigMap.
+	// +optional
+	ConfigMapKeyRef *PackageConfigKeySelector `json:"configMapKeyRef,omitempty"`
+	// Selects a key of a Secret.
+	// +optional
+	SecretKeyRef *PackageConfigKeySelector `json:"secretKeyRef,omitempty"`
+	// Path in the package configuration to store the value at as a string.
+	// If empty, the value must contain a YAML or JSON object,
+	// which is merged into the root of the package configuration.
+	// +example=.database.password
+	// +optional
+	Destination string `json:"destination,omitempty"`
+}
+
+// PackageConfigKeySelector selects a key of a ConfigMap or Secret.
+type PackageConfigKeySelector struct {
+	// Name of the object.
+	Name string `json:"name"`
+	// Namespace of the object.
+	// Packages can only reference objects in their own namespace.
+	// Defaults to the namespace of the Package or,
+	// for ClusterPackages, to the namespace Package Operator is running in.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Key in the data of the object.
+	Key string `json:"key"`
+}
+
+// ImagePullSecretReference references a Secret of type
+// kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg.
+type ImagePullSecretReference struct {
+	// Name of the Secret.
+	// +example=registry-credentials
+	Name string `json:"name"`
 }
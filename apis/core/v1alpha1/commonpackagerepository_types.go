@@ -0,0 +1,53 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// PackageRepositorySpec specification.
+type PackageRepositorySpec struct {
+	// Image of the package repository.
+	// +example=quay.io/package-operator/repository:latest
+	Image string `json:"image"`
+	// Secrets holding credentials to pull the repository image.
+	// Secrets are looked up in the namespace of the PackageRepository or,
+	// for ClusterPackageRepositories, in the namespace Package Operator is running in.
+	// Defaults to the image pull secrets configured for Package Operator.
+	// +optional
+	ImagePullSecrets []ImagePullSecretReference `json:"imagePullSecrets,omitempty"`
+	// Interval in which the repository image is checked for changes.
+	// Defaults to 10m.
+	// +example=1h
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// PackageRepositoryStatus defines the observed state of a PackageRepository.
+type PackageRepositoryStatus struct {
+	// Conditions is a list of status conditions of this object.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Name of the repository, as stored in the repository image.
+	RepositoryName string `json:"repositoryName,omitempty"`
+	// Digest of the last loaded repository image.
+	Digest string `json:"digest,omitempty"`
+	// Last time the repository image was checked for changes.
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// Packages available in the repository, ordered by name.
+	Packages []PackageRepositoryPackage `json:"packages,omitempty"`
+}
+
+// PackageRepositoryPackage lists the available versions of a package in a repository.
+type PackageRepositoryPackage struct {
+	// Name of the package.
+	// +example=nginx
+	Name string `json:"name"`
+	// Image of the package.
+	// +example=quay.io/package-operator/nginx
+	Image string `json:"image,omitempty"`
+	// Available versions of the package, ordered from latest to oldest.
+	Versions []string `json:"versions,omitempty"`
+}
+
+// PackageRepository condition types.
+const (
+	// Loaded tracks if the repository image was successfully pulled and loaded.
+	PackageRepositoryLoaded = "Loaded"
+)
@@ -4,6 +4,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ObjectDeploymentRollbackToRevisionAnnotation requests the ObjectDeployment to roll back
+// to the template of the ObjectSet with the given revision number.
+// The annotation is removed by the controller after the rollback was applied.
+const ObjectDeploymentRollbackToRevisionAnnotation = "package-operator.run/rollback-to-revision"
+
 // ObjectDeploymentSpec defines the desired state of a ObjectDeployment.
 type ObjectDeploymentSpec struct {
 	// Number of old revisions in the form of archived ObjectSets to keep.
@@ -13,6 +18,25 @@ type ObjectDeploymentSpec struct {
 	Selector metav1.LabelSelector `json:"selector"`
 	// Template to create new ObjectSets from.
 	Template ObjectSetTemplate `json:"template"`
+	// Strategy to roll out new revisions with.
+	// +optional
+	RolloutStrategy *ObjectDeploymentRolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// ObjectDeploymentRolloutStrategy configures how new revisions are rolled out.
+type ObjectDeploymentRolloutStrategy struct {
+	// Maximum time in seconds for a new revision to become Available,
+	// before it is considered to have failed.
+	// Revisions are not considered failed, if unset.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+	// AutoRollback pauses a revision that failed to become Available within
+	// the progress deadline and rolls out the template of the last
+	// successful revision again.
+	// Requires ProgressDeadlineSeconds to be set.
+	// +optional
+	AutoRollback bool `json:"autoRollback,omitempty"`
 }
 
 // ObjectSetTemplate describes the template to create new ObjectSets from.
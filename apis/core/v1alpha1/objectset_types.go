@@ -70,6 +70,9 @@ type ObjectSetStatus struct {
 	RemotePhases []RemotePhaseReference `json:"remotePhases,omitempty"`
 	// References all objects controlled by this instance.
 	ControllerOf []ControlledObjectReference `json:"controllerOf,omitempty"`
+	// Availability probes currently failing.
+	// Only contains failures of the first phase that is not available.
+	ProbeResults []ProbeResult `json:"probeResults,omitempty"`
 }
 
 func init() { register(&ObjectSet{}, &ObjectSetList{}) }
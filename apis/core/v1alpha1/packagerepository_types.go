@@ -0,0 +1,28 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// PackageRepository makes the packages of a package repository image
+// available within a namespace.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pkgrepo
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.image"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PackageRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PackageRepositorySpec   `json:"spec,omitempty"`
+	Status PackageRepositoryStatus `json:"status,omitempty"`
+}
+
+// PackageRepositoryList contains a list of PackageRepositories.
+// +kubebuilder:object:root=true
+type PackageRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PackageRepository `json:"items"`
+}
+
+func init() { register(&PackageRepository{}, &PackageRepositoryList{}) }
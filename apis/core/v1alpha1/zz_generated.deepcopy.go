@@ -7,6 +7,7 @@ package v1alpha1
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -78,6 +79,11 @@ func (in *ClusterObjectDeploymentSpec) DeepCopyInto(out *ClusterObjectDeployment
 	}
 	in.Selector.DeepCopyInto(&out.Selector)
 	in.Template.DeepCopyInto(&out.Template)
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(ObjectDeploymentRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObjectDeploymentSpec.
@@ -289,6 +295,13 @@ func (in *ClusterObjectSetPhaseStatus) DeepCopyInto(out *ClusterObjectSetPhaseSt
 		*out = make([]ControlledObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ProbeResults != nil {
+		in, out := &in.ProbeResults, &out.ProbeResults
+		*out = make([]ProbeResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObjectSetPhaseStatus.
@@ -342,6 +355,13 @@ func (in *ClusterObjectSetStatus) DeepCopyInto(out *ClusterObjectSetStatus) {
 		*out = make([]ControlledObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ProbeResults != nil {
+		in, out := &in.ProbeResults, &out.ProbeResults
+		*out = make([]ProbeResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObjectSetStatus.
@@ -536,6 +556,65 @@ func (in *ClusterPackageList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPackageRepository) DeepCopyInto(out *ClusterPackageRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPackageRepository.
+func (in *ClusterPackageRepository) DeepCopy() *ClusterPackageRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPackageRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPackageRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPackageRepositoryList) DeepCopyInto(out *ClusterPackageRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPackageRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPackageRepositoryList.
+func (in *ClusterPackageRepositoryList) DeepCopy() *ClusterPackageRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPackageRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPackageRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConditionMapping) DeepCopyInto(out *ConditionMapping) {
 	*out = *in
@@ -566,6 +645,21 @@ func (in *ControlledObjectReference) DeepCopy() *ControlledObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretReference) DeepCopyInto(out *ImagePullSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretReference.
+func (in *ImagePullSecretReference) DeepCopy() *ImagePullSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectDeployment) DeepCopyInto(out *ObjectDeployment) {
 	*out = *in
@@ -625,6 +719,26 @@ func (in *ObjectDeploymentList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectDeploymentRolloutStrategy) DeepCopyInto(out *ObjectDeploymentRolloutStrategy) {
+	*out = *in
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectDeploymentRolloutStrategy.
+func (in *ObjectDeploymentRolloutStrategy) DeepCopy() *ObjectDeploymentRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectDeploymentRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectDeploymentSpec) DeepCopyInto(out *ObjectDeploymentSpec) {
 	*out = *in
@@ -635,6 +749,11 @@ func (in *ObjectDeploymentSpec) DeepCopyInto(out *ObjectDeploymentSpec) {
 	}
 	in.Selector.DeepCopyInto(&out.Selector)
 	in.Template.DeepCopyInto(&out.Template)
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(ObjectDeploymentRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectDeploymentSpec.
@@ -867,6 +986,13 @@ func (in *ObjectSetPhaseStatus) DeepCopyInto(out *ObjectSetPhaseStatus) {
 		*out = make([]ControlledObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ProbeResults != nil {
+		in, out := &in.ProbeResults, &out.ProbeResults
+		*out = make([]ProbeResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetPhaseStatus.
@@ -890,6 +1016,13 @@ func (in *ObjectSetProbe) DeepCopyInto(out *ObjectSetProbe) {
 		}
 	}
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Aggregate != nil {
+		in, out := &in.Aggregate, &out.Aggregate
+		*out = make([]ProbeAggregateSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetProbe.
@@ -943,6 +1076,13 @@ func (in *ObjectSetStatus) DeepCopyInto(out *ObjectSetStatus) {
 		*out = make([]ControlledObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ProbeResults != nil {
+		in, out := &in.ProbeResults, &out.ProbeResults
+		*out = make([]ProbeResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetStatus.
@@ -1258,6 +1398,46 @@ func (in *Package) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageConfigKeySelector) DeepCopyInto(out *PackageConfigKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageConfigKeySelector.
+func (in *PackageConfigKeySelector) DeepCopy() *PackageConfigKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageConfigKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageConfigSource) DeepCopyInto(out *PackageConfigSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(PackageConfigKeySelector)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(PackageConfigKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageConfigSource.
+func (in *PackageConfigSource) DeepCopy() *PackageConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageList) DeepCopyInto(out *PackageList) {
 	*out = *in
@@ -1290,6 +1470,22 @@ func (in *PackageList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageMaintenanceWindow) DeepCopyInto(out *PackageMaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageMaintenanceWindow.
+func (in *PackageMaintenanceWindow) DeepCopy() *PackageMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageProbeKindSpec) DeepCopyInto(out *PackageProbeKindSpec) {
 	*out = *in
@@ -1305,14 +1501,178 @@ func (in *PackageProbeKindSpec) DeepCopy() *PackageProbeKindSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepository) DeepCopyInto(out *PackageRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepository.
+func (in *PackageRepository) DeepCopy() *PackageRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryList) DeepCopyInto(out *PackageRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PackageRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryList.
+func (in *PackageRepositoryList) DeepCopy() *PackageRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryPackage) DeepCopyInto(out *PackageRepositoryPackage) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryPackage.
+func (in *PackageRepositoryPackage) DeepCopy() *PackageRepositoryPackage {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryPackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositorySpec) DeepCopyInto(out *PackageRepositorySpec) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]ImagePullSecretReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositorySpec.
+func (in *PackageRepositorySpec) DeepCopy() *PackageRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryStatus) DeepCopyInto(out *PackageRepositoryStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make([]PackageRepositoryPackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryStatus.
+func (in *PackageRepositoryStatus) DeepCopy() *PackageRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageSpec) DeepCopyInto(out *PackageSpec) {
 	*out = *in
+	if in.Track != nil {
+		in, out := &in.Track, &out.Track
+		*out = new(PackageTrack)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(ObjectDeploymentRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]ImagePullSecretReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigFrom != nil {
+		in, out := &in.ConfigFrom, &out.ConfigFrom
+		*out = make([]PackageConfigSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(PackageUpgradePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageSpec.
@@ -1335,6 +1695,11 @@ func (in *PackageStatus) DeepCopyInto(out *PackageStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Track != nil {
+		in, out := &in.Track, &out.Track
+		*out = new(PackageTrackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageStatus.
@@ -1347,6 +1712,65 @@ func (in *PackageStatus) DeepCopy() *PackageStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageTrack) DeepCopyInto(out *PackageTrack) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageTrack.
+func (in *PackageTrack) DeepCopy() *PackageTrack {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageTrack)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageTrackStatus) DeepCopyInto(out *PackageTrackStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageTrackStatus.
+func (in *PackageTrackStatus) DeepCopy() *PackageTrackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageTrackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageUpgradePolicy) DeepCopyInto(out *PackageUpgradePolicy) {
+	*out = *in
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]PackageMaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageUpgradePolicy.
+func (in *PackageUpgradePolicy) DeepCopy() *PackageUpgradePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageUpgradePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PreviousRevisionReference) DeepCopyInto(out *PreviousRevisionReference) {
 	*out = *in
@@ -1380,6 +1804,16 @@ func (in *Probe) DeepCopyInto(out *Probe) {
 		*out = new(ProbeCELSpec)
 		**out = **in
 	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(ProbeHTTPGetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCPSocket != nil {
+		in, out := &in.TCPSocket, &out.TCPSocket
+		*out = new(ProbeTCPSocketSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probe.
@@ -1392,6 +1826,26 @@ func (in *Probe) DeepCopy() *Probe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeAggregateSpec) DeepCopyInto(out *ProbeAggregateSpec) {
+	*out = *in
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]ProbeObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeAggregateSpec.
+func (in *ProbeAggregateSpec) DeepCopy() *ProbeAggregateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeAggregateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProbeCELSpec) DeepCopyInto(out *ProbeCELSpec) {
 	*out = *in
@@ -1437,6 +1891,92 @@ func (in *ProbeFieldsEqualSpec) DeepCopy() *ProbeFieldsEqualSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeHTTPBodySpec) DeepCopyInto(out *ProbeHTTPBodySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeHTTPBodySpec.
+func (in *ProbeHTTPBodySpec) DeepCopy() *ProbeHTTPBodySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeHTTPBodySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeHTTPGetSpec) DeepCopyInto(out *ProbeHTTPGetSpec) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(ProbeHTTPBodySpec)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeHTTPGetSpec.
+func (in *ProbeHTTPGetSpec) DeepCopy() *ProbeHTTPGetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeHTTPGetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeObjectReference) DeepCopyInto(out *ProbeObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeObjectReference.
+func (in *ProbeObjectReference) DeepCopy() *ProbeObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeResult) DeepCopyInto(out *ProbeResult) {
+	*out = *in
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(ControlledObjectReference)
+		**out = **in
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeResult.
+func (in *ProbeResult) DeepCopy() *ProbeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProbeSelector) DeepCopyInto(out *ProbeSelector) {
 	*out = *in
@@ -1462,6 +2002,31 @@ func (in *ProbeSelector) DeepCopy() *ProbeSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeTCPSocketSpec) DeepCopyInto(out *ProbeTCPSocketSpec) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeTCPSocketSpec.
+func (in *ProbeTCPSocketSpec) DeepCopy() *ProbeTCPSocketSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeTCPSocketSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemotePhaseReference) DeepCopyInto(out *RemotePhaseReference) {
 	*out = *in
@@ -21,6 +21,12 @@ const (
 	// PackageCollisionProtectionAnnotation prevents Package Operator from working
 	// on objects already under management by a different operator.
 	PackageCollisionProtectionAnnotation = "package-operator.run/collision-protection"
+	// PackageReconcileAnnotation controls how Package Operator reconciles the object.
+	// Set to ObserveOnly to report drift without reverting it.
+	PackageReconcileAnnotation = "package-operator.run/reconcile"
+	// PackageDeletionPolicyAnnotation controls what happens to the object when it is torn down.
+	// Set to Orphan or OrphanIfPopulated to keep user data when the Package is deleted.
+	PackageDeletionPolicyAnnotation = "package-operator.run/deletion-policy"
 )
 
 const (
@@ -32,6 +38,9 @@ const (
 	PackageConfigAnnotation = "package-operator.run/package-config"
 	// PackageInstanceLabel contains the name of the Package instance.
 	PackageInstanceLabel = "package-operator.run/instance"
+	// PackageDependencyOfLabel contains the name of the Package instance
+	// that installed this Package as a dependency.
+	PackageDependencyOfLabel = "package-operator.run/dependency-of"
 )
 
 // PackageManifest defines the manifest of a package.
@@ -146,6 +155,26 @@ type PackageManifestRepository struct {
 type PackageManifestDependency struct {
 	// Resolves the dependency as a image url and digest and commits it to the PackageManifestLock.
 	Image *PackageManifestDependencyImage `json:"image,omitempty"`
+	// Installs the locked dependency as a child Package.
+	// Without this setting the dependency is only resolved and can be used in templates.
+	Install *PackageManifestDependencyInstall `json:"install,omitempty"`
+}
+
+// PackageManifestDependencyInstall configures how a dependency is installed into the cluster.
+type PackageManifestDependencyInstall struct {
+	// Phase to install the dependency Package in.
+	// Later phases are blocked until the dependency reports Available.
+	// +example=dependencies
+	Phase string `json:"phase"`
+	// Namespace to install the dependency Package into.
+	// Only supported for ClusterPackages, a ClusterPackage is created if empty.
+	// Packages always install their dependencies into their own namespace.
+	// +example=my-namespace
+	Namespace string `json:"namespace,omitempty"`
+	// Top-level property of the package configuration
+	// which is passed through as configuration to the dependency.
+	// +example=myDependency
+	ConfigKey string `json:"configKey,omitempty"`
 }
 
 // PackageManifestDependencyImage represents a dependency image found by the solver.
@@ -223,6 +252,9 @@ type PackageManifestPhase struct {
 	// If set to any other string, an out-of-tree controller needs to be present to handle ObjectSetPhase objects.
 	// +example=hosted-cluster
 	Class string `json:"class,omitempty"`
+	// Marks this phase as a lifecycle hook, see ObjectSetTemplatePhase.
+	// +kubebuilder:validation:Enum=PreInstall;PreUpgrade;PostUpgrade;PreDelete;PostDelete
+	Hook corev1alpha1.ObjectSetPhaseHook `json:"hook,omitempty"`
 }
 
 // PackageManifestImage specifies an image tag to be resolved.
@@ -22,10 +22,12 @@ type Builder interface {
 
 func NewCmd(builderFactory BuilderFactory) *cobra.Command {
 	const (
-		buildUse   = "build source_path [--tag tag]... [--output output_path] [--push]"
+		buildUse = "build source_path [--tag tag]... [--output output_path] [--push [--sign-key key_path]]" +
+			" [--format image|artifact|index [--platform os/arch]...]"
 		buildShort = "build an PKO package image using manifests at the given path"
 		buildLong  = "builds and optionally pushes an OCI image in the Package Operator" +
-			" package format from the specified build context directory."
+			" package format from the specified build context directory," +
+			" OCI image layout directory or image tar."
 		buildSuccessMessage = "Package built successfully!"
 	)
 
@@ -48,6 +50,18 @@ func NewCmd(builderFactory BuilderFactory) *cobra.Command {
 		if (opts.OutputPath != "" || opts.Push) && len(opts.Tags) == 0 {
 			return fmt.Errorf("%w: output or push is requested but no tags are set", internalcmd.ErrInvalidArgs)
 		}
+		if opts.SignKey != "" && !opts.Push {
+			return fmt.Errorf("%w: signing is requested but push is not set", internalcmd.ErrInvalidArgs)
+		}
+		switch opts.Format {
+		case formatImage, formatArtifact:
+			if len(opts.Platforms) > 0 {
+				return fmt.Errorf("%w: platforms can only be set for the index format", internalcmd.ErrInvalidArgs)
+			}
+		case formatIndex:
+		default:
+			return fmt.Errorf("%w: unknown format %q", internalcmd.ErrInvalidArgs, opts.Format)
+		}
 		for _, ref := range opts.Tags {
 			if _, err = name.ParseReference(ref); err != nil {
 				return fmt.Errorf("invalid tag specified as parameter %s: %w", ref, err)
@@ -60,6 +74,9 @@ func NewCmd(builderFactory BuilderFactory) *cobra.Command {
 			internalcmd.WithOutputPath(opts.OutputPath),
 			internalcmd.WithPush(opts.Push),
 			internalcmd.WithTags(opts.Tags),
+			internalcmd.WithSignKey(opts.SignKey),
+			internalcmd.WithExportFormat(opts.Format),
+			internalcmd.WithPlatforms(opts.Platforms),
 		); err != nil {
 			return fmt.Errorf("building from source: %w", err)
 		}
@@ -73,10 +90,19 @@ func NewCmd(builderFactory BuilderFactory) *cobra.Command {
 	return cmd
 }
 
+const (
+	formatImage    = "image"
+	formatArtifact = "artifact"
+	formatIndex    = "index"
+)
+
 type options struct {
+	Format     string
 	Insecure   bool
 	OutputPath string
+	Platforms  []string
 	Push       bool
+	SignKey    string
 	Tags       []string
 }
 
@@ -100,6 +126,38 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 		o.Push,
 		"Push the created image tags. Defaults to false",
 	)
+	flags.StringVar(
+		&o.SignKey,
+		"sign-key",
+		o.SignKey,
+		strings.Join([]string{
+			"Path to an unencrypted PEM encoded private key to sign pushed images with.",
+			"Signatures are stored next to the image using the cosign tag scheme.",
+			"Requires --push. Defaults to none.",
+		}, " "),
+	)
+	flags.StringVar(
+		&o.Format,
+		"format",
+		formatImage,
+		strings.Join([]string{
+			"Format of the created image.",
+			"'image' creates a linux/amd64 container image,",
+			"'artifact' an OCI artifact with package specific media types",
+			"and 'index' a multi-platform image index.",
+			"Image indexes can only be pushed. Defaults to image.",
+		}, " "),
+	)
+	flags.StringSliceVar(
+		&o.Platforms,
+		"platform",
+		o.Platforms,
+		strings.Join([]string{
+			"Platforms to list in the image index, e.g. linux/arm64.",
+			"May be specified multiple times. Requires --format index.",
+			"Defaults to linux/amd64.",
+		}, " "),
+	)
 	flags.StringVarP(
 		&o.OutputPath,
 		"output",
@@ -92,6 +92,22 @@ func TestBuildPushWOTags(t *testing.T) {
 	require.Error(t, cmd.Execute())
 }
 
+func TestBuildSignWOPush(t *testing.T) {
+	t.Parallel()
+
+	factory := &builderFactoryMock{}
+	factory.On("Builder").Return(internalcmd.NewBuild())
+
+	cmd := NewCmd(factory)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.SetArgs([]string{".", "--tag", "chicken:oldest", "--sign-key", "key.pem"})
+
+	require.ErrorIs(t, cmd.Execute(), internalcmd.ErrInvalidArgs)
+}
+
 func TestBuildOutputWOTags(t *testing.T) {
 	t.Parallel()
 
@@ -155,3 +171,35 @@ func (m *builderFactoryMock) Builder() Builder {
 
 	return args.Get(0).(Builder)
 }
+
+func TestBuildInvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	factory := &builderFactoryMock{}
+	factory.On("Builder").Return(internalcmd.NewBuild())
+
+	cmd := NewCmd(factory)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.SetArgs([]string{".", "--format", "banana"})
+
+	require.ErrorIs(t, cmd.Execute(), internalcmd.ErrInvalidArgs)
+}
+
+func TestBuildPlatformWOIndex(t *testing.T) {
+	t.Parallel()
+
+	factory := &builderFactoryMock{}
+	factory.On("Builder").Return(internalcmd.NewBuild())
+
+	cmd := NewCmd(factory)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.SetArgs([]string{".", "--format", "artifact", "--platform", "linux/arm64"})
+
+	require.ErrorIs(t, cmd.Execute(), internalcmd.ErrInvalidArgs)
+}
@@ -1,6 +1,7 @@
 package deps
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -9,7 +10,10 @@ import (
 
 	"package-operator.run/cmd/kubectl-package/buildcmd"
 	clustertreecmd "package-operator.run/cmd/kubectl-package/clustertreecmd"
+	"package-operator.run/cmd/kubectl-package/diffcmd"
 	"package-operator.run/cmd/kubectl-package/kickstartcmd"
+	"package-operator.run/cmd/kubectl-package/mirrorcmd"
+	"package-operator.run/cmd/kubectl-package/probecmd"
 	"package-operator.run/cmd/kubectl-package/repocmd"
 	"package-operator.run/cmd/kubectl-package/rolloutcmd"
 	"package-operator.run/cmd/kubectl-package/rootcmd"
@@ -18,6 +22,7 @@ import (
 	"package-operator.run/cmd/kubectl-package/validatecmd"
 	"package-operator.run/cmd/kubectl-package/versioncmd"
 	internalcmd "package-operator.run/internal/cmd"
+	internalprobing "package-operator.run/internal/probing"
 )
 
 func ProvideIOStreams() rootcmd.IOStreams {
@@ -73,6 +78,78 @@ func (f *defaultRendererFactory) Renderer() treecmd.Renderer {
 	)
 }
 
+func ProvideDiffCmd(
+	clientFactory internalcmd.ClientFactory, differFactory diffcmd.DifferFactory,
+) RootSubCommandResult {
+	return RootSubCommandResult{
+		SubCommand: diffcmd.NewCmd(clientFactory, differFactory),
+	}
+}
+
+func ProvideDifferFactory(scheme *runtime.Scheme, f LogFactory) diffcmd.DifferFactory {
+	return &defaultDifferFactory{
+		logFactory: f,
+		scheme:     scheme,
+	}
+}
+
+type defaultDifferFactory struct {
+	logFactory LogFactory
+	scheme     *runtime.Scheme
+}
+
+func (f *defaultDifferFactory) Differ() diffcmd.Differ {
+	return internalcmd.NewDiff(
+		f.scheme,
+		internalcmd.WithLog{
+			Log: f.logFactory.Logger(),
+		},
+	)
+}
+
+func ProvideProbeCmd(
+	clientFactory internalcmd.ClientFactory, proberFactory probecmd.ProberFactory,
+) RootSubCommandResult {
+	return RootSubCommandResult{
+		SubCommand: probecmd.NewCmd(clientFactory, proberFactory),
+	}
+}
+
+func ProvideProberFactory(cfgFactory internalcmd.RestConfigFactory, f LogFactory) probecmd.ProberFactory {
+	return &defaultProberFactory{
+		cfgFactory: cfgFactory,
+		logFactory: f,
+	}
+}
+
+type defaultProberFactory struct {
+	cfgFactory internalcmd.RestConfigFactory
+	logFactory LogFactory
+}
+
+func (f *defaultProberFactory) Prober() (probecmd.Prober, error) {
+	cfg, err := f.cfgFactory.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("getting rest config: %w", err)
+	}
+
+	// HTTP probes have to go through the API server,
+	// as Services are not reachable from outside the cluster.
+	endpoints, err := internalprobing.NewServiceProxyEndpoints(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return internalcmd.NewProbe(
+		internalcmd.WithLog{
+			Log: f.logFactory.Logger(),
+		},
+		internalcmd.WithEndpoints{
+			Endpoints: endpoints,
+		},
+	), nil
+}
+
 func ProvideUpdateCmd(updater updatecmd.Updater) RootSubCommandResult {
 	return RootSubCommandResult{
 		SubCommand: updatecmd.NewCmd(
@@ -139,6 +216,12 @@ func ProvideRepoCmd() RootSubCommandResult {
 	}
 }
 
+func ProvideMirrorCmd() RootSubCommandResult {
+	return RootSubCommandResult{
+		SubCommand: mirrorcmd.NewCmd(),
+	}
+}
+
 func ProvideRolloutCmd(params rolloutcmd.Params) RootSubCommandResult {
 	return RootSubCommandResult{
 		SubCommand: rolloutcmd.NewRolloutCmd(params),
@@ -163,6 +246,18 @@ func ProvideRolloutHistoryCmd(clientFactory internalcmd.ClientFactory) RolloutSu
 	}
 }
 
+func ProvideRolloutUndoCmd(clientFactory internalcmd.ClientFactory) RolloutSubCommandResult {
+	return RolloutSubCommandResult{
+		SubCommand: rolloutcmd.NewUndoCmd(clientFactory),
+	}
+}
+
+func ProvideRolloutApproveCmd(clientFactory internalcmd.ClientFactory) RolloutSubCommandResult {
+	return RolloutSubCommandResult{
+		SubCommand: rolloutcmd.NewApproveCmd(clientFactory),
+	}
+}
+
 func ProvideClientFactory(kcliFactory internalcmd.KubeClientFactory) internalcmd.ClientFactory {
 	return internalcmd.NewDefaultClientFactory(kcliFactory)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
 )
 
 func TestDefaultBuilderFactory(t *testing.T) {
@@ -36,6 +37,44 @@ func TestDefaultRendererFactory(t *testing.T) {
 	require.NotNil(t, factory.Renderer())
 }
 
+func TestDefaultDifferFactory(t *testing.T) {
+	t.Parallel()
+
+	logFactoryMock := &logFactoryMock{}
+	logFactoryMock.On("Logger").Return(logr.Discard())
+
+	factory := &defaultDifferFactory{
+		scheme:     runtime.NewScheme(),
+		logFactory: logFactoryMock,
+	}
+
+	require.NotNil(t, factory.Differ())
+}
+
+func TestDefaultProberFactory(t *testing.T) {
+	t.Parallel()
+
+	logFactoryMock := &logFactoryMock{}
+	logFactoryMock.On("Logger").Return(logr.Discard())
+
+	factory := &defaultProberFactory{
+		cfgFactory: &restConfigFactoryMock{Config: &rest.Config{Host: "https://localhost:6443"}},
+		logFactory: logFactoryMock,
+	}
+
+	prober, err := factory.Prober()
+	require.NoError(t, err)
+	require.NotNil(t, prober)
+}
+
+type restConfigFactoryMock struct {
+	Config *rest.Config
+}
+
+func (m *restConfigFactoryMock) GetConfig() (*rest.Config, error) {
+	return m.Config, nil
+}
+
 type logFactoryMock struct {
 	mock.Mock
 }
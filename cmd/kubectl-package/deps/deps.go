@@ -25,6 +25,10 @@ func constructors() []any {
 		ProvideArgs,
 		ProvideTreeCmd,
 		ProvideClusterTreeCmd,
+		ProvideDiffCmd,
+		ProvideDifferFactory,
+		ProvideProbeCmd,
+		ProvideProberFactory,
 		ProvideUpdateCmd,
 		ProvideValidateCmd,
 		ProvideBuildCmd,
@@ -40,7 +44,10 @@ func constructors() []any {
 		ProvideRolloutCmd,
 		ProvideClientFactory,
 		ProvideRolloutHistoryCmd,
+		ProvideRolloutUndoCmd,
+		ProvideRolloutApproveCmd,
 		ProvideRepoCmd,
+		ProvideMirrorCmd,
 		ProvideKickstartCmd,
 		ProvideKickstarter,
 	}
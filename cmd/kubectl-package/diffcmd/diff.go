@@ -0,0 +1,116 @@
+package diffcmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+type DifferFactory interface {
+	Differ() Differ
+}
+
+type Differ interface {
+	DiffPackage(
+		ctx context.Context, c *internalcmd.Client, srcRef, name string, opts ...internalcmd.DiffPackageOption,
+	) (*internalcmd.DiffResult, error)
+}
+
+func NewCmd(clientFactory internalcmd.ClientFactory, differFactory DifferFactory) *cobra.Command {
+	const (
+		cmdUse   = "diff package_image package_name"
+		cmdShort = "previews the changes a package update would make in-cluster"
+		cmdLong  = "renders the package image with the configuration of an existing (Cluster)Package " +
+			"and prints a diff against the live objects of its current revision"
+	)
+
+	var opts options
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(2),
+		Use:   cmdUse,
+		Short: cmdShort,
+		Long:  cmdLong,
+	}
+	opts.AddFlags(cmd.Flags())
+
+	cmd.MarkFlagsMutuallyExclusive("namespace", "cluster")
+	cmd.MarkFlagsOneRequired("namespace", "cluster")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		client, err := clientFactory.Client()
+		if err != nil {
+			return err
+		}
+
+		res, err := differFactory.Differ().DiffPackage(
+			cmd.Context(), client, args[0], args[1],
+			internalcmd.WithNamespace(opts.Namespace),
+			internalcmd.WithConfigPath(opts.ConfigPath),
+			internalcmd.WithComponent(opts.Component),
+			internalcmd.WithInsecure(opts.Insecure),
+		)
+		if err != nil {
+			return fmt.Errorf("diffing package: %w", err)
+		}
+
+		_, err = fmt.Fprint(cmd.OutOrStdout(), res.String())
+
+		return err
+	}
+
+	return cmd
+}
+
+type options struct {
+	ClusterScope bool
+	Namespace    string
+	ConfigPath   string
+	Component    string
+	Insecure     bool
+}
+
+func (o *options) AddFlags(flags *pflag.FlagSet) {
+	const (
+		clusterScopeUse = "diff against a ClusterPackage"
+		namespaceUse    = "diff against a Package in the given namespace"
+		configPathUse   = "file containing config which is used for templating instead of the config of the Package"
+		componentUse    = "select which component to render instead of the component of the Package"
+		insecureUse     = "allows pulling images without TLS or using TLS with unverified certificates"
+	)
+
+	flags.BoolVar(
+		&o.ClusterScope,
+		"cluster",
+		o.ClusterScope,
+		clusterScopeUse,
+	)
+	flags.StringVarP(
+		&o.Namespace,
+		"namespace",
+		"n",
+		o.Namespace,
+		namespaceUse,
+	)
+	flags.StringVar(
+		&o.ConfigPath,
+		"config-path",
+		o.ConfigPath,
+		configPathUse,
+	)
+	flags.StringVar(
+		&o.Component,
+		"component",
+		o.Component,
+		componentUse,
+	)
+	flags.BoolVar(
+		&o.Insecure,
+		"insecure",
+		o.Insecure,
+		insecureUse,
+	)
+}
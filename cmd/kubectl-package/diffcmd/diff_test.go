@@ -0,0 +1,121 @@
+package diffcmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+var errTest = errors.New("test error")
+
+func TestDiffCmd(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Args       []string
+		Result     *internalcmd.DiffResult
+		Err        error
+		Output     string
+		ShouldFail bool
+	}{
+		"no args": {
+			ShouldFail: true,
+		},
+		"too many args": {
+			Args:       []string{"1", "2", "3", "--cluster"},
+			ShouldFail: true,
+		},
+		"missing scope": {
+			Args:       []string{"quay.io/test/test:v1", "test"},
+			ShouldFail: true,
+		},
+		"namespace and cluster": {
+			Args:       []string{"quay.io/test/test:v1", "test", "--cluster", "-n", "test"},
+			ShouldFail: true,
+		},
+		"differ error": {
+			Args:       []string{"quay.io/test/test:v1", "test", "-n", "test"},
+			Err:        errTest,
+			ShouldFail: true,
+		},
+		"no changes": {
+			Args: []string{"quay.io/test/test:v1", "test", "--cluster"},
+			Result: &internalcmd.DiffResult{
+				Objects: []internalcmd.ObjectDiff{
+					{Key: "ConfigMap /test", Action: internalcmd.DiffActionUnchanged},
+				},
+			},
+			Output: "0 added, 0 changed, 0 removed, 1 unchanged\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme, err := internalcmd.NewScheme()
+			require.NoError(t, err)
+
+			c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			differ := &differMock{}
+			differ.
+				On("DiffPackage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(tc.Result, tc.Err)
+
+			cmd := NewCmd(
+				internalcmd.NewDefaultClientFactory(&kubeClientFactoryMock{Client: c}),
+				&differFactoryMock{differ: differ},
+			)
+			cmd.SetArgs(tc.Args)
+
+			stdout := &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			if tc.ShouldFail {
+				require.Error(t, cmd.Execute())
+
+				return
+			}
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tc.Output, stdout.String())
+		})
+	}
+}
+
+type kubeClientFactoryMock struct {
+	Client client.Client
+}
+
+func (m *kubeClientFactoryMock) GetKubeClient() (client.Client, error) {
+	return m.Client, nil
+}
+
+type differFactoryMock struct {
+	differ Differ
+}
+
+func (m *differFactoryMock) Differ() Differ {
+	return m.differ
+}
+
+type differMock struct {
+	mock.Mock
+}
+
+func (m *differMock) DiffPackage(
+	ctx context.Context, c *internalcmd.Client, srcRef, name string, opts ...internalcmd.DiffPackageOption,
+) (*internalcmd.DiffResult, error) {
+	args := m.Called(ctx, c, srcRef, name, opts)
+	res, _ := args.Get(0).(*internalcmd.DiffResult)
+
+	return res, args.Error(1)
+}
@@ -6,12 +6,14 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+
+	internalcmd "package-operator.run/internal/cmd"
 )
 
 type Kickstarter interface {
 	Kickstart(
 		ctx context.Context, pkgName string,
-		inputs []string, olmBundle string,
+		inputs []string, olmBundle, helmChart string,
 		paramOpts []string,
 	) (msg string, err error)
 }
@@ -21,7 +23,8 @@ func NewCmd(kickstarter Kickstarter) *cobra.Command {
 		cmdUse   = "kickstart pkg_name (experimental)"
 		cmdShort = "Starts a new package with the given name."
 		cmdLong  = "Starts a new package, containing objects referenced via -f " +
-			"or from an OLM Bundle referenced via -b, " +
+			"or from an OLM Bundle referenced via -b " +
+			"or converted from a Helm chart referenced via --from-helm, " +
 			"with the given name in a new folder <pkg_name>."
 	)
 
@@ -36,8 +39,13 @@ func NewCmd(kickstarter Kickstarter) *cobra.Command {
 	opts.AddFlags(cmd.Flags())
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(opts.HelmChart) > 0 && (len(opts.Inputs) > 0 || len(opts.OLMBundle) > 0) {
+			return fmt.Errorf("%w: --from-helm can not be combined with --filename or --olm-bundle",
+				internalcmd.ErrInvalidArgs)
+		}
+
 		msg, err := kickstarter.Kickstart(cmd.Context(), args[0],
-			opts.Inputs, opts.OLMBundle, opts.ParamOpts)
+			opts.Inputs, opts.OLMBundle, opts.HelmChart, opts.ParamOpts)
 		if err != nil {
 			return fmt.Errorf("kickstarting package: %w", err)
 		}
@@ -53,6 +61,8 @@ type options struct {
 	Inputs []string
 	// OLM Bundle image reference.
 	OLMBundle string
+	// Helm chart directory or archive.
+	HelmChart string
 	ParamOpts []string
 }
 
@@ -62,6 +72,8 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 			`Supports glob and "-" to read from stdin. Can be supplied multiple times.`
 		olmBundleUse = "OLM Bundle OCI to import. e.g. quay.io/xx/xxx:tag. " +
 			"Overrides the output package name with the bundle's name."
+		helmChartUse = "Helm chart folder or .tgz archive to convert. " +
+			"Templates that can not be translated automatically are placed into the untranslated folder."
 		parametrizeUse = "Parametrize flags: e.g. replicas."
 	)
 
@@ -86,4 +98,10 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 		"",
 		olmBundleUse,
 	)
+	flags.StringVar(
+		&o.HelmChart,
+		"from-helm",
+		"",
+		helmChartUse,
+	)
 }
@@ -0,0 +1,377 @@
+package mirrorcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"package-operator.run/internal/cli"
+	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/packages"
+	"package-operator.run/internal/utils"
+)
+
+// Annotation storing the original reference of images mirrored into an OCI layout.
+const refNameAnnotation = "org.opencontainers.image.ref.name"
+
+func NewCmd() *cobra.Command {
+	return newCmd()
+}
+
+func newCmd(craneOpts ...crane.Option) *cobra.Command {
+	const (
+		mirrorUse   = "mirror package_image (--to registry | --to-dir oci_layout_path) [--mapping-file path]"
+		mirrorShort = "mirror a package image and all images it references"
+		mirrorLong  = "copies a package image, all images referenced in its manifest lock," +
+			" all locked dependency packages and the signatures of all these images" +
+			" to another registry or into an OCI layout directory." +
+			" Image references are kept as they are, only the registry host changes." +
+			" The resulting mapping of registry hosts can be passed to the" +
+			" registryHostOverrides of the Package Operator manager."
+	)
+
+	cmd := &cobra.Command{
+		Use:   mirrorUse,
+		Short: mirrorShort,
+		Long:  mirrorLong,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	var opts options
+
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		src := args[0]
+
+		switch {
+		case src == "":
+			return fmt.Errorf("%w: package image must be not empty", internalcmd.ErrInvalidArgs)
+		case (opts.To == "") == (opts.ToDir == ""):
+			return fmt.Errorf("%w: exactly one of --to or --to-dir must be set", internalcmd.ErrInvalidArgs)
+		case opts.MappingFile != "" && opts.To == "":
+			return fmt.Errorf("%w: --mapping-file requires --to", internalcmd.ErrInvalidArgs)
+		}
+		if opts.To != "" {
+			if _, err := name.NewRegistry(opts.To); err != nil {
+				return fmt.Errorf("%w: invalid registry %q: %w", internalcmd.ErrInvalidArgs, opts.To, err)
+			}
+		}
+
+		ctx := cmd.Context()
+		craneOpts := append(slices.Clone(craneOpts), crane.WithContext(ctx))
+		if opts.Insecure {
+			craneOpts = append(craneOpts, crane.Insecure)
+		}
+
+		refs, err := collectImages(ctx, src, craneOpts...)
+		if err != nil {
+			return err
+		}
+
+		printer := cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()})
+		m := &mirrorer{craneOpts: craneOpts}
+		if opts.ToDir != "" {
+			if err := m.toDir(opts.ToDir, refs); err != nil {
+				return err
+			}
+			for _, ref := range refs {
+				if err := printer.PrintfOut("mirrored %s\n", ref); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		mirrored, err := m.toRegistry(opts.To, refs)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			if err := printer.PrintfOut("mirrored %s to %s\n", ref, mirrored[ref]); err != nil {
+				return err
+			}
+		}
+
+		mapping, err := hostMapping(opts.To, refs)
+		if err != nil {
+			return err
+		}
+		if opts.MappingFile == "" {
+			return printer.PrintfOut("registryHostOverrides: %s\n", mapping)
+		}
+		if err := os.WriteFile(opts.MappingFile, []byte(mapping+"\n"), 0o600); err != nil {
+			return fmt.Errorf("writing mapping file: %w", err)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+type options struct {
+	Insecure    bool
+	MappingFile string
+	To          string
+	ToDir       string
+}
+
+func (o *options) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Insecure,
+		"insecure",
+		o.Insecure,
+		"Allows pulling and pushing images without TLS or using TLS with unverified certificates.",
+	)
+	flags.StringVar(
+		&o.To,
+		"to",
+		o.To,
+		"Registry host to copy all images to, keeping their repository paths, e.g. mirror.example.com:5000.",
+	)
+	flags.StringVar(
+		&o.ToDir,
+		"to-dir",
+		o.ToDir,
+		strings.Join([]string{
+			"Path of an OCI image layout directory to copy all images into.",
+			"The directory is created if it does not exist.",
+			"Images are annotated with their original reference",
+			"and the package image is marked, so the directory can be used as package source.",
+		}, " "),
+	)
+	flags.StringVar(
+		&o.MappingFile,
+		"mapping-file",
+		o.MappingFile,
+		strings.Join([]string{
+			"File to write the registry host mapping to, in the format of the registryHostOverrides",
+			"of the Package Operator manager. Requires --to. Defaults to printing the mapping.",
+		}, " "),
+	)
+}
+
+// Collects the given package image and all images it references,
+// including all locked dependency packages and their images,
+// followed by the signatures of all collected images.
+// The given package image is always returned first.
+// References of locked images are returned by digest.
+func collectImages(ctx context.Context, src string, craneOpts ...crane.Option) ([]string, error) {
+	var (
+		refs    []string
+		visited = map[string]struct{}{}
+	)
+
+	queue := []string{src}
+	for len(queue) > 0 {
+		pkgRef := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[pkgRef]; ok {
+			continue
+		}
+		visited[pkgRef] = struct{}{}
+		refs = append(refs, pkgRef)
+
+		images, dependencies, err := lockedImages(ctx, pkgRef, craneOpts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range images {
+			if _, ok := visited[ref]; ok {
+				continue
+			}
+			visited[ref] = struct{}{}
+			refs = append(refs, ref)
+		}
+		queue = append(queue, dependencies...)
+	}
+
+	signatures, err := signatureTags(refs, craneOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return append(refs, signatures...), nil
+}
+
+// Returns the tags of the cosign signatures stored next to the given images.
+// Images without signatures are skipped.
+func signatureTags(refs []string, craneOpts ...crane.Option) ([]string, error) {
+	var tags []string
+	for _, ref := range refs {
+		parsed, err := name.ParseReference(ref)
+		if err != nil {
+			return nil, fmt.Errorf("parsing image reference %q: %w", ref, err)
+		}
+		digest, ok := parsed.(name.Digest)
+		if !ok {
+			rawDigest, err := crane.Digest(ref, craneOpts...)
+			if err != nil {
+				return nil, fmt.Errorf("resolving digest of %s: %w", ref, err)
+			}
+			digest = parsed.Context().Digest(rawDigest)
+		}
+
+		tag, err := packages.SignatureTag(digest)
+		if err != nil {
+			return nil, err
+		}
+		_, err = crane.Head(tag.String(), craneOpts...)
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("looking up signatures of %s: %w", ref, err)
+		}
+		tags = append(tags, tag.String())
+	}
+	return tags, nil
+}
+
+// Pulls the given package and returns the digest references
+// of all images and dependency packages in its manifest locks.
+func lockedImages(ctx context.Context, pkgRef string, craneOpts ...crane.Option) (
+	images, dependencies []string, err error,
+) {
+	rawPkg, err := packages.FromRegistry(ctx, pkgRef, craneOpts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pulling package %s: %w", pkgRef, err)
+	}
+
+	pkg, err := packages.DefaultStructuralLoader.Load(ctx, rawPkg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading package %s: %w", pkgRef, err)
+	}
+
+	// Components are locked individually.
+	for _, p := range append([]packages.Package{*pkg}, pkg.Components...) {
+		if p.ManifestLock == nil {
+			continue
+		}
+		for _, img := range p.ManifestLock.Spec.Images {
+			ref, err := digestReference(img.Image, img.Digest)
+			if err != nil {
+				return nil, nil, err
+			}
+			images = append(images, ref)
+		}
+		for _, dep := range p.ManifestLock.Spec.Dependencies {
+			ref, err := digestReference(dep.Image, dep.Digest)
+			if err != nil {
+				return nil, nil, err
+			}
+			dependencies = append(dependencies, ref)
+		}
+	}
+
+	return images, dependencies, nil
+}
+
+func digestReference(image, digest string) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", fmt.Errorf("parsing image reference %q: %w", image, err)
+	}
+	return ref.Context().Digest(digest).String(), nil
+}
+
+// Returns the registryHostOverrides mapping every source registry host to the target registry.
+func hostMapping(to string, refs []string) (string, error) {
+	var hosts []string
+	for _, ref := range refs {
+		parsed, err := name.ParseReference(ref)
+		if err != nil {
+			return "", fmt.Errorf("parsing image reference %q: %w", ref, err)
+		}
+		host := parsed.Context().RegistryStr()
+		if host == to || slices.Contains(hosts, host) {
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+	slices.Sort(hosts)
+
+	overrides := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		overrides = append(overrides, host+"="+to)
+	}
+	return strings.Join(overrides, ","), nil
+}
+
+type mirrorer struct {
+	craneOpts []crane.Option
+}
+
+// Copies all given images to the registry, keeping their repository paths.
+// Returns the destination reference of each image.
+func (m *mirrorer) toRegistry(to string, refs []string) (map[string]string, error) {
+	mirrored := map[string]string{}
+	for _, ref := range refs {
+		dst, err := utils.ImageURLWithOverride(ref, to)
+		if err != nil {
+			return nil, err
+		}
+		if err := crane.Copy(ref, dst, m.craneOpts...); err != nil {
+			return nil, fmt.Errorf("copying %s to %s: %w", ref, dst, err)
+		}
+		mirrored[ref] = dst
+	}
+	return mirrored, nil
+}
+
+// Copies all given images into an OCI layout directory.
+// The first image is annotated as package image, so the layout can be used as package source.
+func (m *mirrorer) toDir(dir string, refs []string) error {
+	p, err := layout.FromPath(dir)
+	if err != nil {
+		// Not an OCI layout yet.
+		if p, err = layout.Write(dir, empty.Index); err != nil {
+			return fmt.Errorf("creating OCI layout: %w", err)
+		}
+	}
+
+	for i, ref := range refs {
+		desc, err := crane.Get(ref, m.craneOpts...)
+		if err != nil {
+			return fmt.Errorf("getting %s: %w", ref, err)
+		}
+
+		refAnnotations := map[string]string{refNameAnnotation: ref}
+		if i == 0 {
+			refAnnotations[packages.OCILayoutPackageAnnotation] = ref
+		}
+		annotations := layout.WithAnnotations(refAnnotations)
+		if desc.MediaType.IsIndex() {
+			index, err := desc.ImageIndex()
+			if err != nil {
+				return fmt.Errorf("getting %s: %w", ref, err)
+			}
+			if err := p.AppendIndex(index, annotations); err != nil {
+				return fmt.Errorf("writing %s: %w", ref, err)
+			}
+			continue
+		}
+
+		image, err := desc.Image()
+		if err != nil {
+			return fmt.Errorf("getting %s: %w", ref, err)
+		}
+		if err := p.AppendImage(image, annotations); err != nil {
+			return fmt.Errorf("writing %s: %w", ref, err)
+		}
+	}
+
+	return nil
+}
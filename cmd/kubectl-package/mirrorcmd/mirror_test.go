@@ -0,0 +1,203 @@
+package mirrorcmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/packages"
+	"package-operator.run/internal/testutil"
+)
+
+const testManifest = `apiVersion: manifests.package-operator.run/v1alpha1
+kind: PackageManifest
+metadata:
+  name: %s
+spec:
+  scopes:
+  - Namespaced
+  phases:
+  - name: deploy
+`
+
+// Pushes a package image with the given lock file and returns its digest.
+func pushPackage(t *testing.T, reg *testutil.InMemoryRegistry, ref, pkgName, lock string) string {
+	t.Helper()
+
+	files := packages.Files{
+		"manifest.yaml": []byte(fmt.Sprintf(testManifest, pkgName)),
+	}
+	if lock != "" {
+		files["manifest.lock.yaml"] = []byte(lock)
+	}
+	require.NoError(t, packages.ToPushedOCI(
+		context.Background(), []string{ref}, &packages.RawPackage{Files: files}, reg.CraneOpt))
+
+	digest, err := crane.Digest(ref, reg.CraneOpt)
+	require.NoError(t, err)
+	return digest
+}
+
+// Pushes a package referencing an image and a dependency package.
+func pushTestPackages(t *testing.T, reg *testutil.InMemoryRegistry) (imageRef, depRef string) {
+	t.Helper()
+
+	image := testutil.BuildImage(t, map[string][]byte{"bin": {1, 2}})
+	require.NoError(t, crane.Push(image, "quay.io/app/image:v1", reg.CraneOpt))
+	imageDigest, err := image.Digest()
+	require.NoError(t, err)
+
+	depDigest := pushPackage(t, reg, "quay.io/pkg/dep:v1", "dep", "")
+
+	pushPackage(t, reg, "quay.io/pkg/root:v1", "root", fmt.Sprintf(`apiVersion: manifests.package-operator.run/v1alpha1
+kind: PackageManifestLock
+spec:
+  images:
+  - name: app
+    image: quay.io/app/image:v1
+    digest: %s
+  dependencies:
+  - name: dep
+    image: quay.io/pkg/dep:v1
+    digest: %s
+    version: v1.0.0
+`, imageDigest, depDigest))
+
+	return "quay.io/app/image@" + imageDigest.String(), "quay.io/pkg/dep@" + depDigest
+}
+
+// Pushes a placeholder signature for the image with the given digest and returns the signature tag.
+func pushSignature(t *testing.T, reg *testutil.InMemoryRegistry, repository, digest string) string {
+	t.Helper()
+
+	ref, err := name.NewDigest(repository + "@" + digest)
+	require.NoError(t, err)
+	tag, err := packages.SignatureTag(ref)
+	require.NoError(t, err)
+
+	sig := testutil.BuildImage(t, map[string][]byte{"sig": {1}})
+	require.NoError(t, crane.Push(sig, tag.String(), reg.CraneOpt))
+	return tag.String()
+}
+
+func TestMirrorCmd_invalidArgs(t *testing.T) {
+	t.Parallel()
+
+	for name, args := range map[string][]string{
+		"no target":            {"quay.io/pkg/root:v1"},
+		"both targets":         {"quay.io/pkg/root:v1", "--to", "mirror.local", "--to-dir", "dir"},
+		"mapping without --to": {"quay.io/pkg/root:v1", "--to-dir", "dir", "--mapping-file", "map"},
+		"invalid registry":     {"quay.io/pkg/root:v1", "--to", "mirror.local/path"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := NewCmd()
+			cmd.SetArgs(args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			require.ErrorIs(t, cmd.Execute(), internalcmd.ErrInvalidArgs)
+		})
+	}
+}
+
+func TestMirrorCmd_toDir(t *testing.T) {
+	t.Parallel()
+
+	reg := testutil.NewInMemoryRegistry()
+	imageRef, depRef := pushTestPackages(t, reg)
+	rootDigest, err := crane.Digest("quay.io/pkg/root:v1", reg.CraneOpt)
+	require.NoError(t, err)
+	sigRef := pushSignature(t, reg, "quay.io/pkg/root", rootDigest)
+
+	dir := filepath.Join(t.TempDir(), "layout")
+	stdout := &bytes.Buffer{}
+	cmd := newCmd(reg.CraneOpt)
+	cmd.SetArgs([]string{"quay.io/pkg/root:v1", "--to-dir", dir})
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "mirrored quay.io/pkg/root:v1\n"+
+		"mirrored "+imageRef+"\n"+
+		"mirrored "+depRef+"\n"+
+		"mirrored "+sigRef+"\n", stdout.String())
+
+	p, err := layout.FromPath(dir)
+	require.NoError(t, err)
+	index, err := p.ImageIndex()
+	require.NoError(t, err)
+	indexManifest, err := index.IndexManifest()
+	require.NoError(t, err)
+
+	refs := make([]string, 0, len(indexManifest.Manifests))
+	var packageRefs []string
+	for _, desc := range indexManifest.Manifests {
+		refs = append(refs, desc.Annotations[refNameAnnotation])
+		if ref, ok := desc.Annotations[packages.OCILayoutPackageAnnotation]; ok {
+			packageRefs = append(packageRefs, ref)
+		}
+	}
+	assert.Equal(t, []string{"quay.io/pkg/root:v1", imageRef, depRef, sigRef}, refs)
+	assert.Equal(t, []string{"quay.io/pkg/root:v1"}, packageRefs)
+
+	// The layout can be used as package source.
+	rawPkg, err := packages.FromOCILayout(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Contains(t, string(rawPkg.Files["manifest.yaml"]), "name: root")
+}
+
+func TestMirrorCmd_toRegistry(t *testing.T) {
+	t.Parallel()
+
+	reg := testutil.NewInMemoryRegistry()
+	imageRef, depRef := pushTestPackages(t, reg)
+	imageDigest := imageRef[len("quay.io/app/image@"):]
+	sigRef := pushSignature(t, reg, "quay.io/app/image", imageDigest)
+
+	mappingFile := filepath.Join(t.TempDir(), "mapping")
+	stdout := &bytes.Buffer{}
+	cmd := newCmd(reg.CraneOpt)
+	cmd.SetArgs([]string{"quay.io/pkg/root:v1", "--to", "mirror.local:5000", "--mapping-file", mappingFile})
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, stdout.String(), "mirrored quay.io/pkg/root:v1 to mirror.local:5000/pkg/root:v1\n")
+	assert.Contains(t, stdout.String(), "mirrored "+imageRef+" to mirror.local:5000/app/image@")
+	assert.Contains(t, stdout.String(), "mirrored "+depRef+" to mirror.local:5000/pkg/dep@")
+	assert.Contains(t, stdout.String(), "mirrored "+sigRef+" to mirror.local:5000/app/image:sha256-")
+
+	_, err := crane.Digest("mirror.local:5000/app/image:"+strings.TrimPrefix(
+		sigRef, "quay.io/app/image:"), reg.CraneOpt)
+	require.NoError(t, err)
+
+	mapping, err := os.ReadFile(mappingFile)
+	require.NoError(t, err)
+	assert.Equal(t, "quay.io=mirror.local:5000\n", string(mapping))
+}
+
+func TestHostMapping(t *testing.T) {
+	t.Parallel()
+
+	mapping, err := hostMapping("mirror.local", []string{
+		"quay.io/pkg/root:v1",
+		"ghcr.io/app/image:v1",
+		"quay.io/pkg/dep:v1",
+		"mirror.local/already/mirrored:v1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io=mirror.local,quay.io=mirror.local", mapping)
+}
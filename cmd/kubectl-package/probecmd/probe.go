@@ -0,0 +1,171 @@
+package probecmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"package-operator.run/internal/cli"
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+type ProberFactory interface {
+	Prober() (Prober, error)
+}
+
+type Prober interface {
+	ProbePackage(
+		ctx context.Context, c *internalcmd.Client, name string, opts ...internalcmd.ProbeObjectSetOption,
+	) (*internalcmd.ProbeResult, error)
+	ProbeObjectSet(
+		ctx context.Context, c *internalcmd.Client, name string, opts ...internalcmd.ProbeObjectSetOption,
+	) (*internalcmd.ProbeResult, error)
+}
+
+func NewCmd(clientFactory internalcmd.ClientFactory, proberFactory ProberFactory) *cobra.Command {
+	const (
+		cmdUse   = "probe (TYPE NAME | TYPE/NAME)"
+		cmdShort = "evaluates the availability probes of a package or objectset"
+		cmdLong  = "evaluates the availability probes of a (Cluster)Package or (Cluster)ObjectSet " +
+			"against the live objects in the cluster, or a directory of object snapshots, " +
+			"and prints the result of every probe for every object. " +
+			"HTTP GET probes of Services are sent through the API server, " +
+			"TCP socket probes of Services are skipped, as they are not reachable from the client"
+	)
+
+	cmd := &cobra.Command{
+		Use:   cmdUse,
+		Short: cmdShort,
+		Long:  cmdLong,
+		Args:  cobra.RangeArgs(1, 2),
+	}
+
+	var opts options
+
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, rawArgs []string) error {
+		args, err := getArgs(rawArgs)
+		if err != nil {
+			return err
+		}
+
+		client, err := clientFactory.Client()
+		if err != nil {
+			return err
+		}
+
+		prober, err := proberFactory.Prober()
+		if err != nil {
+			return err
+		}
+
+		probeOpts := []internalcmd.ProbeObjectSetOption{
+			internalcmd.WithPhase(opts.Phase),
+			internalcmd.WithSnapshotDir(opts.SnapshotDir),
+		}
+
+		var res *internalcmd.ProbeResult
+
+		switch strings.ToLower(args.Resource) {
+		case "clusterpackage":
+			res, err = prober.ProbePackage(cmd.Context(), client, args.Name, probeOpts...)
+		case "package":
+			res, err = prober.ProbePackage(cmd.Context(), client, args.Name,
+				append(probeOpts, internalcmd.WithNamespace(opts.Namespace))...)
+		case "clusterobjectset":
+			res, err = prober.ProbeObjectSet(cmd.Context(), client, args.Name, probeOpts...)
+		case "objectset":
+			res, err = prober.ProbeObjectSet(cmd.Context(), client, args.Name,
+				append(probeOpts, internalcmd.WithNamespace(opts.Namespace))...)
+		default:
+			return fmt.Errorf("%w: unsupported resource type %q", internalcmd.ErrInvalidArgs, args.Resource)
+		}
+		if err != nil {
+			return fmt.Errorf("probing %s/%s: %w", args.Resource, args.Name, err)
+		}
+
+		printer := cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()})
+		if err := printer.PrintTable(
+			res.RenderTable("Phase", "Object", "Probe", "Result", "Message"),
+		); err != nil {
+			return err
+		}
+
+		passed, failed := res.Count()
+
+		return printer.PrintfOut("%d passed, %d failed\n", passed, failed)
+	}
+
+	return cmd
+}
+
+func getArgs(args []string) (*arguments, error) {
+	switch len(args) {
+	case 1:
+		parts := strings.SplitN(args[0], "/", 2)
+		if len(parts) < 2 {
+			return nil, fmt.Errorf(
+				"%w: arguments in resource/name form must have a single resource and name",
+				internalcmd.ErrInvalidArgs,
+			)
+		}
+
+		return &arguments{
+			Resource: parts[0],
+			Name:     parts[1],
+		}, nil
+	case 2:
+		return &arguments{
+			Resource: args[0],
+			Name:     args[1],
+		}, nil
+	default:
+		return nil, fmt.Errorf(
+			"%w: no less than 1 and no more than 2 arguments may be provided",
+			internalcmd.ErrInvalidArgs,
+		)
+	}
+}
+
+type arguments struct {
+	Resource string
+	Name     string
+}
+
+type options struct {
+	Namespace   string
+	Phase       string
+	SnapshotDir string
+}
+
+func (o *options) AddFlags(flags *pflag.FlagSet) {
+	const (
+		namespaceUse   = "namespace of the Package or ObjectSet"
+		phaseUse       = "only evaluate the probes of objects in the given phase"
+		snapshotDirUse = "directory containing YAML snapshots of the objects to probe instead of the live objects"
+	)
+
+	flags.StringVarP(
+		&o.Namespace,
+		"namespace",
+		"n",
+		o.Namespace,
+		namespaceUse,
+	)
+	flags.StringVar(
+		&o.Phase,
+		"phase",
+		o.Phase,
+		phaseUse,
+	)
+	flags.StringVar(
+		&o.SnapshotDir,
+		"snapshot-dir",
+		o.SnapshotDir,
+		snapshotDirUse,
+	)
+}
@@ -0,0 +1,168 @@
+package probecmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+var errTest = errors.New("test error")
+
+func TestProbeCmd(t *testing.T) {
+	t.Parallel()
+
+	result := &internalcmd.ProbeResult{
+		Probes: []internalcmd.ProbeOutcome{
+			{
+				Phase: "deploy", Object: "Deployment.apps test/test",
+				Type: "Condition", Success: true,
+			},
+			{
+				Phase: "deploy", Object: "Deployment.apps test/test",
+				Type: "CEL", Message: "not ready",
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Args           []string
+		Method         string
+		Result         *internalcmd.ProbeResult
+		Err            error
+		ShouldFail     bool
+		ExpectedErr    error
+		ExpectedOutput []string
+	}{
+		"no args": {
+			ShouldFail: true,
+		},
+		"too many args": {
+			Args:       []string{"package", "test", "extra"},
+			ShouldFail: true,
+		},
+		"missing name": {
+			Args:        []string{"package"},
+			ShouldFail:  true,
+			ExpectedErr: internalcmd.ErrInvalidArgs,
+		},
+		"unsupported resource": {
+			Args:        []string{"deployment/test"},
+			ShouldFail:  true,
+			ExpectedErr: internalcmd.ErrInvalidArgs,
+		},
+		"prober error": {
+			Args:        []string{"package", "test", "-n", "test"},
+			Method:      "ProbePackage",
+			Err:         errTest,
+			ShouldFail:  true,
+			ExpectedErr: errTest,
+		},
+		"package": {
+			Args:   []string{"package/test", "-n", "test", "--phase", "deploy"},
+			Method: "ProbePackage",
+			Result: result,
+			ExpectedOutput: []string{
+				"Deployment.apps test/test  Condition  PASS",
+				"Deployment.apps test/test  CEL        FAIL    not ready",
+				"1 passed, 1 failed",
+			},
+		},
+		"clusterobjectset": {
+			Args:   []string{"clusterobjectset", "test-1"},
+			Method: "ProbeObjectSet",
+			Result: &internalcmd.ProbeResult{},
+			ExpectedOutput: []string{
+				"0 passed, 0 failed",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme, err := internalcmd.NewScheme()
+			require.NoError(t, err)
+
+			c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			prober := &proberMock{}
+			if tc.Method != "" {
+				prober.
+					On(tc.Method, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(tc.Result, tc.Err)
+			}
+
+			cmd := NewCmd(
+				internalcmd.NewDefaultClientFactory(&kubeClientFactoryMock{Client: c}),
+				&proberFactoryMock{prober: prober},
+			)
+			cmd.SetArgs(tc.Args)
+
+			stdout := &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err = cmd.Execute()
+			if tc.ShouldFail {
+				require.Error(t, err)
+				if tc.ExpectedErr != nil {
+					require.ErrorIs(t, err, tc.ExpectedErr)
+				}
+
+				return
+			}
+			require.NoError(t, err)
+			prober.AssertExpectations(t)
+
+			for _, line := range tc.ExpectedOutput {
+				assert.Contains(t, stdout.String(), line)
+			}
+		})
+	}
+}
+
+type kubeClientFactoryMock struct {
+	Client client.Client
+}
+
+func (m *kubeClientFactoryMock) GetKubeClient() (client.Client, error) {
+	return m.Client, nil
+}
+
+type proberFactoryMock struct {
+	prober Prober
+}
+
+func (m *proberFactoryMock) Prober() (Prober, error) {
+	return m.prober, nil
+}
+
+type proberMock struct {
+	mock.Mock
+}
+
+func (m *proberMock) ProbePackage(
+	ctx context.Context, c *internalcmd.Client, name string, opts ...internalcmd.ProbeObjectSetOption,
+) (*internalcmd.ProbeResult, error) {
+	args := m.Called(ctx, c, name, opts)
+	res, _ := args.Get(0).(*internalcmd.ProbeResult)
+
+	return res, args.Error(1)
+}
+
+func (m *proberMock) ProbeObjectSet(
+	ctx context.Context, c *internalcmd.Client, name string, opts ...internalcmd.ProbeObjectSetOption,
+) (*internalcmd.ProbeResult, error) {
+	args := m.Called(ctx, c, name, opts)
+	res, _ := args.Get(0).(*internalcmd.ProbeResult)
+
+	return res, args.Error(1)
+}
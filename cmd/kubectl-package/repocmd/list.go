@@ -0,0 +1,34 @@
+package repocmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"package-operator.run/internal/cli"
+)
+
+func newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list source",
+		Short: "list all packages and their versions in the repository at source",
+		Long: "list all packages and their versions in the repository at source. " +
+			"source is a repository file or, if no such file exists, a repository image",
+		Args:    cobra.ExactArgs(1),
+		Aliases: []string{"ls"},
+	}
+
+	var opts outputOptions
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		idx, err := loadIndex(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		return printPackageSummaries(
+			cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()}),
+			summarizePackages(idx), opts)
+	}
+
+	return cmd
+}
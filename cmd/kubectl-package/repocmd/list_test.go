@@ -0,0 +1,161 @@
+package repocmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages"
+)
+
+func TestListCmd(t *testing.T) {
+	t.Parallel()
+
+	repoFile := writeTestRepo(t)
+
+	out, err := runCmd("list", repoFile)
+	require.NoError(t, err)
+	assert.Contains(t, out, "NAME")
+	assert.Contains(t, out, "v2.0.0,v1.1.0,v1.0.0")
+	assert.Contains(t, out, "quay.io/stub-multi")
+
+	out, err = runCmd("list", repoFile, "-o", "json")
+	require.NoError(t, err)
+
+	var summaries []packageSummary
+	require.NoError(t, json.Unmarshal([]byte(out), &summaries))
+	assert.Equal(t, []packageSummary{
+		{
+			Name: "stub", Image: "quay.io/stub", LatestVersion: "v2.0.0",
+			Versions: []string{"v2.0.0", "v1.1.0", "v1.0.0"},
+		},
+		{
+			Name: "stub-multi", Image: "quay.io/stub-multi", LatestVersion: "v0.1.0",
+			Versions: []string{"v0.1.0"},
+		},
+	}, summaries)
+
+	_, err = runCmd("list", repoFile, "-o", "banana")
+	require.ErrorIs(t, err, errInvalidOutputFormat)
+}
+
+func TestSearchCmd(t *testing.T) {
+	t.Parallel()
+
+	repoFile := writeTestRepo(t)
+
+	for name, tc := range map[string]struct {
+		term     string
+		expected []string
+	}{
+		"all":      {term: "stub", expected: []string{"stub", "stub-multi"}},
+		"one":      {term: "MULTI", expected: []string{"stub-multi"}},
+		"no match": {term: "banana"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := runCmd("search", repoFile, tc.term, "-o", "yaml")
+			require.NoError(t, err)
+
+			var summaries []packageSummary
+			require.NoError(t, yaml.Unmarshal([]byte(out), &summaries))
+			names := make([]string, 0, len(summaries))
+			for _, s := range summaries {
+				names = append(names, s.Name)
+			}
+			assert.ElementsMatch(t, tc.expected, names)
+		})
+	}
+
+	_, err := runCmd("search", repoFile, "")
+	require.ErrorContains(t, err, "arguments invalid: term must be not empty")
+}
+
+func TestShowCmd(t *testing.T) {
+	t.Parallel()
+
+	repoFile := writeTestRepo(t)
+
+	out, err := runCmd("show", repoFile, "stub")
+	require.NoError(t, err)
+	assert.Contains(t, out, "DIGEST")
+	assert.Contains(t, out, "platform=Kubernetes;OpenShift >=4.13")
+
+	out, err = runCmd("show", repoFile, "stub", "-o", "json")
+	require.NoError(t, err)
+
+	var entries []manifestsv1alpha1.RepositoryEntryData
+	require.NoError(t, json.Unmarshal([]byte(out), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "222", entries[0].Digest)
+	assert.Equal(t, []string{"v2.0.0"}, entries[0].Versions)
+	assert.Equal(t, "111", entries[1].Digest)
+	assert.Equal(t, []string{"v1.1.0", "v1.0.0"}, entries[1].Versions)
+
+	_, err = runCmd("show", repoFile, "banana")
+	require.Error(t, err)
+}
+
+func TestFormatConstraints(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "-", formatConstraints(nil))
+	assert.Equal(t, "platform=Kubernetes,OpenShift;uniqueInScope", formatConstraints(
+		[]manifestsv1alpha1.PackageManifestConstraint{
+			{Platform: []manifestsv1alpha1.PlatformName{manifestsv1alpha1.Kubernetes, manifestsv1alpha1.OpenShift}},
+			{UniqueInScope: &manifestsv1alpha1.PackageManifestUniqueInScopeConstraint{}},
+		}))
+}
+
+func writeTestRepo(t *testing.T) string {
+	t.Helper()
+
+	ctx := context.Background()
+	idx := packages.NewRepositoryIndex(metav1.ObjectMeta{Name: "test-repo"})
+	for _, entry := range []manifests.RepositoryEntryData{
+		{
+			Image: "quay.io/stub", Digest: "111", Name: "stub",
+			Versions: []string{"1.0.0", "1.1.0"},
+		},
+		{
+			Image: "quay.io/stub", Digest: "222", Name: "stub",
+			Versions: []string{"2.0.0"},
+			Constraints: []manifests.PackageManifestConstraint{
+				{Platform: []manifests.PlatformName{manifests.Kubernetes}},
+				{PlatformVersion: &manifests.PackageManifestPlatformVersionConstraint{
+					Name: manifests.OpenShift, Range: ">=4.13",
+				}},
+			},
+		},
+		{
+			Image: "quay.io/stub-multi", Digest: "333", Name: "stub-multi",
+			Versions: []string{"0.1.0"},
+		},
+	} {
+		require.NoError(t, idx.Add(ctx, &manifests.RepositoryEntry{Data: entry}))
+	}
+
+	repoFile := filepath.Join(t.TempDir(), "repo.yaml")
+	require.NoError(t, packages.SaveRepositoryToFile(ctx, repoFile, idx))
+	return repoFile
+}
+
+func runCmd(args ...string) (string, error) {
+	cmd := NewCmd()
+	cmd.SetArgs(args)
+	stdout := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	return stdout.String(), err
+}
@@ -0,0 +1,183 @@
+package repocmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/internal/cli"
+	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/packages"
+)
+
+var errInvalidOutputFormat = errors.New("invalid output format")
+
+type outputOptions struct {
+	Output string
+}
+
+func (o *outputOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format. One of: json|yaml",
+	)
+}
+
+// packageSummary describes a package and its versions within a repository.
+type packageSummary struct {
+	Name          string   `json:"name"`
+	Image         string   `json:"image"`
+	LatestVersion string   `json:"latestVersion"`
+	Versions      []string `json:"versions"`
+}
+
+// summarizePackages lists all packages of the index, ordered by name.
+func summarizePackages(idx *packages.RepositoryIndex) []packageSummary {
+	names := packageNames(idx)
+	summaries := make([]packageSummary, 0, len(names))
+	for _, name := range names {
+		summary := packageSummary{Name: name}
+		// Lookups can't fail for package names taken from the index itself.
+		summary.Versions, _ = idx.ListVersions(name)
+		if latest, err := idx.GetLatestEntry(name); err == nil {
+			summary.Image = latest.Data.Image
+		}
+		if len(summary.Versions) > 0 {
+			summary.LatestVersion = summary.Versions[0]
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// packageNames lists the names of all packages in the index, ordered by name.
+func packageNames(idx *packages.RepositoryIndex) []string {
+	var names []string
+	for _, entry := range idx.ListAllEntries() {
+		if !slices.Contains(names, entry.Data.Name) {
+			names = append(names, entry.Data.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// packageEntries lists all entries of a package, latest version first.
+func packageEntries(
+	idx *packages.RepositoryIndex, name string,
+) ([]manifestsv1alpha1.RepositoryEntryData, error) {
+	versions, err := idx.ListVersions(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		entries []manifestsv1alpha1.RepositoryEntryData
+		seen    = map[string]struct{}{}
+	)
+	for _, v := range versions {
+		entry, err := idx.GetVersion(name, v)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[entry.Data.Digest]; ok {
+			continue
+		}
+		seen[entry.Data.Digest] = struct{}{}
+
+		v1Entry, err := packages.ToV1Alpha1RepositoryEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, v1Entry.Data)
+	}
+	return entries, nil
+}
+
+func printPackageSummaries(p *cli.Printer, summaries []packageSummary, opts outputOptions) error {
+	table := internalcmd.NewDefaultTable(
+		internalcmd.WithHeaders{"NAME", "LATEST", "VERSIONS", "IMAGE"},
+	)
+	for _, s := range summaries {
+		table.AddRow(
+			internalcmd.Field{Name: "Name", Value: s.Name},
+			internalcmd.Field{Name: "Latest", Value: s.LatestVersion},
+			internalcmd.Field{Name: "Versions", Value: strings.Join(s.Versions, ",")},
+			internalcmd.Field{Name: "Image", Value: s.Image},
+		)
+	}
+	return printOutput(p, summaries, table, opts)
+}
+
+func printPackageEntries(
+	p *cli.Printer, entries []manifestsv1alpha1.RepositoryEntryData, opts outputOptions,
+) error {
+	table := internalcmd.NewDefaultTable(
+		internalcmd.WithHeaders{"VERSIONS", "DIGEST", "CONSTRAINTS"},
+	)
+	for _, e := range entries {
+		table.AddRow(
+			internalcmd.Field{Name: "Versions", Value: strings.Join(e.Versions, ",")},
+			internalcmd.Field{Name: "Digest", Value: e.Digest},
+			internalcmd.Field{Name: "Constraints", Value: formatConstraints(e.Constraints)},
+		)
+	}
+	return printOutput(p, entries, table, opts)
+}
+
+func printOutput(p *cli.Printer, obj any, table internalcmd.Table, opts outputOptions) error {
+	switch strings.ToLower(opts.Output) {
+	case "json":
+		data, err := json.MarshalIndent(obj, "", "    ")
+		if err != nil {
+			return fmt.Errorf("rendering to json: %w", err)
+		}
+
+		return p.PrintfOut("%s", string(data)+"\n")
+	case "yaml":
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("rendering to yaml: %w", err)
+		}
+
+		return p.PrintfOut("%s", string(data))
+	case "":
+		return p.PrintTable(table)
+	default:
+		return fmt.Errorf("%w: %q", errInvalidOutputFormat, opts.Output)
+	}
+}
+
+// formatConstraints renders package constraints in a single line,
+// e.g. "platform=Kubernetes,OpenShift;OpenShift >=4.13;uniqueInScope".
+func formatConstraints(constraints []manifestsv1alpha1.PackageManifestConstraint) string {
+	parts := make([]string, 0, len(constraints))
+	for _, c := range constraints {
+		if len(c.Platform) > 0 {
+			names := make([]string, 0, len(c.Platform))
+			for _, n := range c.Platform {
+				names = append(names, string(n))
+			}
+			parts = append(parts, "platform="+strings.Join(names, ","))
+		}
+		if c.PlatformVersion != nil {
+			parts = append(parts, string(c.PlatformVersion.Name)+" "+c.PlatformVersion.Range)
+		}
+		if c.UniqueInScope != nil {
+			parts = append(parts, "uniqueInScope")
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ";")
+}
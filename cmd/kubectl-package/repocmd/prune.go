@@ -0,0 +1,237 @@
+package repocmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"pkg.package-operator.run/semver"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/cli"
+	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/packages"
+)
+
+func newPruneCmd(registry entryRegistry) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune file",
+		Short: "prune stale versions from the repository at file",
+		Long: "prune stale versions from the repository at file. " +
+			"Entries whose digest no longer exists in the registry are always removed.",
+		Args: cobra.ExactArgs(1),
+	}
+
+	var opts pruneOptions
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		filePath := args[0]
+		if filePath == "" {
+			return fmt.Errorf("%w: file must be not empty", internalcmd.ErrInvalidArgs)
+		}
+		if opts.Keep < 0 {
+			return fmt.Errorf("%w: keep must not be negative", internalcmd.ErrInvalidArgs)
+		}
+
+		var inRange func(semver.Version) bool
+		if opts.Range != "" {
+			rng, err := semver.NewConstraint(opts.Range)
+			if err != nil {
+				return fmt.Errorf("%w: range: %w", internalcmd.ErrInvalidArgs, err)
+			}
+			inRange = rng.Check
+		}
+
+		idx, err := packages.LoadRepositoryFromFile(ctx, filePath)
+		if err != nil {
+			return fmt.Errorf("read from file: %w", err)
+		}
+
+		pruned, err := prune(ctx, idx, registry, inRange, opts.Keep)
+		if err != nil {
+			return err
+		}
+
+		printer := cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()})
+		for _, p := range pruned {
+			if err := printer.PrintfOut("pruned %s %s: %s\n", p.Package, p.Version, p.Reason); err != nil {
+				return err
+			}
+		}
+
+		if opts.DryRun || len(pruned) == 0 {
+			return nil
+		}
+		if err := packages.SaveRepositoryToFile(ctx, filePath, idx); err != nil {
+			return fmt.Errorf("write to file: %w", err)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+type pruneOptions struct {
+	Keep   int
+	Range  string
+	DryRun bool
+}
+
+func (o *pruneOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.IntVar(
+		&o.Keep,
+		"keep",
+		o.Keep,
+		"Number of latest versions to keep per package. All versions are kept if 0",
+	)
+	flags.StringVar(
+		&o.Range,
+		"range",
+		o.Range,
+		"Semver range of versions to keep",
+	)
+	flags.BoolVar(
+		&o.DryRun,
+		"dry-run",
+		o.DryRun,
+		"Only print what would be pruned, without writing the file",
+	)
+}
+
+// isDigestNotFound returns true, if the registry reports the manifest as missing.
+func isDigestNotFound(err error) bool {
+	var terr *transport.Error
+	if !errors.As(err, &terr) {
+		return false
+	}
+	if terr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	for _, diag := range terr.Errors {
+		if diag.Code == transport.ManifestUnknownErrorCode {
+			return true
+		}
+	}
+	return false
+}
+
+// prunedVersion is a version removed from the repository.
+type prunedVersion struct {
+	Package string
+	Version string
+	Reason  string
+}
+
+// prune removes versions of entries whose digest does not exist anymore,
+// versions not in range and all but the latest keep versions of each package.
+// A nil inRange func keeps versions regardless of their range.
+func prune(
+	ctx context.Context, idx *packages.RepositoryIndex,
+	registry entryRegistry, inRange func(semver.Version) bool, keep int,
+) ([]prunedVersion, error) {
+	entries := idx.ListAllEntries()
+	sortEntries(entries)
+
+	var pruned []prunedVersion
+	// Versions to drop by entry name.
+	drop := map[string]map[string]struct{}{}
+	dropVersion := func(entry manifests.RepositoryEntry, version, reason string) {
+		if drop[entry.Name] == nil {
+			drop[entry.Name] = map[string]struct{}{}
+		}
+		if _, ok := drop[entry.Name][version]; ok {
+			return
+		}
+		drop[entry.Name][version] = struct{}{}
+		pruned = append(pruned, prunedVersion{Package: entry.Data.Name, Version: version, Reason: reason})
+	}
+
+	for _, entry := range entries {
+		ref, err := entryReference(entry.Data)
+		if err != nil {
+			return nil, err
+		}
+		_, err = registry.Digest(ctx, ref)
+		if isDigestNotFound(err) {
+			for _, v := range entry.Data.Versions {
+				dropVersion(entry, v, "digest not found")
+			}
+			continue
+		}
+		if err != nil {
+			// Don't drop versions because of e.g. authentication or network errors.
+			return nil, fmt.Errorf("looking up digest of %s: %w", ref, err)
+		}
+
+		if inRange == nil {
+			continue
+		}
+		for _, v := range entry.Data.Versions {
+			sv, err := semver.NewVersion(strings.TrimPrefix(v, "v"))
+			if err != nil {
+				return nil, err
+			}
+			if !inRange(sv) {
+				dropVersion(entry, v, "not in range")
+			}
+		}
+	}
+
+	if keep > 0 {
+		for _, pkgName := range packageNames(idx) {
+			// Versions are ordered latest first.
+			versions, err := idx.ListVersions(pkgName)
+			if err != nil {
+				return nil, err
+			}
+			var kept int
+			for _, v := range versions {
+				entry, err := idx.GetVersion(pkgName, v)
+				if err != nil {
+					return nil, err
+				}
+				if _, dropped := drop[entry.Name][v]; dropped {
+					continue
+				}
+				if kept < keep {
+					kept++
+					continue
+				}
+				dropVersion(*entry, v, fmt.Sprintf("older than the latest %d versions", keep))
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		versions, ok := drop[entry.Name]
+		if !ok {
+			continue
+		}
+		if err := idx.Remove(ctx, &entry); err != nil {
+			return nil, err
+		}
+
+		var remaining []string
+		for _, v := range entry.Data.Versions {
+			if _, dropped := versions[v]; !dropped {
+				remaining = append(remaining, v)
+			}
+		}
+		if len(remaining) == 0 {
+			continue
+		}
+		entry.Data.Versions = remaining
+		if err := idx.Add(ctx, &entry); err != nil {
+			return nil, err
+		}
+	}
+	return pruned, nil
+}
@@ -0,0 +1,186 @@
+package repocmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages"
+)
+
+var errTest = errors.New("explosion")
+
+func TestPruneCmd(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		args             []string
+		missingDigest    string
+		digestErr        error
+		expectedOutput   string
+		expectedVersions map[string][]string
+	}{
+		"nothing to prune": {
+			expectedVersions: map[string][]string{
+				"stub":       {"v2.0.0", "v1.1.0", "v1.0.0"},
+				"stub-multi": {"v0.1.0"},
+			},
+		},
+		"digest not found": {
+			missingDigest: "quay.io/stub@sha256:111",
+			digestErr:     &transport.Error{StatusCode: http.StatusNotFound},
+			expectedOutput: "pruned stub v1.1.0: digest not found\n" +
+				"pruned stub v1.0.0: digest not found\n",
+			expectedVersions: map[string][]string{
+				"stub":       {"v2.0.0"},
+				"stub-multi": {"v0.1.0"},
+			},
+		},
+		"manifest unknown": {
+			missingDigest: "quay.io/stub@sha256:111",
+			digestErr: &transport.Error{
+				StatusCode: http.StatusBadRequest,
+				Errors:     []transport.Diagnostic{{Code: transport.ManifestUnknownErrorCode}},
+			},
+			expectedOutput: "pruned stub v1.1.0: digest not found\n" +
+				"pruned stub v1.0.0: digest not found\n",
+			expectedVersions: map[string][]string{
+				"stub":       {"v2.0.0"},
+				"stub-multi": {"v0.1.0"},
+			},
+		},
+		"range": {
+			args: []string{"--range", ">=1.1.0"},
+			expectedOutput: "pruned stub v1.0.0: not in range\n" +
+				"pruned stub-multi v0.1.0: not in range\n",
+			expectedVersions: map[string][]string{
+				"stub": {"v2.0.0", "v1.1.0"},
+			},
+		},
+		"keep": {
+			args:           []string{"--keep", "2"},
+			expectedOutput: "pruned stub v1.0.0: older than the latest 2 versions\n",
+			expectedVersions: map[string][]string{
+				"stub":       {"v2.0.0", "v1.1.0"},
+				"stub-multi": {"v0.1.0"},
+			},
+		},
+		"dry run": {
+			args: []string{"--keep", "1", "--dry-run"},
+			expectedOutput: "pruned stub v1.1.0: older than the latest 1 versions\n" +
+				"pruned stub v1.0.0: older than the latest 1 versions\n",
+			expectedVersions: map[string][]string{
+				"stub":       {"v2.0.0", "v1.1.0", "v1.0.0"},
+				"stub-multi": {"v0.1.0"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			repoFile := writeTestRepo(t)
+
+			registry := &entryRegistryMock{}
+			if tc.missingDigest != "" {
+				registry.On("Digest", mock.Anything, tc.missingDigest).Return("", tc.digestErr)
+			}
+			registry.On("Digest", mock.Anything, mock.Anything).Return("sha256:123", nil)
+
+			cmd := newPruneCmd(registry)
+			cmd.SetArgs(append([]string{repoFile}, tc.args...))
+			stdout := &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tc.expectedOutput, stdout.String())
+
+			idx, err := packages.LoadRepositoryFromFile(context.Background(), repoFile)
+			require.NoError(t, err)
+			actual := map[string][]string{}
+			for _, pkgName := range packageNames(idx) {
+				actual[pkgName], err = idx.ListVersions(pkgName)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedVersions, actual)
+		})
+	}
+}
+
+func TestPruneCmd_digestError(t *testing.T) {
+	t.Parallel()
+
+	for name, digestErr := range map[string]error{
+		"unauthorized": &transport.Error{StatusCode: http.StatusUnauthorized},
+		"forbidden":    &transport.Error{StatusCode: http.StatusForbidden},
+		"network":      errTest,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			repoFile := writeTestRepo(t)
+
+			registry := &entryRegistryMock{}
+			registry.On("Digest", mock.Anything, "quay.io/stub@sha256:111").Return("", digestErr)
+			registry.On("Digest", mock.Anything, mock.Anything).Return("sha256:123", nil)
+
+			cmd := newPruneCmd(registry)
+			cmd.SetArgs([]string{repoFile})
+			stdout := &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+			require.ErrorIs(t, cmd.Execute(), digestErr)
+			assert.Empty(t, stdout.String())
+
+			// The repository must not be touched.
+			idx, err := packages.LoadRepositoryFromFile(context.Background(), repoFile)
+			require.NoError(t, err)
+			versions, err := idx.ListVersions("stub")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"v2.0.0", "v1.1.0", "v1.0.0"}, versions)
+		})
+	}
+}
+
+func TestPruneCmd_invalidArgs(t *testing.T) {
+	t.Parallel()
+
+	for name, args := range map[string][]string{
+		"no file":        {},
+		"negative keep":  {"repo.yaml", "--keep", "-1"},
+		"invalid range":  {"repo.yaml", "--range", "banana"},
+		"file not found": {"does-not-exist.yaml"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := newPruneCmd(&entryRegistryMock{})
+			cmd.SetArgs(args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			require.Error(t, cmd.Execute())
+		})
+	}
+}
+
+type entryRegistryMock struct {
+	mock.Mock
+}
+
+func (m *entryRegistryMock) Digest(ctx context.Context, ref string) (string, error) {
+	args := m.Called(ctx, ref)
+	return args.String(0), args.Error(1)
+}
+
+func (m *entryRegistryMock) Manifest(ctx context.Context, ref string) (*manifests.PackageManifest, error) {
+	args := m.Called(ctx, ref)
+	manifest, _ := args.Get(0).(*manifests.PackageManifest)
+	return manifest, args.Error(1)
+}
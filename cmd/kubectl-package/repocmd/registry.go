@@ -0,0 +1,74 @@
+package repocmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages"
+)
+
+// entryRegistry looks up the package images referenced by repository entries.
+type entryRegistry interface {
+	// Digest returns the digest of the given image reference,
+	// failing if the image does not exist.
+	Digest(ctx context.Context, ref string) (string, error)
+	// Manifest pulls the package image and returns its PackageManifest.
+	Manifest(ctx context.Context, ref string) (*manifests.PackageManifest, error)
+}
+
+// craneEntryRegistry talks to the actual registries.
+type craneEntryRegistry struct{}
+
+func (craneEntryRegistry) Digest(ctx context.Context, ref string) (string, error) {
+	return crane.Digest(ref, crane.WithContext(ctx))
+}
+
+func (craneEntryRegistry) Manifest(ctx context.Context, ref string) (*manifests.PackageManifest, error) {
+	pkgImg, err := crane.Pull(ref, crane.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("pull package image: %w", err)
+	}
+
+	rawPkg, err := packages.FromOCI(ctx, pkgImg)
+	if err != nil {
+		return nil, fmt.Errorf("raw package from package image: %w", err)
+	}
+
+	pkg, err := packages.DefaultStructuralLoader.Load(ctx, rawPkg)
+	if err != nil {
+		return nil, fmt.Errorf("package from raw package: %w", err)
+	}
+
+	return pkg.Manifest, nil
+}
+
+// entryReference references the package image of an entry by digest.
+// Entries added via the add command only contain the hex part of the sha256 digest.
+func entryReference(data manifests.RepositoryEntryData) (string, error) {
+	ref, err := name.ParseReference(data.Image)
+	if err != nil {
+		return "", fmt.Errorf("parsing image reference %q: %w", data.Image, err)
+	}
+
+	digest := data.Digest
+	if !strings.Contains(digest, ":") {
+		digest = "sha256:" + digest
+	}
+	return ref.Context().Digest(digest).String(), nil
+}
+
+// sortEntries orders entries by package name and digest.
+func sortEntries(entries []manifests.RepositoryEntry) {
+	slices.SortFunc(entries, func(a, b manifests.RepositoryEntry) int {
+		if c := strings.Compare(a.Data.Name, b.Data.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Data.Digest, b.Data.Digest)
+	})
+}
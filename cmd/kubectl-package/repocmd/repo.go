@@ -11,7 +11,11 @@ func NewCmd() *cobra.Command {
 		Aliases: []string{"repo"},
 	}
 
-	cmd.AddCommand(newInitCmd(), newPullCmd(), newAddCmd(), newRemoveCmd(), newPushCmd())
+	cmd.AddCommand(
+		newInitCmd(), newPullCmd(), newAddCmd(), newRemoveCmd(), newPushCmd(),
+		newListCmd(), newSearchCmd(), newShowCmd(),
+		newPruneCmd(craneEntryRegistry{}), newVerifyCmd(craneEntryRegistry{}),
+	)
 
 	return cmd
 }
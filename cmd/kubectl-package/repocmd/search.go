@@ -0,0 +1,49 @@
+package repocmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"package-operator.run/internal/cli"
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+func newSearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search source term",
+		Short: "search the repository at source for packages with names containing term",
+		Long: "search the repository at source for packages with names containing term. " +
+			"source is a repository file or, if no such file exists, a repository image",
+		Args: cobra.ExactArgs(2),
+	}
+
+	var opts outputOptions
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		term := strings.ToLower(args[1])
+		if term == "" {
+			return fmt.Errorf("%w: term must be not empty", internalcmd.ErrInvalidArgs)
+		}
+
+		idx, err := loadIndex(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		var matches []packageSummary
+		for _, s := range summarizePackages(idx) {
+			if strings.Contains(strings.ToLower(s.Name), term) {
+				matches = append(matches, s)
+			}
+		}
+
+		return printPackageSummaries(
+			cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()}),
+			matches, opts)
+	}
+
+	return cmd
+}
@@ -0,0 +1,46 @@
+package repocmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"package-operator.run/internal/cli"
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+func newShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show source package",
+		Short: "show versions, digests and constraints of package in the repository at source",
+		Long: "show versions, digests and constraints of package in the repository at source. " +
+			"source is a repository file or, if no such file exists, a repository image",
+		Args: cobra.ExactArgs(2),
+	}
+
+	var opts outputOptions
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		pkgName := args[1]
+		if pkgName == "" {
+			return fmt.Errorf("%w: package must be not empty", internalcmd.ErrInvalidArgs)
+		}
+
+		idx, err := loadIndex(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		entries, err := packageEntries(idx, pkgName)
+		if err != nil {
+			return err
+		}
+
+		return printPackageEntries(
+			cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()}),
+			entries, opts)
+	}
+
+	return cmd
+}
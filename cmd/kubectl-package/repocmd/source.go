@@ -0,0 +1,26 @@
+package repocmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+
+	"package-operator.run/internal/packages"
+)
+
+// loadIndex loads a repository index from the given file
+// or pulls it from the given image, if no such file exists.
+func loadIndex(ctx context.Context, source string) (*packages.RepositoryIndex, error) {
+	if _, err := os.Stat(source); err == nil {
+		return packages.LoadRepositoryFromFile(ctx, source)
+	}
+
+	image, err := crane.Pull(source, crane.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("pull repository image: %w", err)
+	}
+
+	return packages.LoadRepositoryFromOCI(ctx, image)
+}
@@ -0,0 +1,127 @@
+package repocmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/spf13/cobra"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/cli"
+	internalcmd "package-operator.run/internal/cmd"
+	"package-operator.run/internal/packages"
+)
+
+var errVerificationFailed = errors.New("repository verification failed")
+
+func newVerifyCmd(registry entryRegistry) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify source",
+		Short: "verify that all entries of the repository at source match their package images",
+		Long: "verify that the image digest of every entry in the repository at source exists " +
+			"and that the name and constraints of the entry match the package manifest. " +
+			"source is a repository file or, if no such file exists, a repository image",
+		Args: cobra.ExactArgs(1),
+	}
+
+	var opts outputOptions
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		idx, err := loadIndex(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		problems := verify(ctx, idx, registry)
+		if len(problems) == 0 {
+			return nil
+		}
+
+		if err := printEntryProblems(
+			cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()}),
+			problems, opts); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %d problems found", errVerificationFailed, len(problems))
+	}
+
+	return cmd
+}
+
+// entryProblem describes why a repository entry does not match its package image.
+type entryProblem struct {
+	Package string `json:"package"`
+	Digest  string `json:"digest"`
+	Problem string `json:"problem"`
+}
+
+// verify checks every entry of the index against its package image.
+func verify(
+	ctx context.Context, idx *packages.RepositoryIndex, registry entryRegistry,
+) []entryProblem {
+	entries := idx.ListAllEntries()
+	sortEntries(entries)
+
+	var problems []entryProblem
+	for _, entry := range entries {
+		if problem := verifyEntry(ctx, entry.Data, registry); problem != "" {
+			problems = append(problems, entryProblem{
+				Package: entry.Data.Name,
+				Digest:  entry.Data.Digest,
+				Problem: problem,
+			})
+		}
+	}
+	return problems
+}
+
+// verifyEntry returns a description of the first problem found with the given entry.
+func verifyEntry(
+	ctx context.Context, data manifests.RepositoryEntryData, registry entryRegistry,
+) string {
+	ref, err := entryReference(data)
+	if err != nil {
+		return err.Error()
+	}
+	if _, err := registry.Digest(ctx, ref); err != nil {
+		return fmt.Sprintf("digest not found: %s", err)
+	}
+
+	manifest, err := registry.Manifest(ctx, ref)
+	if err != nil {
+		return err.Error()
+	}
+	if manifest.Name != data.Name {
+		return fmt.Sprintf("package manifest is named %q", manifest.Name)
+	}
+	if !constraintsEqual(manifest.Spec.Constraints, data.Constraints) {
+		return "constraints differ from package manifest"
+	}
+	return ""
+}
+
+func constraintsEqual(a, b []manifests.PackageManifestConstraint) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func printEntryProblems(p *cli.Printer, problems []entryProblem, opts outputOptions) error {
+	table := internalcmd.NewDefaultTable(
+		internalcmd.WithHeaders{"PACKAGE", "DIGEST", "PROBLEM"},
+	)
+	for _, pr := range problems {
+		table.AddRow(
+			internalcmd.Field{Name: "Package", Value: pr.Package},
+			internalcmd.Field{Name: "Digest", Value: pr.Digest},
+			internalcmd.Field{Name: "Problem", Value: pr.Problem},
+		)
+	}
+	return printOutput(p, problems, table, opts)
+}
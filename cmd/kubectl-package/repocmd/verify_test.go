@@ -0,0 +1,90 @@
+package repocmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"package-operator.run/internal/apis/manifests"
+)
+
+func TestVerifyCmd(t *testing.T) {
+	t.Parallel()
+
+	stubConstraints := []manifests.PackageManifestConstraint{
+		{Platform: []manifests.PlatformName{manifests.Kubernetes}},
+		{PlatformVersion: &manifests.PackageManifestPlatformVersionConstraint{
+			Name: manifests.OpenShift, Range: ">=4.13",
+		}},
+	}
+	manifest := func(name string, constraints []manifests.PackageManifestConstraint) *manifests.PackageManifest {
+		return &manifests.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       manifests.PackageManifestSpec{Constraints: constraints},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		pkgManifests     map[string]*manifests.PackageManifest
+		missingDigest    string
+		expectedProblems []entryProblem
+	}{
+		"valid": {
+			pkgManifests: map[string]*manifests.PackageManifest{
+				"quay.io/stub@sha256:111":       manifest("stub", nil),
+				"quay.io/stub@sha256:222":       manifest("stub", stubConstraints),
+				"quay.io/stub-multi@sha256:333": manifest("stub-multi", nil),
+			},
+		},
+		"problems": {
+			pkgManifests: map[string]*manifests.PackageManifest{
+				"quay.io/stub@sha256:222":       manifest("stub", nil),
+				"quay.io/stub-multi@sha256:333": manifest("banana", nil),
+			},
+			missingDigest: "quay.io/stub@sha256:111",
+			expectedProblems: []entryProblem{
+				{Package: "stub", Digest: "111", Problem: "digest not found: explosion"},
+				{Package: "stub", Digest: "222", Problem: "constraints differ from package manifest"},
+				{Package: "stub-multi", Digest: "333", Problem: `package manifest is named "banana"`},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			repoFile := writeTestRepo(t)
+
+			registry := &entryRegistryMock{}
+			if tc.missingDigest != "" {
+				registry.On("Digest", mock.Anything, tc.missingDigest).Return("", errTest)
+			}
+			registry.On("Digest", mock.Anything, mock.Anything).Return("sha256:123", nil)
+			for ref, m := range tc.pkgManifests {
+				registry.On("Manifest", mock.Anything, ref).Return(m, nil)
+			}
+
+			cmd := newVerifyCmd(registry)
+			cmd.SetArgs([]string{repoFile, "-o", "json"})
+			stdout := &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if len(tc.expectedProblems) == 0 {
+				require.NoError(t, err)
+				assert.Empty(t, stdout.String())
+				return
+			}
+			require.ErrorIs(t, err, errVerificationFailed)
+
+			var problems []entryProblem
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &problems))
+			assert.Equal(t, tc.expectedProblems, problems)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package rolloutcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"package-operator.run/internal/cli"
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+func NewApproveCmd(clientFactory internalcmd.ClientFactory) *cobra.Command {
+	const (
+		cmdUse   = "approve"
+		cmdShort = "approve the pending upgrade of a package"
+		cmdLong  = "approve the change of a package held back by its upgrade policy. " +
+			"The change is rolled out once approved, within the next maintenance window if any are configured"
+	)
+
+	cmd := &cobra.Command{
+		Use:   cmdUse,
+		Short: cmdShort,
+		Long:  cmdLong,
+		Args:  cobra.RangeArgs(1, 2),
+	}
+
+	var opts approveOptions
+
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, rawArgs []string) error {
+		args, err := getArgs(rawArgs)
+		if err != nil {
+			return err
+		}
+
+		client, err := clientFactory.Client()
+		if err != nil {
+			return err
+		}
+
+		var pkg *internalcmd.Package
+
+		switch strings.ToLower(args.Resource) {
+		case "clusterpackage":
+			pkg, err = client.GetPackage(cmd.Context(), args.Name)
+		case "package":
+			pkg, err = client.GetPackage(cmd.Context(), args.Name, internalcmd.WithNamespace(opts.Namespace))
+		default:
+			return errInvalidResourceType
+		}
+
+		if err != nil {
+			return fmt.Errorf("getting resource %s/%s: %w", args.Resource, args.Name, err)
+		}
+
+		hash, err := pkg.Approve(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("approving %s/%s: %w", args.Resource, args.Name, err)
+		}
+
+		printer := cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()})
+
+		return printer.PrintfOut("%s/%s approved %s\n", args.Resource, args.Name, hash)
+	}
+
+	return cmd
+}
+
+type approveOptions struct {
+	Namespace string
+}
+
+func (o *approveOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Namespace,
+		"namespace",
+		"n",
+		o.Namespace,
+		"If present, the namespace scope for this CLI request",
+	)
+}
@@ -0,0 +1,130 @@
+package rolloutcmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+func TestApproveCmd(t *testing.T) {
+	t.Parallel()
+
+	pkg := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Status: corev1alpha1.PackageStatus{PendingHash: "abc"},
+	}
+	clusterPkg := &corev1alpha1.ClusterPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Status: corev1alpha1.PackageStatus{PendingHash: "def"},
+	}
+	nothingPending := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Args          []string
+		ActualObjects []client.Object
+		Output        string
+		ShouldFail    bool
+		Assert        func(t *testing.T, c client.Client)
+	}{
+		"no args": {
+			ShouldFail: true,
+		},
+		"invalid resource": {
+			Args:          []string{"objectdeployment", "test"},
+			ActualObjects: []client.Object{pkg},
+			ShouldFail:    true,
+		},
+		"not found": {
+			Args:       []string{"package/test", "-n", "test"},
+			ShouldFail: true,
+		},
+		"nothing pending": {
+			Args:          []string{"package/test", "-n", "test"},
+			ActualObjects: []client.Object{nothingPending},
+			ShouldFail:    true,
+		},
+		"package": {
+			Args:          []string{"package/test", "-n", "test"},
+			ActualObjects: []client.Object{pkg},
+			Output:        "package/test approved abc\n",
+			Assert: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				actual := &corev1alpha1.Package{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(pkg), actual))
+				assert.Equal(t, "abc", actual.Annotations[corev1alpha1.PackageApprovedHashAnnotation])
+			},
+		},
+		"cluster package": {
+			Args:          []string{"clusterpackage", "test"},
+			ActualObjects: []client.Object{clusterPkg},
+			Output:        "clusterpackage/test approved def\n",
+			Assert: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				actual := &corev1alpha1.ClusterPackage{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(clusterPkg), actual))
+				assert.Equal(t, "def", actual.Annotations[corev1alpha1.PackageApprovedHashAnnotation])
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme, err := internalcmd.NewScheme()
+			require.NoError(t, err)
+
+			objs := make([]client.Object, 0, len(tc.ActualObjects))
+			for _, obj := range tc.ActualObjects {
+				objs = append(objs, obj.DeepCopyObject().(client.Object))
+			}
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objs...).
+				Build()
+
+			cmd := NewApproveCmd(internalcmd.NewDefaultClientFactory(
+				&kubeClientFactoryMock{
+					Client: c,
+				},
+			))
+			cmd.SetArgs(tc.Args)
+
+			stdout := &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			if tc.ShouldFail {
+				require.Error(t, cmd.Execute())
+
+				return
+			}
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tc.Output, stdout.String())
+
+			if tc.Assert != nil {
+				tc.Assert(t, c)
+			}
+		})
+	}
+}
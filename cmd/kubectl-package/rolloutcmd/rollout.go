@@ -14,8 +14,10 @@ type Params struct {
 func NewRolloutCmd(params Params) *cobra.Command {
 	const (
 		cmdUse   = "rollout"
-		cmdShort = "view package rollout status or history"
-		cmdLong  = "view package rollout status or history including detailed revision information"
+		cmdShort = "view package rollout status or history, roll back to a previous revision or approve upgrades"
+		cmdLong  = "view package rollout status or history including detailed revision information, " +
+			"roll back packages or object deployments to a previous revision " +
+			"and approve package upgrades held back by their upgrade policy"
 	)
 
 	cmd := &cobra.Command{
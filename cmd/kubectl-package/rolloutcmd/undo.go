@@ -0,0 +1,146 @@
+package rolloutcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"package-operator.run/internal/cli"
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+func NewUndoCmd(clientFactory internalcmd.ClientFactory) *cobra.Command {
+	const (
+		cmdUse   = "undo"
+		cmdShort = "roll back to a previous rollout revision"
+		cmdLong  = "roll back a package or object deployment to a previous rollout revision. " +
+			"Packages are rolled back by restoring the image and configuration of the given revision, " +
+			"object deployments roll out the template of the given revision as a new revision"
+	)
+
+	cmd := &cobra.Command{
+		Use:   cmdUse,
+		Short: cmdShort,
+		Long:  cmdLong,
+		Args:  cobra.RangeArgs(1, 2),
+	}
+
+	var opts undoOptions
+
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, rawArgs []string) error {
+		args, err := getArgs(rawArgs)
+		if err != nil {
+			return err
+		}
+
+		client, err := clientFactory.Client()
+		if err != nil {
+			return err
+		}
+
+		target, err := getRollbackTarget(cmd.Context(), client, args.Resource, args.Name, opts.Namespace)
+		if err != nil {
+			return err
+		}
+
+		revision := opts.ToRevision
+		if revision == 0 {
+			revision, err = previousRevision(cmd.Context(), target)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := target.RollbackTo(cmd.Context(), revision); err != nil {
+			return fmt.Errorf("rolling back %s/%s: %w", args.Resource, args.Name, err)
+		}
+
+		printer := cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()})
+
+		return printer.PrintfOut("%s/%s rolled back to revision %d\n", args.Resource, args.Name, revision)
+	}
+
+	return cmd
+}
+
+type rollbackTarget interface {
+	CurrentRevision() int64
+	ObjectSets(context.Context) (internalcmd.ObjectSetList, error)
+	RollbackTo(context.Context, int64) error
+}
+
+func getRollbackTarget(
+	ctx context.Context, client *internalcmd.Client, rsrc, name, ns string,
+) (rollbackTarget, error) {
+	var (
+		target rollbackTarget
+		err    error
+	)
+
+	switch strings.ToLower(rsrc) {
+	case "clusterpackage":
+		target, err = client.GetPackage(ctx, name)
+	case "package":
+		target, err = client.GetPackage(ctx, name, internalcmd.WithNamespace(ns))
+	case "clusterobjectdeployment":
+		target, err = client.GetObjectDeployment(ctx, name)
+	case "objectdeployment":
+		target, err = client.GetObjectDeployment(ctx, name, internalcmd.WithNamespace(ns))
+	default:
+		return nil, errInvalidResourceType
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting resource %s/%s: %w", rsrc, name, err)
+	}
+
+	return target, nil
+}
+
+// previousRevision returns the highest revision below the current revision of the target.
+func previousRevision(ctx context.Context, target rollbackTarget) (int64, error) {
+	list, err := target.ObjectSets(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	list.Sort()
+
+	current := target.CurrentRevision()
+	for i := len(list) - 1; i >= 0; i-- {
+		if rev := list[i].Revision(); rev < current {
+			return rev, nil
+		}
+	}
+
+	return 0, errNoPreviousRevision
+}
+
+var errNoPreviousRevision = errors.New("no previous revision found")
+
+type undoOptions struct {
+	Namespace  string
+	ToRevision int64
+}
+
+func (o *undoOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Namespace,
+		"namespace",
+		"n",
+		o.Namespace,
+		"If present, the namespace scope for this CLI request",
+	)
+	flags.Int64Var(
+		&o.ToRevision,
+		"to-revision",
+		o.ToRevision,
+		"The revision to roll back to. Default to 0 (previous revision)",
+	)
+}
@@ -0,0 +1,212 @@
+package rolloutcmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	internalcmd "package-operator.run/internal/cmd"
+)
+
+func TestUndoCmd(t *testing.T) {
+	t.Parallel()
+
+	packageObjectSet := func(rev int64, image, config string) *corev1alpha1.ObjectSet {
+		return &corev1alpha1.ObjectSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-" + image,
+				Namespace: "test",
+				Labels: map[string]string{
+					manifestsv1alpha1.PackageInstanceLabel: "test",
+				},
+				Annotations: map[string]string{
+					manifestsv1alpha1.PackageSourceImageAnnotation: image,
+					manifestsv1alpha1.PackageConfigAnnotation:      config,
+				},
+			},
+			Status: corev1alpha1.ObjectSetStatus{Revision: rev},
+		}
+	}
+	pkg := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: corev1alpha1.PackageSpec{
+			Image: "v3",
+		},
+		Status: corev1alpha1.PackageStatus{Revision: 3},
+	}
+	packageObjects := []client.Object{
+		pkg,
+		packageObjectSet(1, "v1", `{"banana":"bread"}`),
+		packageObjectSet(2, "v2", "null"),
+		packageObjectSet(3, "v3", "null"),
+	}
+	trackedPkg := pkg.DeepCopy()
+	trackedPkg.Spec.Image = ""
+	trackedPkg.Spec.Track = &corev1alpha1.PackageTrack{Repository: "repo", Package: "test"}
+	configFromPkg := pkg.DeepCopy()
+	configFromPkg.Spec.ConfigFrom = []corev1alpha1.PackageConfigSource{{
+		ConfigMapKeyRef: &corev1alpha1.PackageConfigKeySelector{Name: "config", Key: "config.yaml"},
+	}}
+
+	deploymentObjectSet := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-1",
+			Namespace: "test",
+			Labels: map[string]string{
+				"app": "test",
+			},
+		},
+		Status: corev1alpha1.ObjectSetStatus{Revision: 1},
+	}
+	deployment := &corev1alpha1.ObjectDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+			Labels: map[string]string{
+				"app": "test",
+			},
+		},
+		Status: corev1alpha1.ObjectDeploymentStatus{Revision: 2},
+	}
+	packageDeployment := deployment.DeepCopy()
+	packageDeployment.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: corev1alpha1.GroupVersion.String(),
+		Kind:       "Package",
+		Name:       "test",
+		Controller: ptr.To(true),
+	}}
+
+	for name, tc := range map[string]struct {
+		Args          []string
+		ActualObjects []client.Object
+		Output        string
+		ShouldFail    bool
+		Assert        func(t *testing.T, c client.Client)
+	}{
+		"no args": {
+			ShouldFail: true,
+		},
+		"invalid resource": {
+			Args:       []string{"banana", "test"},
+			ShouldFail: true,
+		},
+		"revision not found": {
+			Args:          []string{"package/test", "-n", "test", "--to-revision", "5"},
+			ActualObjects: packageObjects,
+			ShouldFail:    true,
+		},
+		"package to revision": {
+			Args:          []string{"package/test", "-n", "test", "--to-revision", "1"},
+			ActualObjects: packageObjects,
+			Output:        "package/test rolled back to revision 1\n",
+			Assert: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				actual := &corev1alpha1.Package{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(pkg), actual))
+				assert.Equal(t, "v1", actual.Spec.Image)
+				assert.Equal(t, &runtime.RawExtension{Raw: []byte(`{"banana":"bread"}`)}, actual.Spec.Config)
+			},
+		},
+		"package to previous revision": {
+			Args:          []string{"package", "test", "-n", "test"},
+			ActualObjects: packageObjects,
+			Output:        "package/test rolled back to revision 2\n",
+			Assert: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				actual := &corev1alpha1.Package{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(pkg), actual))
+				assert.Equal(t, "v2", actual.Spec.Image)
+				assert.Nil(t, actual.Spec.Config)
+			},
+		},
+		"tracked package": {
+			Args: []string{"package/test", "-n", "test", "--to-revision", "1"},
+			ActualObjects: []client.Object{
+				trackedPkg,
+				packageObjectSet(1, "v1", "null"),
+			},
+			ShouldFail: true,
+		},
+		"package with config from": {
+			Args: []string{"package/test", "-n", "test", "--to-revision", "1"},
+			ActualObjects: []client.Object{
+				configFromPkg,
+				packageObjectSet(1, "v1", "null"),
+			},
+			ShouldFail: true,
+		},
+		"objectdeployment": {
+			Args:          []string{"objectdeployment/test", "-n", "test", "--to-revision", "1"},
+			ActualObjects: []client.Object{deployment, deploymentObjectSet},
+			Output:        "objectdeployment/test rolled back to revision 1\n",
+			Assert: func(t *testing.T, c client.Client) {
+				t.Helper()
+
+				actual := &corev1alpha1.ObjectDeployment{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(deployment), actual))
+				assert.Equal(t, "1",
+					actual.Annotations[corev1alpha1.ObjectDeploymentRollbackToRevisionAnnotation])
+			},
+		},
+		"objectdeployment managed by package": {
+			Args:          []string{"objectdeployment/test", "-n", "test", "--to-revision", "1"},
+			ActualObjects: []client.Object{packageDeployment, deploymentObjectSet},
+			ShouldFail:    true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme, err := internalcmd.NewScheme()
+			require.NoError(t, err)
+
+			objs := make([]client.Object, 0, len(tc.ActualObjects))
+			for _, obj := range tc.ActualObjects {
+				objs = append(objs, obj.DeepCopyObject().(client.Object))
+			}
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objs...).
+				Build()
+
+			cmd := NewUndoCmd(internalcmd.NewDefaultClientFactory(
+				&kubeClientFactoryMock{
+					Client: c,
+				},
+			))
+			cmd.SetArgs(tc.Args)
+
+			stdout := &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			if tc.ShouldFail {
+				require.Error(t, cmd.Execute())
+
+				return
+			}
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tc.Output, stdout.String())
+
+			if tc.Assert != nil {
+				tc.Assert(t, c)
+			}
+		})
+	}
+}
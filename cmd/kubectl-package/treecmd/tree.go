@@ -22,7 +22,8 @@ func NewCmd(rendererFactory RendererFactory) *cobra.Command {
 	const (
 		cmdUse   = "tree source_path"
 		cmdShort = "outputs a logical tree view of the package contents"
-		cmdLong  = "outputs a logical tree view of the package by printing root->phases->objects"
+		cmdLong  = "outputs a logical tree view of the package by printing root->phases->objects. " +
+			"Source path may be a source directory, an OCI image layout directory or an image tar."
 	)
 
 	var opts options
@@ -19,7 +19,7 @@ func NewCmd(validator Validator) *cobra.Command {
 		validateUse   = "validate [--pull] target"
 		validateShort = "validate a package."
 		validateLong  = "validate a package. Target may be a source directory, " +
-			"a package in a tar[.gz] or a fully qualified tag if --pull is set."
+			"an OCI image layout directory, a package in a tar[.gz] or a fully qualified tag if --pull is set."
 		validationSuccessMessage = "Package validated successfully!"
 	)
 
@@ -15,7 +15,7 @@ type packageObjectLoader interface {
 }
 
 type bootstrapperPullImageFn func(
-	ctx context.Context, image string) (*packages.RawPackage, error)
+	ctx context.Context, image string, opts ...packages.PullOption) (*packages.RawPackage, error)
 
 type packageObjectLoad struct{}
 
@@ -29,6 +29,8 @@ import (
 	"package-operator.run/internal/dynamiccache"
 	"package-operator.run/internal/environment"
 	"package-operator.run/internal/metrics"
+	internalprobing "package-operator.run/internal/probing"
+	"package-operator.run/pkg/probing"
 )
 
 // Returns a new pre-configured DI container.
@@ -39,6 +41,7 @@ func NewComponents() (*dig.Container, error) {
 		ProvideMetricsRecorder, ProvideDynamicCache,
 		ProvideUncachedClient, ProvideOptions, ProvideLogger,
 		ProvideRegistry, ProvideDiscoveryClient, ProvideEnvironmentManager,
+		ProvideProbeEndpoints,
 
 		// -----------
 		// Controllers
@@ -54,6 +57,8 @@ func NewComponents() (*dig.Container, error) {
 		ProvidePackageController, ProvideClusterPackageController,
 		// ObjectTemplate
 		ProvideObjectTemplateController, ProvideClusterObjectTemplateController,
+		// PackageRepository
+		ProvidePackageRepositoryController, ProvideClusterPackageRepositoryController,
 
 		// HostedCluster
 		ProvideHostedClusterController,
@@ -177,6 +182,10 @@ func ProvideUncachedClient(
 	return UncachedClient{uncachedClient}, nil
 }
 
+func ProvideProbeEndpoints(restConfig *rest.Config) (probing.Endpoints, error) {
+	return internalprobing.NewServiceProxyEndpoints(restConfig)
+}
+
 func ProvideDiscoveryClient(restConfig *rest.Config) (
 	discovery.DiscoveryInterface, error,
 ) {
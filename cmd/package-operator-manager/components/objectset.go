@@ -7,6 +7,7 @@ import (
 	"package-operator.run/internal/controllers/objectsets"
 	"package-operator.run/internal/dynamiccache"
 	"package-operator.run/internal/metrics"
+	"package-operator.run/pkg/probing"
 )
 
 // Type alias for dependency injector to differentiate
@@ -21,13 +22,15 @@ func ProvideObjectSetController(
 	dc *dynamiccache.Cache,
 	uncachedClient UncachedClient,
 	recorder *metrics.Recorder,
+	probeEndpoints probing.Endpoints,
 ) ObjectSetController {
 	return ObjectSetController{
 		objectsets.NewObjectSetController(
 			mgr.GetClient(),
 			log.WithName("controllers").WithName("ObjectSet"),
 			mgr.GetScheme(), dc, uncachedClient, recorder,
-			mgr.GetRESTMapper(),
+			mgr.GetRESTMapper(), mgr.GetEventRecorderFor("package-operator"),
+			probeEndpoints,
 		),
 	}
 }
@@ -37,13 +40,15 @@ func ProvideClusterObjectSetController(
 	dc *dynamiccache.Cache,
 	uncachedClient UncachedClient,
 	recorder *metrics.Recorder,
+	probeEndpoints probing.Endpoints,
 ) ClusterObjectSetController {
 	return ClusterObjectSetController{
 		objectsets.NewClusterObjectSetController(
 			mgr.GetClient(),
 			log.WithName("controllers").WithName("ObjectSet"),
 			mgr.GetScheme(), dc, uncachedClient, recorder,
-			mgr.GetRESTMapper(),
+			mgr.GetRESTMapper(), mgr.GetEventRecorderFor("package-operator"),
+			probeEndpoints,
 		),
 	}
 }
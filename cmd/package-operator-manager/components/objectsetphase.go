@@ -6,6 +6,7 @@ import (
 
 	"package-operator.run/internal/controllers/objectsetphases"
 	"package-operator.run/internal/dynamiccache"
+	"package-operator.run/pkg/probing"
 )
 
 // Type alias for dependency injector to differentiate
@@ -21,13 +22,15 @@ func ProvideObjectSetPhaseController(
 	mgr ctrl.Manager, log logr.Logger,
 	dc *dynamiccache.Cache,
 	uncachedClient UncachedClient,
+	probeEndpoints probing.Endpoints,
 ) ObjectSetPhaseController {
 	return ObjectSetPhaseController{
 		objectsetphases.NewSameClusterObjectSetPhaseController(
 			log.WithName("controllers").WithName("ObjectSetPhase"),
 			mgr.GetScheme(), dc, uncachedClient,
 			defaultObjectSetPhaseClass, mgr.GetClient(),
-			mgr.GetRESTMapper(),
+			mgr.GetRESTMapper(), mgr.GetEventRecorderFor("package-operator"),
+			probeEndpoints,
 		),
 	}
 }
@@ -36,13 +39,15 @@ func ProvideClusterObjectSetPhaseController(
 	mgr ctrl.Manager, log logr.Logger,
 	dc *dynamiccache.Cache,
 	uncachedClient UncachedClient,
+	probeEndpoints probing.Endpoints,
 ) ClusterObjectSetPhaseController {
 	return ClusterObjectSetPhaseController{
 		objectsetphases.NewSameClusterClusterObjectSetPhaseController(
 			log.WithName("controllers").WithName("ClusterObjectSetPhase"),
 			mgr.GetScheme(), dc, uncachedClient,
 			defaultObjectSetPhaseClass, mgr.GetClient(),
-			mgr.GetRESTMapper(),
+			mgr.GetRESTMapper(), mgr.GetEventRecorderFor("package-operator"),
+			probeEndpoints,
 		),
 	}
 }
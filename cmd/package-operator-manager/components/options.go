@@ -28,6 +28,14 @@ const (
 		" with Package Operator using the given Package Operator Package Image"
 	registryHostOverrides = "List of registry host overrides to change during image pulling. " +
 		"e.g. quay.io=localhost:123,<original-host>=<new-host>"
+	packageSignaturePublicKeys = "Comma separated list of paths to PEM encoded public keys. " +
+		"When set, package images must be signed by one of these keys to be unpacked."
+	imagePullSecrets = "Comma separated list of Secret names in the Package Operator namespace, " +
+		"used to pull images of packages that do not reference image pull secrets themselves."
+	packageCacheDir = "Directory to cache pulled package images in, keyed by image digest. " +
+		"Mount a persistent volume to keep the cache across restarts. Caching is disabled when empty."
+	packageCacheMaxSize = "Maximum size of the package image cache, e.g. 512Mi. " +
+		"Least recently used images are evicted when exceeded."
 	packageOperatorPackageImage = "Image pointing to a package operator package. " +
 		"This image is currently used with the HyperShift integration to spin up the remote-phase-manager " +
 		"and hosted-cluster-manager for every HostedCluster"
@@ -49,6 +57,10 @@ type Options struct {
 	EnableLeaderElection        bool
 	ProbeAddr                   string
 	RegistryHostOverrides       string
+	PackageSignaturePublicKeys  string
+	ImagePullSecrets            string
+	PackageCacheDir             string
+	PackageCacheMaxSize         string
 	PackageHashModifier         *int32
 	PackageOperatorPackageImage string
 
@@ -106,6 +118,22 @@ func ProvideOptions() (opts Options, err error) {
 		&opts.RegistryHostOverrides, "registry-host-overrides",
 		os.Getenv("PKO_REGISTRY_HOST_OVERRIDES"),
 		registryHostOverrides)
+	flag.StringVar(
+		&opts.PackageSignaturePublicKeys, "package-signature-public-keys",
+		os.Getenv("PKO_PACKAGE_SIGNATURE_PUBLIC_KEYS"),
+		packageSignaturePublicKeys)
+	flag.StringVar(
+		&opts.ImagePullSecrets, "image-pull-secrets",
+		os.Getenv("PKO_IMAGE_PULL_SECRETS"),
+		imagePullSecrets)
+	flag.StringVar(
+		&opts.PackageCacheDir, "package-cache-dir",
+		os.Getenv("PKO_PACKAGE_CACHE_DIR"),
+		packageCacheDir)
+	flag.StringVar(
+		&opts.PackageCacheMaxSize, "package-cache-max-size",
+		os.Getenv("PKO_PACKAGE_CACHE_MAX_SIZE"),
+		packageCacheMaxSize)
 
 	flag.DurationVar(
 		&opts.ObjectTemplateResourceRetryInterval,
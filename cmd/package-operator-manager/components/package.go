@@ -1,12 +1,15 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/resource"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	controllerspackages "package-operator.run/internal/controllers/packages"
+	"package-operator.run/internal/dynamiccache"
 	"package-operator.run/internal/metrics"
 	"package-operator.run/internal/packages"
 )
@@ -22,9 +25,65 @@ type (
 	}
 )
 
-func ProvideRegistry(log logr.Logger, opts Options) *packages.Registry {
+func ProvideRegistry(
+	log logr.Logger, recorder *metrics.Recorder, opts Options,
+) (*packages.Registry, error) {
+	var registryOpts []packages.RegistryOption
+	if len(opts.PackageSignaturePublicKeys) > 0 {
+		keys, err := packages.LoadSignaturePublicKeys(
+			strings.Split(opts.PackageSignaturePublicKeys, ",")...)
+		if err != nil {
+			return nil, err
+		}
+
+		log.WithName("Registry").Info("package signature verification active", "keys", len(keys))
+		registryOpts = append(registryOpts, packages.WithImageVerifier{
+			Verifier: packages.NewSignatureVerifier(keys...),
+		})
+	}
+
+	if len(opts.PackageCacheDir) > 0 {
+		cache, err := preparePackageCache(log, recorder, opts.PackageCacheDir, opts.PackageCacheMaxSize)
+		if err != nil {
+			return nil, err
+		}
+		registryOpts = append(registryOpts, packages.WithPackageCache{Cache: cache})
+	}
+
 	return packages.NewRegistry(
-		prepareRegistryHostOverrides(log, opts.RegistryHostOverrides))
+		prepareRegistryHostOverrides(log, opts.RegistryHostOverrides),
+		registryOpts...,
+	), nil
+}
+
+func imagePullSecretsOption(opts Options) controllerspackages.WithImagePullSecrets {
+	var defaults []string
+	if len(opts.ImagePullSecrets) > 0 {
+		defaults = strings.Split(opts.ImagePullSecrets, ",")
+	}
+	return controllerspackages.WithImagePullSecrets{
+		ManagerNamespace: opts.Namespace,
+		Defaults:         defaults,
+	}
+}
+
+func preparePackageCache(
+	log logr.Logger, recorder *metrics.Recorder, dir, maxSizeFlag string,
+) (*packages.DiskCache, error) {
+	maxSize := packages.DefaultDiskCacheMaxSize
+	if len(maxSizeFlag) > 0 {
+		q, err := resource.ParseQuantity(maxSizeFlag)
+		if err != nil {
+			return nil, fmt.Errorf("parsing package cache max size: %w", err)
+		}
+		maxSize = q.Value()
+	}
+
+	log.WithName("Registry").Info("package cache active", "dir", dir, "maxSize", maxSize)
+	return packages.NewDiskCache(dir,
+		packages.WithDiskCacheMaxSize(maxSize),
+		packages.WithDiskCacheRecorder{Recorder: recorder},
+	)
 }
 
 func prepareRegistryHostOverrides(log logr.Logger, flag string) map[string]string {
@@ -47,6 +106,7 @@ func prepareRegistryHostOverrides(log logr.Logger, flag string) map[string]strin
 
 func ProvidePackageController(
 	mgr ctrl.Manager, log logr.Logger, uncachedClient UncachedClient,
+	dc *dynamiccache.Cache,
 	registry *packages.Registry,
 	recorder *metrics.Recorder,
 	opts Options,
@@ -56,8 +116,9 @@ func ProvidePackageController(
 			mgr.GetClient(),
 			uncachedClient,
 			log.WithName("controllers").WithName("Package"),
-			mgr.GetScheme(),
+			mgr.GetScheme(), dc,
 			registry, recorder, opts.PackageHashModifier,
+			imagePullSecretsOption(opts),
 		),
 	}
 }
@@ -65,6 +126,7 @@ func ProvidePackageController(
 func ProvideClusterPackageController(
 	mgr ctrl.Manager, log logr.Logger,
 	uncachedClient UncachedClient,
+	dc *dynamiccache.Cache,
 	registry *packages.Registry,
 	recorder *metrics.Recorder,
 	opts Options,
@@ -73,8 +135,9 @@ func ProvideClusterPackageController(
 		controllerspackages.NewClusterPackageController(
 			mgr.GetClient(), uncachedClient.Client,
 			log.WithName("controllers").WithName("ClusterPackage"),
-			mgr.GetScheme(),
+			mgr.GetScheme(), dc,
 			registry, recorder, opts.PackageHashModifier,
+			imagePullSecretsOption(opts),
 		),
 	}
 }
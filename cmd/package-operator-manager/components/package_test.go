@@ -5,6 +5,9 @@ import (
 
 	"github.com/go-logr/logr/testr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/metrics"
 )
 
 func Test_prepareRegistryHostOverrides(t *testing.T) {
@@ -13,3 +16,14 @@ func Test_prepareRegistryHostOverrides(t *testing.T) {
 	or := prepareRegistryHostOverrides(log, "quay.io=dev-registry.dev-registry.svc.cluster.local:5001")
 	assert.Equal(t, map[string]string{"quay.io": "dev-registry.dev-registry.svc.cluster.local:5001"}, or)
 }
+
+func Test_preparePackageCache(t *testing.T) {
+	t.Parallel()
+	log := testr.New(t)
+
+	_, err := preparePackageCache(log, metrics.NewRecorder(), t.TempDir(), "64Mi")
+	require.NoError(t, err)
+
+	_, err = preparePackageCache(log, metrics.NewRecorder(), t.TempDir(), "banana")
+	require.Error(t, err)
+}
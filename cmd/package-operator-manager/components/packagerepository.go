@@ -0,0 +1,60 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"package-operator.run/internal/controllers/packagerepositories"
+)
+
+// Type alias for dependency injector to differentiate
+// Cluster and non-cluster scoped *Generic<>Controllers.
+type (
+	PackageRepositoryController struct {
+		controllerAndEnvSinker
+	}
+	ClusterPackageRepositoryController struct {
+		controllerAndEnvSinker
+	}
+)
+
+func ProvidePackageRepositoryController(
+	mgr ctrl.Manager, log logr.Logger,
+	uncachedClient UncachedClient,
+	options Options,
+) PackageRepositoryController {
+	return PackageRepositoryController{
+		packagerepositories.NewPackageRepositoryController(
+			mgr.GetClient(), uncachedClient,
+			log.WithName("controllers").WithName("PackageRepository"),
+			mgr.GetScheme(), packageRepositoryControllerConfig(options),
+		),
+	}
+}
+
+func ProvideClusterPackageRepositoryController(
+	mgr ctrl.Manager, log logr.Logger,
+	uncachedClient UncachedClient,
+	options Options,
+) ClusterPackageRepositoryController {
+	return ClusterPackageRepositoryController{
+		packagerepositories.NewClusterPackageRepositoryController(
+			mgr.GetClient(), uncachedClient,
+			log.WithName("controllers").WithName("ClusterPackageRepository"),
+			mgr.GetScheme(), packageRepositoryControllerConfig(options),
+		),
+	}
+}
+
+func packageRepositoryControllerConfig(opts Options) packagerepositories.ControllerConfig {
+	var defaults []string
+	if len(opts.ImagePullSecrets) > 0 {
+		defaults = strings.Split(opts.ImagePullSecrets, ",")
+	}
+	return packagerepositories.ControllerConfig{
+		ManagerNamespace:        opts.Namespace,
+		DefaultImagePullSecrets: defaults,
+	}
+}
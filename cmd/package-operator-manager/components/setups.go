@@ -52,6 +52,9 @@ type AllControllers struct {
 
 	ObjectTemplate        ObjectTemplateController
 	ClusterObjectTemplate ClusterObjectTemplateController
+
+	PackageRepository        PackageRepositoryController
+	ClusterPackageRepository ClusterPackageRepositoryController
 }
 
 func (ac AllControllers) List() []any {
@@ -61,6 +64,7 @@ func (ac AllControllers) List() []any {
 		ac.ObjectDeployment, ac.ClusterObjectDeployment,
 		ac.Package, ac.ClusterPackage,
 		ac.ObjectTemplate, ac.ClusterObjectTemplate,
+		ac.PackageRepository, ac.ClusterPackageRepository,
 	}
 }
 
@@ -106,6 +110,14 @@ func (ac AllControllers) SetupWithManager(mgr ctrl.Manager) error {
 			name:       "ClusterObjectTemplate",
 			controller: ac.ClusterObjectTemplate,
 		},
+		{
+			name:       "PackageRepository",
+			controller: ac.PackageRepository,
+		},
+		{
+			name:       "ClusterPackageRepository",
+			controller: ac.ClusterPackageRepository,
+		},
 	})
 }
 
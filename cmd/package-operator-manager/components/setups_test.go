@@ -65,6 +65,8 @@ func TestAllControllers(t *testing.T) {
 		cpkg   = newMock()
 		otmpl  = newMock()
 		cotmpl = newMock()
+		prepo  = newMock()
+		cprepo = newMock()
 	)
 	all := AllControllers{
 		ObjectSet:        ObjectSetController{os},
@@ -81,6 +83,9 @@ func TestAllControllers(t *testing.T) {
 
 		ObjectTemplate:        ObjectTemplateController{otmpl},
 		ClusterObjectTemplate: ClusterObjectTemplateController{cotmpl},
+
+		PackageRepository:        PackageRepositoryController{prepo},
+		ClusterPackageRepository: ClusterPackageRepositoryController{cprepo},
 	}
 	err := all.SetupWithManager(nil)
 	require.NoError(t, err)
@@ -88,7 +93,7 @@ func TestAllControllers(t *testing.T) {
 	for _, m := range mocks {
 		m.AssertExpectations(t)
 	}
-	assert.Len(t, all.List(), 10)
+	assert.Len(t, all.List(), 12)
 }
 
 func TestBootstrapControllers(t *testing.T) {
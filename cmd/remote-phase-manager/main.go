@@ -31,6 +31,7 @@ import (
 	"package-operator.run/internal/controllers/objectsetphases"
 	"package-operator.run/internal/dynamiccache"
 	"package-operator.run/internal/metrics"
+	internalprobing "package-operator.run/internal/probing"
 	"package-operator.run/internal/version"
 )
 
@@ -197,6 +198,12 @@ func run(log logr.Logger, scheme *runtime.Scheme, opts opts) error {
 		return fmt.Errorf("unable to set up uncached client: %w", err)
 	}
 
+	// Probe endpoints of the target cluster through its API server.
+	probeEndpoints, err := internalprobing.NewServiceProxyEndpoints(targetCfg)
+	if err != nil {
+		return fmt.Errorf("creating target cluster probe endpoints: %w", err)
+	}
+
 	managementClusterClient := mgr.GetClient()
 
 	if err = objectsetphases.NewMultiClusterObjectSetPhaseController(
@@ -204,6 +211,8 @@ func run(log logr.Logger, scheme *runtime.Scheme, opts opts) error {
 		mgr.GetScheme(), dc, uncachedTargetClient,
 		opts.class, managementClusterClient,
 		targetClient, targetMapper,
+		mgr.GetEventRecorderFor("remote-phase-manager"),
+		probeEndpoints,
 	).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create controller for ObjectSetPhase: %w", err)
 	}
@@ -215,6 +224,8 @@ func run(log logr.Logger, scheme *runtime.Scheme, opts opts) error {
 			mgr.GetScheme(), dc, uncachedTargetClient,
 			opts.class, managementClusterClient,
 			targetClient, targetMapper,
+			mgr.GetEventRecorderFor("remote-phase-manager"),
+			probeEndpoints,
 		).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to create controller for ClusterObjectSetPhase: %w", err)
 		}
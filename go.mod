@@ -24,6 +24,7 @@ require (
 	github.com/openshift/api v0.0.0-20240806000012-e65e6f54eb3c
 	github.com/operator-framework/api v0.26.0
 	github.com/operator-framework/deppy v0.3.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/prometheus/client_golang v1.20.4
 	github.com/pterm/pterm v0.12.79
 	github.com/spf13/cobra v1.8.1
@@ -122,7 +123,6 @@ require (
 	github.com/pborman/uuid v1.2.1 // indirect
 	github.com/pelletier/go-toml v1.9.5 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/prometheus/client_model v0.6.1 // indirect
 	github.com/prometheus/common v0.60.0 // indirect
 	github.com/prometheus/procfs v0.15.1 // indirect
@@ -18,6 +18,8 @@ type ObjectDeploymentAccessor interface {
 	SetTemplateSpec(corev1alpha1.ObjectSetTemplateSpec)
 	GetTemplateSpec() corev1alpha1.ObjectSetTemplateSpec
 	GetRevisionHistoryLimit() *int32
+	GetRolloutStrategy() *corev1alpha1.ObjectDeploymentRolloutStrategy
+	SetRolloutStrategy(strategy *corev1alpha1.ObjectDeploymentRolloutStrategy)
 	SetStatusConditions(...metav1.Condition)
 	SetStatusCollisionCount(*int32)
 	GetStatusCollisionCount() *int32
@@ -74,6 +76,14 @@ func (a *ObjectDeployment) GetRevisionHistoryLimit() *int32 {
 	return a.Spec.RevisionHistoryLimit
 }
 
+func (a *ObjectDeployment) GetRolloutStrategy() *corev1alpha1.ObjectDeploymentRolloutStrategy {
+	return a.Spec.RolloutStrategy
+}
+
+func (a *ObjectDeployment) SetRolloutStrategy(strategy *corev1alpha1.ObjectDeploymentRolloutStrategy) {
+	a.Spec.RolloutStrategy = strategy
+}
+
 func (a *ObjectDeployment) SetStatusCollisionCount(cc *int32) {
 	a.Status.CollisionCount = cc
 }
@@ -157,6 +167,14 @@ func (a *ClusterObjectDeployment) GetRevisionHistoryLimit() *int32 {
 	return a.Spec.RevisionHistoryLimit
 }
 
+func (a *ClusterObjectDeployment) GetRolloutStrategy() *corev1alpha1.ObjectDeploymentRolloutStrategy {
+	return a.Spec.RolloutStrategy
+}
+
+func (a *ClusterObjectDeployment) SetRolloutStrategy(strategy *corev1alpha1.ObjectDeploymentRolloutStrategy) {
+	a.Spec.RolloutStrategy = strategy
+}
+
 func (a *ClusterObjectDeployment) SetStatusCollisionCount(cc *int32) {
 	a.Status.CollisionCount = cc
 }
@@ -24,6 +24,15 @@ type GenericPackageAccessor interface {
 	SetStatusRevision(rev int64)
 	GetStatusRevision() int64
 	GetComponent() string
+	GetRolloutStrategy() *corev1alpha1.ObjectDeploymentRolloutStrategy
+	GetImagePullSecrets() []corev1alpha1.ImagePullSecretReference
+	GetConfigFrom() []corev1alpha1.PackageConfigSource
+	GetUpgradePolicy() *corev1alpha1.PackageUpgradePolicy
+	GetPendingHash() string
+	SetPendingHash(hash string)
+	GetTrack() *corev1alpha1.PackageTrack
+	GetTrackStatus() *corev1alpha1.PackageTrackStatus
+	SetTrackStatus(status *corev1alpha1.PackageTrackStatus)
 }
 
 type GenericPackageFactory func(scheme *runtime.Scheme) GenericPackageAccessor
@@ -72,6 +81,42 @@ func (a *GenericPackage) GetComponent() string {
 	return a.Spec.Component
 }
 
+func (a *GenericPackage) GetRolloutStrategy() *corev1alpha1.ObjectDeploymentRolloutStrategy {
+	return a.Spec.RolloutStrategy
+}
+
+func (a *GenericPackage) GetImagePullSecrets() []corev1alpha1.ImagePullSecretReference {
+	return a.Spec.ImagePullSecrets
+}
+
+func (a *GenericPackage) GetConfigFrom() []corev1alpha1.PackageConfigSource {
+	return a.Spec.ConfigFrom
+}
+
+func (a *GenericPackage) GetTrack() *corev1alpha1.PackageTrack {
+	return a.Spec.Track
+}
+
+func (a *GenericPackage) GetTrackStatus() *corev1alpha1.PackageTrackStatus {
+	return a.Status.Track
+}
+
+func (a *GenericPackage) SetTrackStatus(status *corev1alpha1.PackageTrackStatus) {
+	a.Status.Track = status
+}
+
+func (a *GenericPackage) GetUpgradePolicy() *corev1alpha1.PackageUpgradePolicy {
+	return a.Spec.UpgradePolicy
+}
+
+func (a *GenericPackage) GetPendingHash() string {
+	return a.Status.PendingHash
+}
+
+func (a *GenericPackage) SetPendingHash(hash string) {
+	a.Status.PendingHash = hash
+}
+
 func (a *GenericPackage) GetConditions() *[]metav1.Condition {
 	return &a.Status.Conditions
 }
@@ -80,12 +125,14 @@ func (a *GenericPackage) UpdatePhase() {
 	updatePackagePhase(a)
 }
 
+// GetImage returns the image to install,
+// which is the image resolved from the repository for tracked packages.
 func (a *GenericPackage) GetImage() string {
-	return a.Spec.Image
+	return packageImage(a.Spec, a.Status)
 }
 
 func (a *GenericPackage) GetSpecHash(packageHashModifier *int32) string {
-	return utils.ComputeSHA256Hash(a.Spec, packageHashModifier)
+	return specHash(a.Spec, a.Status, packageHashModifier)
 }
 
 func (a *GenericPackage) SetUnpackedHash(hash string) {
@@ -112,7 +159,7 @@ func (a *GenericPackage) TemplateContext() manifests.TemplateContext {
 	return manifests.TemplateContext{
 		Package: manifests.TemplateContextPackage{
 			TemplateContextObjectMeta: templateContextObjectMetaFromObjectMeta(a.ObjectMeta),
-			Image:                     a.Spec.Image,
+			Image:                     a.GetImage(),
 		},
 		Config: a.Package.Spec.Config,
 	}
@@ -130,6 +177,42 @@ func (a *GenericClusterPackage) GetComponent() string {
 	return a.Spec.Component
 }
 
+func (a *GenericClusterPackage) GetRolloutStrategy() *corev1alpha1.ObjectDeploymentRolloutStrategy {
+	return a.Spec.RolloutStrategy
+}
+
+func (a *GenericClusterPackage) GetImagePullSecrets() []corev1alpha1.ImagePullSecretReference {
+	return a.Spec.ImagePullSecrets
+}
+
+func (a *GenericClusterPackage) GetConfigFrom() []corev1alpha1.PackageConfigSource {
+	return a.Spec.ConfigFrom
+}
+
+func (a *GenericClusterPackage) GetTrack() *corev1alpha1.PackageTrack {
+	return a.Spec.Track
+}
+
+func (a *GenericClusterPackage) GetTrackStatus() *corev1alpha1.PackageTrackStatus {
+	return a.Status.Track
+}
+
+func (a *GenericClusterPackage) SetTrackStatus(status *corev1alpha1.PackageTrackStatus) {
+	a.Status.Track = status
+}
+
+func (a *GenericClusterPackage) GetUpgradePolicy() *corev1alpha1.PackageUpgradePolicy {
+	return a.Spec.UpgradePolicy
+}
+
+func (a *GenericClusterPackage) GetPendingHash() string {
+	return a.Status.PendingHash
+}
+
+func (a *GenericClusterPackage) SetPendingHash(hash string) {
+	a.Status.PendingHash = hash
+}
+
 func (a *GenericClusterPackage) GetConditions() *[]metav1.Condition {
 	return &a.Status.Conditions
 }
@@ -138,12 +221,14 @@ func (a *GenericClusterPackage) UpdatePhase() {
 	updatePackagePhase(a)
 }
 
+// GetImage returns the image to install,
+// which is the image resolved from the repository for tracked packages.
 func (a *GenericClusterPackage) GetImage() string {
-	return a.Spec.Image
+	return packageImage(a.Spec, a.Status)
 }
 
 func (a *GenericClusterPackage) GetSpecHash(packageHashModifier *int32) string {
-	return utils.ComputeSHA256Hash(a.Spec, packageHashModifier)
+	return specHash(a.Spec, a.Status, packageHashModifier)
 }
 
 func (a *GenericClusterPackage) SetStatusRevision(rev int64) {
@@ -162,7 +247,7 @@ func (a *GenericClusterPackage) TemplateContext() manifests.TemplateContext {
 	return manifests.TemplateContext{
 		Package: manifests.TemplateContextPackage{
 			TemplateContextObjectMeta: templateContextObjectMetaFromObjectMeta(a.ObjectMeta),
-			Image:                     a.Spec.Image,
+			Image:                     a.GetImage(),
 		},
 		Config: a.Spec.Config,
 	}
@@ -176,6 +261,27 @@ func (a *GenericClusterPackage) GetUnpackedHash() string {
 	return a.Status.UnpackedHash
 }
 
+// Changes to the upgrade policy are not held back by the upgrade policy itself,
+// so it is excluded from the hash of changes to roll out.
+// Tracked packages are rolled out again whenever a new version is resolved.
+func specHash(
+	spec corev1alpha1.PackageSpec, status corev1alpha1.PackageStatus, packageHashModifier *int32,
+) string {
+	spec.UpgradePolicy = nil
+	spec.Image = packageImage(spec, status)
+	return utils.ComputeSHA256Hash(spec, packageHashModifier)
+}
+
+func packageImage(spec corev1alpha1.PackageSpec, status corev1alpha1.PackageStatus) string {
+	if spec.Track == nil {
+		return spec.Image
+	}
+	if status.Track == nil {
+		return ""
+	}
+	return status.Track.Image
+}
+
 func updatePackagePhase(pkg GenericPackageAccessor) {
 	if meta.IsStatusConditionTrue(*pkg.GetConditions(), corev1alpha1.PackageInvalid) {
 		pkg.setStatusPhase(corev1alpha1.PackagePhaseInvalid)
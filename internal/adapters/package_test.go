@@ -20,6 +20,32 @@ func TestGenericPackage(t *testing.T) {
 	assert.Equal(
 		t, corev1alpha1.PackagePhaseUnpacking, p.Status.Phase)
 
+	assert.Nil(t, pkg.GetUpgradePolicy())
+	hash := pkg.GetSpecHash(nil)
+	p.Spec.UpgradePolicy = &corev1alpha1.PackageUpgradePolicy{
+		Approval: corev1alpha1.PackageUpgradeApprovalManual,
+	}
+	assert.Same(t, p.Spec.UpgradePolicy, pkg.GetUpgradePolicy())
+	assert.Equal(t, hash, pkg.GetSpecHash(nil))
+
+	pkg.SetPendingHash("456")
+	assert.Equal(t, "456", p.Status.PendingHash)
+	assert.Equal(t, "456", pkg.GetPendingHash())
+
+	assert.Nil(t, pkg.GetTrack())
+	p.Spec.Track = &corev1alpha1.PackageTrack{Repository: "repo", Package: "pkg"}
+	assert.Same(t, p.Spec.Track, pkg.GetTrack())
+	assert.Empty(t, pkg.GetImage())
+	trackStatus := &corev1alpha1.PackageTrackStatus{Version: "1.0.0", Image: "pkg@sha256:1"}
+	pkg.SetTrackStatus(trackStatus)
+	assert.Same(t, trackStatus, pkg.GetTrackStatus())
+	assert.Equal(t, "pkg@sha256:1", pkg.GetImage())
+	hash = pkg.GetSpecHash(nil)
+	pkg.SetTrackStatus(&corev1alpha1.PackageTrackStatus{Version: "1.1.0", Image: "pkg@sha256:2"})
+	assert.NotEqual(t, hash, pkg.GetSpecHash(nil))
+	p.Spec.Track = nil
+	p.Status.Track = nil
+
 	p.Spec.Image = "test"
 	assert.Equal(t, p.Spec.Image, pkg.GetImage())
 
@@ -64,6 +90,32 @@ func TestGenericClusterPackage(t *testing.T) {
 	assert.Equal(
 		t, corev1alpha1.PackagePhaseUnpacking, p.Status.Phase)
 
+	assert.Nil(t, pkg.GetUpgradePolicy())
+	hash := pkg.GetSpecHash(nil)
+	p.Spec.UpgradePolicy = &corev1alpha1.PackageUpgradePolicy{
+		Approval: corev1alpha1.PackageUpgradeApprovalManual,
+	}
+	assert.Same(t, p.Spec.UpgradePolicy, pkg.GetUpgradePolicy())
+	assert.Equal(t, hash, pkg.GetSpecHash(nil))
+
+	pkg.SetPendingHash("456")
+	assert.Equal(t, "456", p.Status.PendingHash)
+	assert.Equal(t, "456", pkg.GetPendingHash())
+
+	assert.Nil(t, pkg.GetTrack())
+	p.Spec.Track = &corev1alpha1.PackageTrack{Repository: "repo", Package: "pkg"}
+	assert.Same(t, p.Spec.Track, pkg.GetTrack())
+	assert.Empty(t, pkg.GetImage())
+	trackStatus := &corev1alpha1.PackageTrackStatus{Version: "1.0.0", Image: "pkg@sha256:1"}
+	pkg.SetTrackStatus(trackStatus)
+	assert.Same(t, trackStatus, pkg.GetTrackStatus())
+	assert.Equal(t, "pkg@sha256:1", pkg.GetImage())
+	hash = pkg.GetSpecHash(nil)
+	pkg.SetTrackStatus(&corev1alpha1.PackageTrackStatus{Version: "1.1.0", Image: "pkg@sha256:2"})
+	assert.NotEqual(t, hash, pkg.GetSpecHash(nil))
+	p.Spec.Track = nil
+	p.Status.Track = nil
+
 	p.Spec.Image = "test"
 	assert.Equal(t, p.Spec.Image, pkg.GetImage())
 
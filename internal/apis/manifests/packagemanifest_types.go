@@ -20,6 +20,7 @@ const (
 	PackageSourceImageAnnotation = manifestsv1alpha1.PackageSourceImageAnnotation
 	PackageConfigAnnotation      = manifestsv1alpha1.PackageConfigAnnotation
 	PackageInstanceLabel         = manifestsv1alpha1.PackageInstanceLabel
+	PackageDependencyOfLabel     = manifestsv1alpha1.PackageDependencyOfLabel
 )
 
 // +kubebuilder:object:root=true
@@ -126,6 +127,19 @@ type PackageManifestRepository struct {
 type PackageManifestDependency struct {
 	// Resolves the dependency as a image url and digest and commits it to the PackageManifestLock.
 	Image *PackageManifestDependencyImage
+	// Installs the locked dependency as a child Package.
+	Install *PackageManifestDependencyInstall
+}
+
+// Configures how a dependency is installed into the cluster.
+type PackageManifestDependencyInstall struct {
+	// Phase to install the dependency Package in.
+	Phase string
+	// Namespace to install the dependency Package into.
+	Namespace string
+	// Top-level property of the package configuration
+	// which is passed through as configuration to the dependency.
+	ConfigKey string
 }
 
 type PackageManifestDependencyImage struct {
@@ -183,6 +197,8 @@ type PackageManifestPhase struct {
 	// If set to the string "default" the built-in controller reconciling the object.
 	// If set to any other string, an out-of-tree controller needs to be present to handle ObjectSetPhase objects.
 	Class string
+	// Marks this phase as a lifecycle hook, see ObjectSetTemplatePhase.
+	Hook corev1alpha1.ObjectSetPhaseHook
 }
 
 // PackageManifestImage specifies an image tag to be resolved.
@@ -143,6 +143,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PackageManifestDependencyInstall)(nil), (*v1alpha1.PackageManifestDependencyInstall)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_manifests_PackageManifestDependencyInstall_To_v1alpha1_PackageManifestDependencyInstall(a.(*PackageManifestDependencyInstall), b.(*v1alpha1.PackageManifestDependencyInstall), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PackageManifestDependencyInstall)(nil), (*PackageManifestDependencyInstall)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageManifestDependencyInstall_To_manifests_PackageManifestDependencyInstall(a.(*v1alpha1.PackageManifestDependencyInstall), b.(*PackageManifestDependencyInstall), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PackageManifestFilter)(nil), (*v1alpha1.PackageManifestFilter)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_manifests_PackageManifestFilter_To_v1alpha1_PackageManifestFilter(a.(*PackageManifestFilter), b.(*v1alpha1.PackageManifestFilter), scope)
 	}); err != nil {
@@ -619,6 +629,7 @@ func Convert_v1alpha1_PackageManifestConstraint_To_manifests_PackageManifestCons
 
 func autoConvert_manifests_PackageManifestDependency_To_v1alpha1_PackageManifestDependency(in *PackageManifestDependency, out *v1alpha1.PackageManifestDependency, s conversion.Scope) error {
 	out.Image = (*v1alpha1.PackageManifestDependencyImage)(unsafe.Pointer(in.Image))
+	out.Install = (*v1alpha1.PackageManifestDependencyInstall)(unsafe.Pointer(in.Install))
 	return nil
 }
 
@@ -629,6 +640,7 @@ func Convert_manifests_PackageManifestDependency_To_v1alpha1_PackageManifestDepe
 
 func autoConvert_v1alpha1_PackageManifestDependency_To_manifests_PackageManifestDependency(in *v1alpha1.PackageManifestDependency, out *PackageManifestDependency, s conversion.Scope) error {
 	out.Image = (*PackageManifestDependencyImage)(unsafe.Pointer(in.Image))
+	out.Install = (*PackageManifestDependencyInstall)(unsafe.Pointer(in.Install))
 	return nil
 }
 
@@ -661,6 +673,30 @@ func Convert_v1alpha1_PackageManifestDependencyImage_To_manifests_PackageManifes
 	return autoConvert_v1alpha1_PackageManifestDependencyImage_To_manifests_PackageManifestDependencyImage(in, out, s)
 }
 
+func autoConvert_manifests_PackageManifestDependencyInstall_To_v1alpha1_PackageManifestDependencyInstall(in *PackageManifestDependencyInstall, out *v1alpha1.PackageManifestDependencyInstall, s conversion.Scope) error {
+	out.Phase = in.Phase
+	out.Namespace = in.Namespace
+	out.ConfigKey = in.ConfigKey
+	return nil
+}
+
+// Convert_manifests_PackageManifestDependencyInstall_To_v1alpha1_PackageManifestDependencyInstall is an autogenerated conversion function.
+func Convert_manifests_PackageManifestDependencyInstall_To_v1alpha1_PackageManifestDependencyInstall(in *PackageManifestDependencyInstall, out *v1alpha1.PackageManifestDependencyInstall, s conversion.Scope) error {
+	return autoConvert_manifests_PackageManifestDependencyInstall_To_v1alpha1_PackageManifestDependencyInstall(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageManifestDependencyInstall_To_manifests_PackageManifestDependencyInstall(in *v1alpha1.PackageManifestDependencyInstall, out *PackageManifestDependencyInstall, s conversion.Scope) error {
+	out.Phase = in.Phase
+	out.Namespace = in.Namespace
+	out.ConfigKey = in.ConfigKey
+	return nil
+}
+
+// Convert_v1alpha1_PackageManifestDependencyInstall_To_manifests_PackageManifestDependencyInstall is an autogenerated conversion function.
+func Convert_v1alpha1_PackageManifestDependencyInstall_To_manifests_PackageManifestDependencyInstall(in *v1alpha1.PackageManifestDependencyInstall, out *PackageManifestDependencyInstall, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageManifestDependencyInstall_To_manifests_PackageManifestDependencyInstall(in, out, s)
+}
+
 func autoConvert_manifests_PackageManifestFilter_To_v1alpha1_PackageManifestFilter(in *PackageManifestFilter, out *v1alpha1.PackageManifestFilter, s conversion.Scope) error {
 	out.Conditions = *(*[]v1alpha1.PackageManifestNamedCondition)(unsafe.Pointer(&in.Conditions))
 	out.Paths = *(*[]v1alpha1.PackageManifestPath)(unsafe.Pointer(&in.Paths))
@@ -850,6 +886,7 @@ func Convert_v1alpha1_PackageManifestPath_To_manifests_PackageManifestPath(in *v
 func autoConvert_manifests_PackageManifestPhase_To_v1alpha1_PackageManifestPhase(in *PackageManifestPhase, out *v1alpha1.PackageManifestPhase, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Class = in.Class
+	out.Hook = corev1alpha1.ObjectSetPhaseHook(in.Hook)
 	return nil
 }
 
@@ -861,6 +898,7 @@ func Convert_manifests_PackageManifestPhase_To_v1alpha1_PackageManifestPhase(in
 func autoConvert_v1alpha1_PackageManifestPhase_To_manifests_PackageManifestPhase(in *v1alpha1.PackageManifestPhase, out *PackageManifestPhase, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Class = in.Class
+	out.Hook = corev1alpha1.ObjectSetPhaseHook(in.Hook)
 	return nil
 }
 
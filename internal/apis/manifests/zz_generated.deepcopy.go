@@ -228,6 +228,11 @@ func (in *PackageManifestDependency) DeepCopyInto(out *PackageManifestDependency
 		*out = new(PackageManifestDependencyImage)
 		**out = **in
 	}
+	if in.Install != nil {
+		in, out := &in.Install, &out.Install
+		*out = new(PackageManifestDependencyInstall)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageManifestDependency.
@@ -255,6 +260,21 @@ func (in *PackageManifestDependencyImage) DeepCopy() *PackageManifestDependencyI
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageManifestDependencyInstall) DeepCopyInto(out *PackageManifestDependencyInstall) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageManifestDependencyInstall.
+func (in *PackageManifestDependencyInstall) DeepCopy() *PackageManifestDependencyInstall {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageManifestDependencyInstall)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageManifestFilter) DeepCopyInto(out *PackageManifestFilter) {
 	*out = *in
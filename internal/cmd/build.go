@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	containerregistrypkgv1 "github.com/google/go-containerregistry/pkg/v1"
 
 	"package-operator.run/internal/packages"
 )
@@ -65,6 +68,20 @@ func (b *Build) BuildFromSource(ctx context.Context, srcPath string, opts ...Bui
 
 	cfg.Option(opts...)
 
+	var signKey crypto.Signer
+	if cfg.SignKey != "" {
+		key, err := packages.LoadSignaturePrivateKey(cfg.SignKey)
+		if err != nil {
+			return fmt.Errorf("loading sign key: %w", err)
+		}
+		signKey = key
+	}
+
+	exporter, err := cfg.exporter()
+	if err != nil {
+		return err
+	}
+
 	rawPkg, err := getPackageFromPath(ctx, srcPath)
 	if err != nil {
 		return fmt.Errorf("load source from disk path %s: %w", srcPath, err)
@@ -87,10 +104,10 @@ func (b *Build) BuildFromSource(ctx context.Context, srcPath string, opts ...Bui
 			&packages.LockfileDigestLookupValidator{
 				CraneOptions: craneOpts,
 			},
-			packages.NewTemplateTestValidator(srcPath),
 		},
-		packages.DefaultPackageValidators...,
+		templateTestValidators(srcPath)...,
 	)
+	validators = append(validators, packages.DefaultPackageValidators...)
 	if err := validators.ValidatePackage(ctx, pkg); err != nil {
 		return fmt.Errorf("loading package from files: %w", err)
 	}
@@ -98,15 +115,51 @@ func (b *Build) BuildFromSource(ctx context.Context, srcPath string, opts ...Bui
 	if cfg.OutputPath != "" {
 		b.cfg.Log.Info("writing tagged image to disk", "path", cfg.OutputPath)
 
-		if err := packages.ToOCIFile(cfg.OutputPath, cfg.Tags, rawPkg); err != nil {
+		if err := exporter.ToOCIFile(cfg.OutputPath, cfg.Tags, rawPkg); err != nil {
 			return fmt.Errorf("exporting package to file: %w", err)
 		}
 	}
 
 	if cfg.Push {
-		if err := packages.ToPushedOCI(ctx, cfg.Tags, rawPkg, craneOpts...); err != nil {
+		if err := exporter.ToPushedOCI(ctx, cfg.Tags, rawPkg, craneOpts...); err != nil {
 			return fmt.Errorf("exporting package to image: %w", err)
 		}
+
+		if signKey != nil {
+			if err := b.sign(ctx, signKey, exporter, cfg.Tags, rawPkg, craneOpts...); err != nil {
+				return fmt.Errorf("signing package image: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Signs the pushed image of the given package for each of the given tags.
+func (b *Build) sign(
+	ctx context.Context, key crypto.Signer, exporter packages.Exporter, tags []string,
+	rawPkg *packages.RawPackage, craneOpts ...crane.Option,
+) error {
+	// Exporting is reproducible, so the digest matches the pushed image.
+	image, err := exporter.ToOCI(rawPkg)
+	if err != nil {
+		return err
+	}
+	digest, err := image.Digest()
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		ref, err := name.ParseReference(tag)
+		if err != nil {
+			return fmt.Errorf("parsing tag %s: %w", tag, err)
+		}
+
+		b.cfg.Log.Info("signing image", "reference", tag)
+		if err := packages.SignImage(ctx, ref.Context().Digest(digest.String()), key, craneOpts...); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -117,6 +170,31 @@ type BuildFromSourceConfig struct {
 	OutputPath string
 	Tags       []string
 	Push       bool
+	// Path to a private key to sign pushed images with.
+	SignKey string
+	// Format of the exported package image: image, artifact or index.
+	// Defaults to image.
+	ExportFormat string
+	// Platforms listed in image indexes, e.g. linux/arm64.
+	Platforms []string
+}
+
+// Returns the package exporter for the configured format and platforms.
+func (c *BuildFromSourceConfig) exporter() (packages.Exporter, error) {
+	exporter := packages.Exporter{Format: packages.ExportFormat(c.ExportFormat)}
+	if len(c.Platforms) > 0 && exporter.Format != packages.ExportFormatIndex {
+		return exporter, BuildValidationError{Msg: "platforms can only be set for the index format"}
+	}
+
+	for _, p := range c.Platforms {
+		platform, err := containerregistrypkgv1.ParsePlatform(p)
+		if err != nil {
+			return exporter, fmt.Errorf("parsing platform %q: %w", p, err)
+		}
+		exporter.Platforms = append(exporter.Platforms, *platform)
+	}
+
+	return exporter, nil
 }
 
 func (c *BuildFromSourceConfig) Option(opts ...BuildFromSourceOption) {
@@ -6,16 +6,28 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/apis/manifests"
+)
+
+var (
+	ErrRevisionNotFound    = errors.New("revision not found")
+	ErrRollbackNotPossible = errors.New("rollback not possible")
+	ErrNoPendingUpgrade    = errors.New("no pending upgrade")
 )
 
 func NewClient(client client.Client) *Client {
@@ -81,6 +93,134 @@ func (c *Client) GetClusterObjectset(ctx context.Context, name string,
 	return objres, nil
 }
 
+// GetObjectSetByName returns the ObjectSet (or ClusterObjectSet if no namespace is given) with the given name.
+func (c *Client) GetObjectSetByName(
+	ctx context.Context, name string, opts ...GetObjectSetOption,
+) (ObjectSet, error) {
+	var cfg GetObjectSetConfig
+
+	cfg.Option(opts...)
+
+	var obj client.Object
+
+	if cfg.Namespace != "" {
+		obj = &corev1alpha1.ObjectSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: cfg.Namespace,
+			},
+		}
+	} else {
+		obj = &corev1alpha1.ClusterObjectSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+	}
+
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return ObjectSet{}, fmt.Errorf("getting objectset object: %w", err)
+	}
+
+	return NewObjectSet(obj), nil
+}
+
+type GetObjectSetConfig struct {
+	Namespace string
+}
+
+func (c *GetObjectSetConfig) Option(opts ...GetObjectSetOption) {
+	for _, opt := range opts {
+		opt.ConfigureGetObjectSet(c)
+	}
+}
+
+type GetObjectSetOption interface {
+	ConfigureGetObjectSet(*GetObjectSetConfig)
+}
+
+// ObjectSetObjects returns all objects of the given ObjectSet in phase order,
+// including objects that have been offloaded into ObjectSlices.
+func (c *Client) ObjectSetObjects(ctx context.Context, os ObjectSet) ([]unstructured.Unstructured, error) {
+	var objects []unstructured.Unstructured
+
+	for _, phase := range os.Phases() {
+		phaseObjects, err := c.PhaseObjects(ctx, os, phase)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, phaseObjects...)
+	}
+
+	return objects, nil
+}
+
+// PhaseObjects returns all objects of the given phase of an ObjectSet,
+// including objects that have been offloaded into ObjectSlices.
+func (c *Client) PhaseObjects(
+	ctx context.Context, os ObjectSet, phase corev1alpha1.ObjectSetTemplatePhase,
+) ([]unstructured.Unstructured, error) {
+	objects := make([]unstructured.Unstructured, 0, len(phase.Objects))
+
+	for _, obj := range phase.Objects {
+		objects = append(objects, obj.Object)
+	}
+
+	for _, sliceName := range phase.Slices {
+		sliceObjects, err := c.getObjectSliceObjects(ctx, sliceName, os.Namespace())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range sliceObjects {
+			objects = append(objects, obj.Object)
+		}
+	}
+
+	return objects, nil
+}
+
+func (c *Client) getObjectSliceObjects(
+	ctx context.Context, name, ns string,
+) ([]corev1alpha1.ObjectSetObject, error) {
+	if ns == "" {
+		slice := &corev1alpha1.ClusterObjectSlice{}
+		if err := c.client.Get(ctx, client.ObjectKey{Name: name}, slice); err != nil {
+			return nil, fmt.Errorf("getting ClusterObjectSlice %s: %w", name, err)
+		}
+
+		return slice.Objects, nil
+	}
+
+	slice := &corev1alpha1.ObjectSlice{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, slice); err != nil {
+		return nil, fmt.Errorf("getting ObjectSlice %s/%s: %w", ns, name, err)
+	}
+
+	return slice.Objects, nil
+}
+
+// GetLiveObject looks up the in-cluster state of the given object.
+// The boolean return value is false if the object or its API does not exist.
+func (c *Client) GetLiveObject(
+	ctx context.Context, obj *unstructured.Unstructured,
+) (*unstructured.Unstructured, bool, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+
+	err := c.client.Get(ctx, client.ObjectKeyFromObject(obj), live)
+
+	switch {
+	case apimachineryerrors.IsNotFound(err), meta.IsNoMatchError(err):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, fmt.Errorf("getting live object: %w", err)
+	}
+
+	return live, true, nil
+}
+
 func (c *Client) GetPackage(ctx context.Context, name string, opts ...GetPackageOption) (*Package, error) {
 	var cfg GetPackageConfig
 
@@ -194,6 +334,37 @@ func (p *Package) CurrentRevision() int64 {
 	return p.obj.(*corev1alpha1.Package).Status.Revision
 }
 
+func (p *Package) Spec() corev1alpha1.PackageSpec {
+	if cpkg, ok := p.obj.(*corev1alpha1.ClusterPackage); ok {
+		return cpkg.Spec
+	}
+
+	return p.obj.(*corev1alpha1.Package).Spec
+}
+
+// TemplateContext returns the template context the package operator
+// would use to render the contents of this Package.
+func (p *Package) TemplateContext() manifests.TemplateContext {
+	if cpkg, ok := p.obj.(*corev1alpha1.ClusterPackage); ok {
+		return (&adapters.GenericClusterPackage{ClusterPackage: *cpkg}).TemplateContext()
+	}
+
+	return (&adapters.GenericPackage{Package: *p.obj.(*corev1alpha1.Package)}).TemplateContext()
+}
+
+// CurrentObjectSet returns the ObjectSet matching the current revision of the Package.
+// The boolean return value is false if no such ObjectSet exists yet.
+func (p *Package) CurrentObjectSet(ctx context.Context) (ObjectSet, bool, error) {
+	sets, err := p.ObjectSets(ctx)
+	if err != nil {
+		return ObjectSet{}, false, err
+	}
+
+	os, found := sets.FindRevision(p.CurrentRevision())
+
+	return os, found, nil
+}
+
 func (p *Package) ObjectSets(ctx context.Context) (ObjectSetList, error) {
 	opts := []findObjectSetsOption{
 		withSelector{
@@ -210,6 +381,97 @@ func (p *Package) ObjectSets(ctx context.Context) (ObjectSetList, error) {
 	return findObjectSets(ctx, p.client, opts...)
 }
 
+// RollbackTo resets the image and configuration of the Package
+// to the values that were used to create the given revision.
+// Packages loading their configuration from ConfigMaps or Secrets can not be rolled back.
+func (p *Package) RollbackTo(ctx context.Context, revision int64) error {
+	if p.Spec().Track != nil {
+		return fmt.Errorf(
+			"%w: version is tracked from repository, change the tracked version range instead", ErrRollbackNotPossible)
+	}
+	if len(p.Spec().ConfigFrom) > 0 {
+		// Values loaded from ConfigMaps and Secrets are not recorded in revisions,
+		// so the configuration of the revision can not be restored.
+		return fmt.Errorf(
+			"%w: configuration is loaded from ConfigMaps or Secrets, roll back their contents instead",
+			ErrRollbackNotPossible)
+	}
+
+	sets, err := p.ObjectSets(ctx)
+	if err != nil {
+		return err
+	}
+
+	os, found := sets.FindRevision(revision)
+	if !found {
+		return fmt.Errorf("%w: %d", ErrRevisionNotFound, revision)
+	}
+
+	annotations := os.obj.GetAnnotations()
+	image := annotations[manifestsv1alpha1.PackageSourceImageAnnotation]
+	if image == "" {
+		return fmt.Errorf(
+			"%w: revision %d does not record its package source image", ErrRollbackNotPossible, revision)
+	}
+
+	var config *runtime.RawExtension
+	if rawConfig := annotations[manifestsv1alpha1.PackageConfigAnnotation]; rawConfig != "" &&
+		rawConfig != "null" && rawConfig != "{}" {
+		config = &runtime.RawExtension{Raw: []byte(rawConfig)}
+	}
+
+	patch := client.MergeFrom(p.obj.DeepCopyObject().(client.Object))
+
+	if cpkg, ok := p.obj.(*corev1alpha1.ClusterPackage); ok {
+		cpkg.Spec.Image = image
+		cpkg.Spec.Config = config
+	} else {
+		pkg := p.obj.(*corev1alpha1.Package)
+		pkg.Spec.Image = image
+		pkg.Spec.Config = config
+	}
+
+	if err := p.client.Patch(ctx, p.obj, patch); err != nil {
+		return fmt.Errorf("patching package object: %w", err)
+	}
+
+	return nil
+}
+
+// PendingHash returns the hash of the change held back by the upgrade policy of the Package.
+func (p *Package) PendingHash() string {
+	if cpkg, ok := p.obj.(*corev1alpha1.ClusterPackage); ok {
+		return cpkg.Status.PendingHash
+	}
+
+	return p.obj.(*corev1alpha1.Package).Status.PendingHash
+}
+
+// Approve approves the change held back by the upgrade policy of the Package
+// and returns the hash of the approved change.
+func (p *Package) Approve(ctx context.Context) (string, error) {
+	hash := p.PendingHash()
+	if hash == "" {
+		return "", ErrNoPendingUpgrade
+	}
+
+	patch := client.MergeFrom(p.obj.DeepCopyObject().(client.Object))
+
+	annotations := p.obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[corev1alpha1.PackageApprovedHashAnnotation] = hash
+	p.obj.SetAnnotations(annotations)
+
+	if err := p.client.Patch(ctx, p.obj, patch); err != nil {
+		return "", fmt.Errorf("patching package object: %w", err)
+	}
+
+	return hash, nil
+}
+
 type ObjectDeployment struct {
 	client client.Client
 	obj    client.Object
@@ -245,6 +507,39 @@ func (d *ObjectDeployment) ObjectSets(ctx context.Context) (ObjectSetList, error
 	return findObjectSets(ctx, d.client, opts...)
 }
 
+// RollbackTo requests the ObjectDeployment to roll out the template of the given revision again.
+func (d *ObjectDeployment) RollbackTo(ctx context.Context, revision int64) error {
+	if owner := metav1.GetControllerOf(d.obj); owner != nil &&
+		(owner.Kind == "Package" || owner.Kind == "ClusterPackage") {
+		return fmt.Errorf(
+			"%w: managed by %s %q, roll back the package instead", ErrRollbackNotPossible, owner.Kind, owner.Name)
+	}
+
+	sets, err := d.ObjectSets(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, found := sets.FindRevision(revision); !found {
+		return fmt.Errorf("%w: %d", ErrRevisionNotFound, revision)
+	}
+
+	patch := client.MergeFrom(d.obj.DeepCopyObject().(client.Object))
+
+	annotations := d.obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[corev1alpha1.ObjectDeploymentRollbackToRevisionAnnotation] = strconv.FormatInt(revision, 10)
+	d.obj.SetAnnotations(annotations)
+
+	if err := d.client.Patch(ctx, d.obj, patch); err != nil {
+		return fmt.Errorf("patching objectdeployment object: %w", err)
+	}
+
+	return nil
+}
+
 func findObjectSets(ctx context.Context, c client.Client, opts ...findObjectSetsOption) (ObjectSetList, error) {
 	var cfg findObjectSetsConfig
 
@@ -332,6 +627,22 @@ func (s *ObjectSet) Namespace() string {
 	return s.obj.GetNamespace()
 }
 
+func (s *ObjectSet) Phases() []corev1alpha1.ObjectSetTemplatePhase {
+	if cos, ok := s.obj.(*corev1alpha1.ClusterObjectSet); ok {
+		return cos.Spec.Phases
+	}
+
+	return s.obj.(*corev1alpha1.ObjectSet).Spec.Phases
+}
+
+func (s *ObjectSet) AvailabilityProbes() []corev1alpha1.ObjectSetProbe {
+	if cos, ok := s.obj.(*corev1alpha1.ClusterObjectSet); ok {
+		return cos.Spec.AvailabilityProbes
+	}
+
+	return s.obj.(*corev1alpha1.ObjectSet).Spec.AvailabilityProbes
+}
+
 func (s *ObjectSet) HasSucceeded() bool {
 	return meta.IsStatusConditionTrue(s.getConditions(), corev1alpha1.ObjectSetSucceeded)
 }
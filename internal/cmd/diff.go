@@ -0,0 +1,477 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages"
+)
+
+func NewDiff(scheme *runtime.Scheme, opts ...DiffOption) *Diff {
+	var cfg DiffConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Diff{
+		cfg:    cfg,
+		scheme: scheme,
+	}
+}
+
+// Diff renders a package with the configuration of an existing
+// Package object and compares the result to the objects in the cluster.
+type Diff struct {
+	cfg    DiffConfig
+	scheme *runtime.Scheme
+}
+
+type DiffConfig struct {
+	Log  logr.Logger
+	Pull PullFn
+}
+
+func (c *DiffConfig) Option(opts ...DiffOption) {
+	for _, opt := range opts {
+		opt.ConfigureDiff(c)
+	}
+}
+
+func (c *DiffConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+	if c.Pull == nil {
+		c.Pull = packages.FromRegistry
+	}
+}
+
+type DiffOption interface {
+	ConfigureDiff(*DiffConfig)
+}
+
+// DiffPackage renders the package found at srcRef, which is either an image reference or a local source folder,
+// using the configuration of the Package (or ClusterPackage if no namespace is given) with the given name.
+// The rendered objects are compared against the live objects owned by the current revision of the Package.
+func (d *Diff) DiffPackage(
+	ctx context.Context, c *Client, srcRef, name string, opts ...DiffPackageOption,
+) (*DiffResult, error) {
+	var cfg DiffPackageConfig
+
+	cfg.Option(opts...)
+
+	pkg, err := c.GetPackage(ctx, name, WithNamespace(cfg.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	desired, err := d.renderDesiredObjects(ctx, pkg, srcRef, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var current []unstructured.Unstructured
+
+	objectSet, found, err := pkg.CurrentObjectSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		current, err = c.ObjectSetObjects(ctx, objectSet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return d.diffObjects(ctx, c, pkg.Namespace(), current, desired)
+}
+
+func (d *Diff) renderDesiredObjects(
+	ctx context.Context, pkg *Package, srcRef string, cfg DiffPackageConfig,
+) ([]unstructured.Unstructured, error) {
+	rawPkg, err := d.loadRawPackage(ctx, srcRef, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	component := pkg.Spec().Component
+	if cfg.Component != "" {
+		component = cfg.Component
+	}
+
+	loadedPkg, err := packages.DefaultStructuralLoader.LoadComponent(ctx, rawPkg, component)
+	if err != nil {
+		return nil, fmt.Errorf("parsing package contents: %w", err)
+	}
+
+	tmplCtx := pkg.TemplateContext()
+	tmplCtx.Package.Image = srcRef
+
+	tmplCfg, err := d.getConfig(tmplCtx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("getting config: %w", err)
+	}
+
+	validationErrors, err := packages.AdmitPackageConfiguration(
+		ctx, tmplCfg, loadedPkg.Manifest, field.NewPath("spec", "config"))
+	if err != nil {
+		return nil, fmt.Errorf("validate Package configuration: %w", err)
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrors.ToAggregate()
+	}
+
+	images := map[string]string{}
+	if loadedPkg.ManifestLock != nil {
+		for _, image := range loadedPkg.ManifestLock.Spec.Images {
+			resolvedImage, err := packages.ImageWithDigest(image.Image, image.Digest)
+			if err != nil {
+				return nil, err
+			}
+			images[image.Name] = resolvedImage
+		}
+	}
+
+	scope := manifests.PackageManifestScopeNamespaced
+	if pkg.Namespace() == "" {
+		scope = manifests.PackageManifestScopeCluster
+	}
+
+	pkgInstance, err := packages.RenderPackageInstance(ctx, loadedPkg, packages.PackageRenderContext{
+		Package: tmplCtx.Package,
+		Config:  tmplCfg,
+		Images:  images,
+	}, append(
+		packages.DefaultPackageValidators,
+		packages.PackageScopeValidator(scope),
+	), packages.DefaultObjectValidators)
+	if err != nil {
+		return nil, fmt.Errorf("rendering package: %w", err)
+	}
+
+	var objects []unstructured.Unstructured
+	for _, phase := range packages.RenderObjectSetTemplateSpec(pkgInstance).Phases {
+		for _, obj := range phase.Objects {
+			objects = append(objects, obj.Object)
+		}
+	}
+
+	return objects, nil
+}
+
+func (d *Diff) loadRawPackage(
+	ctx context.Context, srcRef string, cfg DiffPackageConfig,
+) (*packages.RawPackage, error) {
+	if _, err := os.Stat(srcRef); err == nil {
+		d.cfg.Log.Info("loading source from disk", "path", srcRef)
+
+		return getPackageFromPath(ctx, srcRef)
+	}
+
+	d.cfg.Log.Info("pulling image", "image", srcRef)
+
+	var craneOpts []crane.Option
+	if cfg.Insecure {
+		craneOpts = append(craneOpts, crane.Insecure)
+	}
+
+	rawPkg, err := d.cfg.Pull(ctx, srcRef, craneOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("importing package from image: %w", err)
+	}
+
+	return rawPkg, nil
+}
+
+func (d *Diff) getConfig(tmplCtx manifests.TemplateContext, cfg DiffPackageConfig) (map[string]any, error) {
+	config := map[string]any{}
+
+	switch {
+	case cfg.ConfigPath != "":
+		data, err := os.ReadFile(cfg.ConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("read config from file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("unmarshal config from file %s: %w", cfg.ConfigPath, err)
+		}
+	case tmplCtx.Config != nil:
+		if err := json.Unmarshal(tmplCtx.Config.Raw, &config); err != nil {
+			return nil, fmt.Errorf("unmarshal config from Package: %w", err)
+		}
+	}
+
+	return config, nil
+}
+
+func (d *Diff) diffObjects(
+	ctx context.Context, c *Client, namespace string,
+	current, desired []unstructured.Unstructured,
+) (*DiffResult, error) {
+	currentByKey := map[string]*unstructured.Unstructured{}
+	for i := range current {
+		defaultNamespace(&current[i], namespace)
+		currentByKey[objectDiffKey(&current[i])] = &current[i]
+	}
+
+	res := &DiffResult{}
+	desiredKeys := map[string]struct{}{}
+
+	for i := range desired {
+		desiredObj := &desired[i]
+		defaultNamespace(desiredObj, namespace)
+
+		key := objectDiffKey(desiredObj)
+		desiredKeys[key] = struct{}{}
+
+		live, found, err := c.GetLiveObject(ctx, desiredObj)
+		if err != nil {
+			return nil, err
+		}
+
+		var before map[string]any
+		if found {
+			refs := []map[string]any{desiredObj.Object}
+			if currentObj, ok := currentByKey[key]; ok {
+				refs = append(refs, currentObj.Object)
+			}
+			before = pruneToFields(live.Object, refs...)
+		}
+
+		objDiff, err := newObjectDiff(key, before, desiredObj.Object)
+		if err != nil {
+			return nil, err
+		}
+		res.Objects = append(res.Objects, objDiff)
+	}
+
+	var removedKeys []string
+	for key := range currentByKey {
+		if _, ok := desiredKeys[key]; !ok {
+			removedKeys = append(removedKeys, key)
+		}
+	}
+	sort.Strings(removedKeys)
+
+	for _, key := range removedKeys {
+		currentObj := currentByKey[key]
+
+		live, found, err := c.GetLiveObject(ctx, currentObj)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		objDiff, err := newObjectDiff(key, pruneToFields(live.Object, currentObj.Object), nil)
+		if err != nil {
+			return nil, err
+		}
+		res.Objects = append(res.Objects, objDiff)
+	}
+
+	return res, nil
+}
+
+func defaultNamespace(obj *unstructured.Unstructured, namespace string) {
+	// Mirrors namespace defaulting of the phase reconciler.
+	if len(obj.GetNamespace()) == 0 {
+		obj.SetNamespace(namespace)
+	}
+}
+
+func objectDiffKey(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s %s", obj.GroupVersionKind().GroupKind(), client.ObjectKeyFromObject(obj))
+}
+
+// pruneToFields returns a copy of obj only containing fields that are present in at least one of refs.
+// Lists and scalar values are kept as a whole.
+// This strips server-side defaults, status and metadata that is not managed by the package.
+func pruneToFields(obj map[string]any, refs ...map[string]any) map[string]any {
+	out := map[string]any{}
+
+	for k, v := range obj {
+		var (
+			present bool
+			subRefs []map[string]any
+		)
+
+		for _, ref := range refs {
+			refV, ok := ref[k]
+			if !ok {
+				continue
+			}
+			present = true
+
+			if refMap, ok := refV.(map[string]any); ok {
+				subRefs = append(subRefs, refMap)
+			}
+		}
+		if !present {
+			continue
+		}
+
+		if vMap, ok := v.(map[string]any); ok && len(subRefs) > 0 {
+			out[k] = pruneToFields(vMap, subRefs...)
+			continue
+		}
+		out[k] = runtime.DeepCopyJSONValue(v)
+	}
+
+	return out
+}
+
+// DiffAction describes how an object will be affected by a package update.
+type DiffAction string
+
+const (
+	DiffActionAdded     DiffAction = "Added"
+	DiffActionChanged   DiffAction = "Changed"
+	DiffActionRemoved   DiffAction = "Removed"
+	DiffActionUnchanged DiffAction = "Unchanged"
+)
+
+// ObjectDiff is the difference between the live and desired state of a single object.
+type ObjectDiff struct {
+	// Kind and key of the object.
+	Key string
+	// Action that will be performed on the object.
+	Action DiffAction
+	// Unified diff between live and desired state.
+	Diff string
+}
+
+func newObjectDiff(key string, live, desired map[string]any) (ObjectDiff, error) {
+	liveYAML, err := marshalDiffObject(live)
+	if err != nil {
+		return ObjectDiff{}, fmt.Errorf("marshalling live object %s: %w", key, err)
+	}
+
+	desiredYAML, err := marshalDiffObject(desired)
+	if err != nil {
+		return ObjectDiff{}, fmt.Errorf("marshalling desired object %s: %w", key, err)
+	}
+
+	objDiff := ObjectDiff{Key: key}
+
+	switch {
+	case live == nil:
+		objDiff.Action = DiffActionAdded
+	case desired == nil:
+		objDiff.Action = DiffActionRemoved
+	case liveYAML == desiredYAML:
+		objDiff.Action = DiffActionUnchanged
+
+		return objDiff, nil
+	default:
+		objDiff.Action = DiffActionChanged
+	}
+
+	objDiff.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYAML),
+		B:        difflib.SplitLines(desiredYAML),
+		FromFile: "live/" + key,
+		ToFile:   "package/" + key,
+		Context:  3,
+	})
+	if err != nil {
+		return ObjectDiff{}, fmt.Errorf("computing diff for %s: %w", key, err)
+	}
+
+	return objDiff, nil
+}
+
+func marshalDiffObject(obj map[string]any) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+
+	data, err := sigsyaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// DiffResult contains the differences of all objects of a package.
+type DiffResult struct {
+	Objects []ObjectDiff
+}
+
+// HasChanges returns true if at least one object is added, changed or removed.
+func (r *DiffResult) HasChanges() bool {
+	for _, obj := range r.Objects {
+		if obj.Action != DiffActionUnchanged {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Count returns the number of objects affected by the given action.
+func (r *DiffResult) Count(action DiffAction) int {
+	var n int
+	for _, obj := range r.Objects {
+		if obj.Action == action {
+			n++
+		}
+	}
+
+	return n
+}
+
+// String renders all object diffs followed by a summary line.
+func (r *DiffResult) String() string {
+	var sb strings.Builder
+
+	for _, obj := range r.Objects {
+		if obj.Action == DiffActionUnchanged {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "%s %s\n", obj.Action, obj.Key)
+		sb.WriteString(obj.Diff)
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "%d added, %d changed, %d removed, %d unchanged\n",
+		r.Count(DiffActionAdded), r.Count(DiffActionChanged),
+		r.Count(DiffActionRemoved), r.Count(DiffActionUnchanged))
+
+	return sb.String()
+}
+
+type DiffPackageConfig struct {
+	Component  string
+	ConfigPath string
+	Insecure   bool
+	Namespace  string
+}
+
+func (c *DiffPackageConfig) Option(opts ...DiffPackageOption) {
+	for _, opt := range opts {
+		opt.ConfigureDiffPackage(c)
+	}
+}
+
+type DiffPackageOption interface {
+	ConfigureDiffPackage(*DiffPackageConfig)
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/internal/constants"
+)
+
+const diffTestPackageSource = "../testutil/testdata/simple-with-config"
+
+func TestDiff_DiffPackage(t *testing.T) {
+	t.Parallel()
+
+	pkgLabels := map[string]string{
+		manifestsv1alpha1.PackageLabel:         "test-webapp-frontend",
+		manifestsv1alpha1.PackageInstanceLabel: "test",
+	}
+	liveLabels := map[string]string{
+		manifestsv1alpha1.PackageLabel:         "test-webapp-frontend",
+		manifestsv1alpha1.PackageInstanceLabel: "test",
+		constants.DynamicCacheLabel:            "True",
+	}
+
+	frontendConfig := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "frontend-config",
+			Namespace: "test-ns",
+			Labels:    liveLabels,
+			Annotations: map[string]string{
+				corev1alpha1.ObjectSetRevisionAnnotation: "1",
+			},
+		},
+		Data: map[string]string{"PUBLIC_API_BASE_URL": "http://old"},
+	}
+	legacyConfig := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "legacy-config",
+			Namespace: "test-ns",
+			Labels:    liveLabels,
+		},
+		Data: map[string]string{"banana": "bread"},
+	}
+
+	objectSet := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-1",
+			Namespace: "test-ns",
+			Labels:    pkgLabels,
+		},
+		Spec: corev1alpha1.ObjectSetSpec{
+			ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+				Phases: []corev1alpha1.ObjectSetTemplatePhase{{
+					Name: "deploy",
+					Objects: []corev1alpha1.ObjectSetObject{
+						{Object: toUnstructured(t, &corev1.ConfigMap{
+							TypeMeta:   frontendConfig.TypeMeta,
+							ObjectMeta: metav1.ObjectMeta{Name: "frontend-config", Labels: pkgLabels},
+							Data:       frontendConfig.Data,
+						})},
+						{Object: toUnstructured(t, &corev1.ConfigMap{
+							TypeMeta:   legacyConfig.TypeMeta,
+							ObjectMeta: metav1.ObjectMeta{Name: "legacy-config", Labels: pkgLabels},
+							Data:       legacyConfig.Data,
+						})},
+					},
+				}},
+			},
+		},
+		Status: corev1alpha1.ObjectSetStatus{Revision: 1},
+	}
+
+	pkg := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: corev1alpha1.PackageSpec{
+			Image:  "quay.io/package-operator/test-webapp-frontend:v1",
+			Config: &runtime.RawExtension{Raw: []byte(`{"apiBaseUrl":"http://example.com"}`)},
+		},
+		Status: corev1alpha1.PackageStatus{Revision: 1},
+	}
+
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	c := NewClient(fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pkg, objectSet, frontendConfig, legacyConfig).
+		Build())
+
+	res, err := NewDiff(scheme).DiffPackage(
+		context.Background(), c, diffTestPackageSource, "test", WithNamespace("test-ns"))
+	require.NoError(t, err)
+
+	assert.True(t, res.HasChanges())
+	assert.Equal(t, 2, res.Count(DiffActionAdded))
+	assert.Equal(t, 1, res.Count(DiffActionChanged))
+	assert.Equal(t, 1, res.Count(DiffActionRemoved))
+
+	out := res.String()
+	assert.Contains(t, out, "Changed ConfigMap test-ns/frontend-config")
+	assert.Contains(t, out, "-  PUBLIC_API_BASE_URL: http://old")
+	assert.Contains(t, out, "+  PUBLIC_API_BASE_URL: http://example.com")
+	assert.Contains(t, out, "Added Deployment.apps test-ns/test-webapp-frontend")
+	assert.Contains(t, out, "Removed ConfigMap test-ns/legacy-config")
+	// system metadata of live objects must not show up.
+	assert.NotContains(t, out, constants.DynamicCacheLabel)
+	assert.NotContains(t, out, corev1alpha1.ObjectSetRevisionAnnotation)
+	assert.Contains(t, out, "2 added, 1 changed, 1 removed, 0 unchanged")
+}
+
+func TestDiff_DiffPackage_PackageNotFound(t *testing.T) {
+	t.Parallel()
+
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+
+	c := NewClient(fake.NewClientBuilder().WithScheme(scheme).Build())
+
+	_, err = NewDiff(scheme).DiffPackage(
+		context.Background(), c, diffTestPackageSource, "dne", WithNamespace("test-ns"))
+	require.Error(t, err)
+}
+
+func TestPruneToFields(t *testing.T) {
+	t.Parallel()
+
+	live := map[string]any{
+		"metadata": map[string]any{
+			"name":            "test",
+			"resourceVersion": "123",
+			"labels": map[string]any{
+				"a": "b",
+				"c": "d",
+			},
+		},
+		"spec": map[string]any{
+			"replicas": int64(1),
+			"defaulted": map[string]any{
+				"x": "y",
+			},
+			"list": []any{"a", "b"},
+		},
+		"status": map[string]any{},
+	}
+	desired := map[string]any{
+		"metadata": map[string]any{
+			"name": "test",
+			"labels": map[string]any{
+				"a": "b",
+			},
+		},
+		"spec": map[string]any{
+			"replicas": int64(2),
+			"list":     []any{"a"},
+		},
+	}
+	current := map[string]any{
+		"spec": map[string]any{
+			"defaulted": map[string]any{},
+		},
+	}
+
+	assert.Equal(t, map[string]any{
+		"metadata": map[string]any{
+			"name": "test",
+			"labels": map[string]any{
+				"a": "b",
+			},
+		},
+		"spec": map[string]any{
+			"replicas":  int64(1),
+			"defaulted": map[string]any{},
+			"list":      []any{"a", "b"},
+		},
+	}, pruneToFields(live, desired, current))
+}
+
+func toUnstructured(t *testing.T, obj client.Object) unstructured.Unstructured {
+	t.Helper()
+
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err)
+
+	return unstructured.Unstructured{Object: data}
+}
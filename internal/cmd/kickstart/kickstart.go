@@ -51,6 +51,7 @@ func (k *Kickstarter) Kickstart(
 	pkgName string,
 	inputs []string,
 	olmBundle string,
+	helmChart string,
 	paramOpts []string,
 ) (string, error) {
 	folderName := pkgName
@@ -92,7 +93,17 @@ func (k *Kickstarter) Kickstart(
 		pkgName = reg.PackageName
 	}
 
-	rawPkg, res, err := packages.Kickstart(ctx, pkgName, objects, paramOpts)
+	var (
+		rawPkg *packages.RawPackage
+		res    packages.KickstartResult
+		err    error
+	)
+	if len(helmChart) > 0 {
+		// Helm charts are converted into a complete package.
+		rawPkg, res, err = packages.KickstartFromHelmChart(ctx, pkgName, helmChart)
+	} else {
+		rawPkg, res, err = packages.Kickstart(ctx, pkgName, objects, paramOpts)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -116,6 +127,9 @@ func (k *Kickstarter) Kickstart(
 	if ok {
 		msg += "\n" + report
 	}
+	if report, ok := reportHelmConversion(res); ok {
+		msg += "\n" + report
+	}
 	return msg, nil
 }
 
@@ -207,3 +221,22 @@ func reportGKsWithoutProbes(gksWithoutProbes []schema.GroupKind) (report string,
 	}
 	return report, ok
 }
+
+func reportHelmConversion(res packages.KickstartResult) (report string, ok bool) {
+	if len(res.UntranslatedTemplates) > 0 {
+		report += "[WARN] Some Helm templates could not be translated " +
+			"and have been placed into the untranslated folder for manual conversion:\n"
+		for _, tmpl := range res.UntranslatedTemplates {
+			report += fmt.Sprintf("- %s\n", tmpl)
+		}
+		ok = true
+	}
+	if len(res.SkippedHooks) > 0 {
+		report += "[WARN] Some Helm hooks have no equivalent and have been skipped:\n"
+		for _, hook := range res.SkippedHooks {
+			report += fmt.Sprintf("- %s\n", hook)
+		}
+		ok = true
+	}
+	return report, ok
+}
@@ -24,7 +24,29 @@ func TestKickstart(t *testing.T) {
 
 	ctx := context.Background()
 	k := NewKickstarter(nil)
-	msg, err := k.Kickstart(ctx, "my-pkg", []string{"testdata/all-the-objects.yaml"}, "", nil)
+	msg, err := k.Kickstart(ctx, "my-pkg", []string{"testdata/all-the-objects.yaml"}, "", "", nil)
 	require.NoError(t, err)
 	assert.Equal(t, kickstartMessage, msg)
 }
+
+var kickstartHelmMessage = `Kickstarted the "my-helm-pkg" package with 1 objects.
+[WARN] Some Helm templates could not be translated and have been placed into the untranslated folder for manual conversion:
+- templates/secret.yaml
+`
+
+func TestKickstart_Helm(t *testing.T) {
+	t.Parallel()
+	defer func() {
+		if err := os.RemoveAll("my-helm-pkg"); err != nil {
+			panic(err)
+		}
+	}()
+
+	ctx := context.Background()
+	k := NewKickstarter(nil)
+	msg, err := k.Kickstart(ctx, "my-helm-pkg", nil, "", "testdata/helm-chart", nil)
+	require.NoError(t, err)
+	assert.Equal(t, kickstartHelmMessage, msg)
+	assert.FileExists(t, "my-helm-pkg/deploy/deployment.deployment.yaml.gotmpl")
+	assert.FileExists(t, "my-helm-pkg/untranslated/templates/secret.yaml.todo")
+}
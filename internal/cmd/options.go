@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"github.com/go-logr/logr"
+
+	"package-operator.run/pkg/probing"
 )
 
 type WithClock struct{ Clock Clock }
@@ -22,6 +24,10 @@ func (w WithConfigPath) ConfigureRenderPackage(c *RenderPackageConfig) {
 	c.ConfigPath = string(w)
 }
 
+func (w WithConfigPath) ConfigureDiffPackage(c *DiffPackageConfig) {
+	c.ConfigPath = string(w)
+}
+
 type WithConfigTestcase string
 
 func (w WithConfigTestcase) ConfigureRenderPackage(c *RenderPackageConfig) {
@@ -34,6 +40,10 @@ func (w WithComponent) ConfigureRenderPackage(c *RenderPackageConfig) {
 	c.Component = string(w)
 }
 
+func (w WithComponent) ConfigureDiffPackage(c *DiffPackageConfig) {
+	c.Component = string(w)
+}
+
 type WithDigestResolver struct{ Resolver DigestResolver }
 
 func (w WithDigestResolver) ConfigureBuild(c *BuildConfig) {
@@ -44,12 +54,32 @@ func (w WithDigestResolver) ConfigureUpdate(c *UpdateConfig) {
 	c.Resolver = w.Resolver
 }
 
+type WithEndpoints struct{ Endpoints probing.Endpoints }
+
+func (w WithEndpoints) ConfigureProbe(c *ProbeConfig) {
+	c.Endpoints = w.Endpoints
+}
+
+type WithExportFormat string
+
+func (w WithExportFormat) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
+	c.ExportFormat = string(w)
+}
+
 type WithLog struct{ Log logr.Logger }
 
 func (w WithLog) ConfigureBuild(c *BuildConfig) {
 	c.Log = w.Log
 }
 
+func (w WithLog) ConfigureDiff(c *DiffConfig) {
+	c.Log = w.Log
+}
+
+func (w WithLog) ConfigureProbe(c *ProbeConfig) {
+	c.Log = w.Log
+}
+
 func (w WithLog) ConfigureTree(c *TreeConfig) {
 	c.Log = w.Log
 }
@@ -74,6 +104,10 @@ func (w WithInsecure) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
 	c.Insecure = bool(w)
 }
 
+func (w WithInsecure) ConfigureDiffPackage(c *DiffPackageConfig) {
+	c.Insecure = bool(w)
+}
+
 func (w WithInsecure) ConfigureGenerateLockData(c *GenerateLockDataConfig) {
 	c.Insecure = bool(w)
 }
@@ -88,6 +122,10 @@ func (w WithInsecure) ConfigureValidatePackage(c *ValidatePackageConfig) {
 
 type WithNamespace string
 
+func (w WithNamespace) ConfigureDiffPackage(c *DiffPackageConfig) {
+	c.Namespace = string(w)
+}
+
 func (w WithNamespace) ConfigureGetPackage(c *GetPackageConfig) {
 	c.Namespace = string(w)
 }
@@ -96,6 +134,14 @@ func (w WithNamespace) ConfigureGetObjectDeployment(c *GetObjectDeploymentConfig
 	c.Namespace = string(w)
 }
 
+func (w WithNamespace) ConfigureGetObjectSet(c *GetObjectSetConfig) {
+	c.Namespace = string(w)
+}
+
+func (w WithNamespace) ConfigureProbeObjectSet(c *ProbeObjectSetConfig) {
+	c.Namespace = string(w)
+}
+
 type WithOutputPath string
 
 func (w WithOutputPath) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
@@ -110,6 +156,10 @@ func (w WithPackageLoader) ConfigureUpdate(c *UpdateConfig) {
 
 type WithPuller struct{ Pull PullFn }
 
+func (w WithPuller) ConfigureDiff(c *DiffConfig) {
+	c.Pull = w.Pull
+}
+
 func (w WithPuller) ConfigureValidate(c *ValidateConfig) {
 	c.Pull = w.Pull
 }
@@ -120,12 +170,36 @@ func (w WithPath) ConfigureValidatePackage(c *ValidatePackageConfig) {
 	c.Path = string(w)
 }
 
+type WithPhase string
+
+func (w WithPhase) ConfigureProbeObjectSet(c *ProbeObjectSetConfig) {
+	c.Phase = string(w)
+}
+
+type WithPlatforms []string
+
+func (w WithPlatforms) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
+	c.Platforms = append(c.Platforms, w...)
+}
+
 type WithPush bool
 
 func (w WithPush) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
 	c.Push = bool(w)
 }
 
+type WithSignKey string
+
+func (w WithSignKey) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
+	c.SignKey = string(w)
+}
+
+type WithSnapshotDir string
+
+func (w WithSnapshotDir) ConfigureProbeObjectSet(c *ProbeObjectSetConfig) {
+	c.SnapshotDir = string(w)
+}
+
 type WithRemoteReference string
 
 func (w WithRemoteReference) ConfigureValidatePackage(c *ValidatePackageConfig) {
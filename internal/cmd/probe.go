@@ -0,0 +1,593 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	internalprobing "package-operator.run/internal/probing"
+	"package-operator.run/pkg/probing"
+)
+
+var serviceGroupKind = schema.GroupKind{Kind: "Service"}
+
+var (
+	ErrObjectSetNotFound = errors.New("objectset not found")
+	ErrPhaseNotFound     = errors.New("phase not found")
+	// ErrCrossNamespaceReference is returned when aggregate probes of a namespaced
+	// ObjectSet reference objects in other namespaces.
+	ErrCrossNamespaceReference = errors.New("cross-namespace references are not allowed")
+)
+
+func NewProbe(opts ...ProbeOption) *Probe {
+	var cfg ProbeConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Probe{
+		cfg: cfg,
+	}
+}
+
+// Probe evaluates the availability probes of an ObjectSet against the current state
+// of its objects, to explain why an ObjectSet does not become available.
+type Probe struct {
+	cfg ProbeConfig
+}
+
+type ProbeConfig struct {
+	Log logr.Logger
+	// Endpoints HTTPGet and TCPSocket probes connect to.
+	// Defaults to connecting directly, which only reaches Services from within the cluster network.
+	// TCPSocket probes of Services are always skipped, as they can't be proxied through the API server.
+	Endpoints probing.Endpoints
+}
+
+func (c *ProbeConfig) Option(opts ...ProbeOption) {
+	for _, opt := range opts {
+		opt.ConfigureProbe(c)
+	}
+}
+
+func (c *ProbeConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+	if c.Endpoints == nil {
+		c.Endpoints = &probing.DirectEndpoints{}
+	}
+}
+
+type ProbeOption interface {
+	ConfigureProbe(*ProbeConfig)
+}
+
+// ProbePackage evaluates the probes of the ObjectSet matching the current revision of the
+// Package (or ClusterPackage if no namespace is given) with the given name.
+func (p *Probe) ProbePackage(
+	ctx context.Context, c *Client, name string, opts ...ProbeObjectSetOption,
+) (*ProbeResult, error) {
+	var cfg ProbeObjectSetConfig
+
+	cfg.Option(opts...)
+
+	pkg, err := c.GetPackage(ctx, name, WithNamespace(cfg.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	objectSet, found, err := pkg.CurrentObjectSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: for revision %d of package %s", ErrObjectSetNotFound, pkg.CurrentRevision(), name)
+	}
+
+	return p.probe(ctx, c, objectSet, cfg)
+}
+
+// ProbeObjectSet evaluates the probes of the ObjectSet
+// (or ClusterObjectSet if no namespace is given) with the given name.
+func (p *Probe) ProbeObjectSet(
+	ctx context.Context, c *Client, name string, opts ...ProbeObjectSetOption,
+) (*ProbeResult, error) {
+	var cfg ProbeObjectSetConfig
+
+	cfg.Option(opts...)
+
+	objectSet, err := c.GetObjectSetByName(ctx, name, WithNamespace(cfg.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	return p.probe(ctx, c, objectSet, cfg)
+}
+
+func (p *Probe) probe(
+	ctx context.Context, c *Client, objectSet ObjectSet, cfg ProbeObjectSetConfig,
+) (*ProbeResult, error) {
+	var source objectSource = &liveObjects{client: c}
+
+	if cfg.SnapshotDir != "" {
+		snapshot, err := loadObjectSnapshot(cfg.SnapshotDir)
+		if err != nil {
+			return nil, err
+		}
+
+		p.cfg.Log.Info("loaded object snapshot", "path", cfg.SnapshotDir, "objects", len(snapshot))
+		source = snapshot
+	}
+
+	res := &ProbeResult{}
+	probedPhases := 0
+
+	for _, phase := range objectSet.Phases() {
+		if cfg.Phase != "" && phase.Name != cfg.Phase {
+			continue
+		}
+		probedPhases++
+
+		objects, err := c.PhaseObjects(ctx, objectSet, phase)
+		if err != nil {
+			return nil, err
+		}
+
+		outcomes, err := p.probePhase(ctx, objectSet, phase.Name, objects, source)
+		if err != nil {
+			return nil, fmt.Errorf("probing phase %s: %w", phase.Name, err)
+		}
+
+		res.Probes = append(res.Probes, outcomes...)
+	}
+
+	if probedPhases == 0 && cfg.Phase != "" {
+		return nil, fmt.Errorf("%w: %s", ErrPhaseNotFound, cfg.Phase)
+	}
+
+	return res, nil
+}
+
+func (p *Probe) probePhase(
+	ctx context.Context, objectSet ObjectSet, phaseName string,
+	objects []unstructured.Unstructured, source objectSource,
+) ([]ProbeOutcome, error) {
+	var (
+		outcomes []ProbeOutcome
+		probed   []*unstructured.Unstructured
+	)
+
+	for i := range objects {
+		obj := &objects[i]
+		defaultNamespace(obj, objectSet.Namespace())
+		key := objectDiffKey(obj)
+
+		current, found, err := source.Get(ctx, obj)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if !found {
+			outcomes = append(outcomes, ProbeOutcome{
+				Phase:   phaseName,
+				Object:  key,
+				Type:    corev1alpha1.ProbeResultTypeNotFound,
+				Message: "object not found",
+			})
+
+			continue
+		}
+		probed = append(probed, current)
+
+		for _, pkgProbe := range objectSet.AvailabilityProbes() {
+			objOutcomes, err := p.probeObject(ctx, pkgProbe, current)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, o := range objOutcomes {
+				o.Phase = phaseName
+				o.Object = key
+				outcomes = append(outcomes, o)
+			}
+		}
+	}
+
+	resolve := source.Resolver(ctx, objectSet.Namespace())
+
+	for _, pkgProbe := range objectSet.AvailabilityProbes() {
+		for _, aggregate := range pkgProbe.Aggregate {
+			probe, err := internalprobing.ParseAggregate(ctx, pkgProbe.Selector, aggregate)
+			if err != nil {
+				return nil, err
+			}
+			ap, ok := probe.(probing.AggregateProber)
+			if !ok {
+				continue
+			}
+
+			success, message := ap.ProbeAggregate(probed, resolve)
+			if success {
+				message = ""
+			}
+			outcomes = append(outcomes, ProbeOutcome{
+				Phase:   phaseName,
+				Type:    string(probing.ProbeTypeAggregate),
+				Success: success,
+				Message: message,
+			})
+		}
+	}
+
+	return outcomes, nil
+}
+
+// Evaluates every probe of the given ObjectSetProbe individually,
+// if the object is matched by its selector.
+func (p *Probe) probeObject(
+	ctx context.Context, pkgProbe corev1alpha1.ObjectSetProbe, obj *unstructured.Unstructured,
+) ([]ProbeOutcome, error) {
+	selection := &selectionProbe{}
+
+	selector, err := internalprobing.ParseSelector(ctx, pkgProbe.Selector, selection)
+	if err != nil {
+		return nil, err
+	}
+	selector.Probe(obj)
+	if !selection.selected {
+		return nil, nil
+	}
+
+	// Like in the package operator, probes only run when .status.observedGeneration is up-to-date.
+	observedGeneration := &probing.ObservedGenerationProbe{Prober: probing.And{}}
+	if success, message := observedGeneration.Probe(obj); !success {
+		return []ProbeOutcome{{
+			Type:    string(probing.ProbeTypeObservedGeneration),
+			Message: message,
+		}}, nil
+	}
+
+	outcomes := make([]ProbeOutcome, 0, len(pkgProbe.Probes))
+
+	for _, spec := range pkgProbe.Probes {
+		probeType := probeSpecType(spec)
+		if probeType == "" {
+			// probe has no known config
+			continue
+		}
+
+		if spec.TCPSocket != nil && obj.GroupVersionKind().GroupKind() == serviceGroupKind {
+			// HTTP requests to Services are proxied through the API server, TCP connections can't be.
+			outcomes = append(outcomes, ProbeOutcome{
+				Type:    string(probeType),
+				Skipped: true,
+				Message: "not reachable from client",
+			})
+
+			continue
+		}
+
+		parsed, err := internalprobing.ParseProbes(
+			ctx, []corev1alpha1.Probe{spec}, internalprobing.WithEndpoints{Endpoints: p.cfg.Endpoints})
+		if err != nil {
+			return nil, err
+		}
+
+		// .status.observedGeneration has already been checked.
+		var probe probing.Prober = parsed
+		if og, ok := parsed.(*probing.ObservedGenerationProbe); ok {
+			probe = og.Prober
+		}
+
+		failures := probing.ProbeDetailed(probe, obj)
+		messages := make([]string, len(failures))
+
+		for i, f := range failures {
+			messages[i] = f.Message
+		}
+
+		outcomes = append(outcomes, ProbeOutcome{
+			Type:    string(probeType),
+			Success: len(failures) == 0,
+			Message: strings.Join(messages, ", "),
+		})
+	}
+
+	return outcomes, nil
+}
+
+func probeSpecType(spec corev1alpha1.Probe) probing.ProbeType {
+	switch {
+	case spec.FieldsEqual != nil:
+		return probing.ProbeTypeFieldsEqual
+	case spec.Condition != nil:
+		return probing.ProbeTypeCondition
+	case spec.CEL != nil:
+		return probing.ProbeTypeCEL
+	case spec.HTTPGet != nil:
+		return probing.ProbeTypeHTTPGet
+	case spec.TCPSocket != nil:
+		return probing.ProbeTypeTCPSocket
+	default:
+		return ""
+	}
+}
+
+// selectionProbe records whether a selector passed an object on to it.
+type selectionProbe struct {
+	selected bool
+}
+
+func (p *selectionProbe) Probe(*unstructured.Unstructured) (bool, string) {
+	p.selected = true
+
+	return true, ""
+}
+
+// ProbeResult contains the outcome of every probe evaluated for the objects of an ObjectSet.
+type ProbeResult struct {
+	Probes []ProbeOutcome
+}
+
+// ProbeOutcome is the outcome of a single probe for a single object.
+type ProbeOutcome struct {
+	// Phase the probed object belongs to.
+	Phase string
+	// Object the probe was evaluated for, empty for aggregate probes.
+	Object string
+	// Type of the probe.
+	Type string
+	// Success is true if the probe passed.
+	Success bool
+	// Skipped is true if the probe could not be evaluated from the client.
+	Skipped bool
+	// Message of the failing or skipped probe.
+	Message string
+}
+
+// Count returns the number of passed and failed probes.
+// Skipped probes are neither.
+func (r *ProbeResult) Count() (passed, failed int) {
+	for _, o := range r.Probes {
+		switch {
+		case o.Skipped:
+		case o.Success:
+			passed++
+		default:
+			failed++
+		}
+	}
+
+	return passed, failed
+}
+
+func (r *ProbeResult) RenderTable(headers ...string) Table {
+	table := NewDefaultTable(
+		WithHeaders(headers),
+	)
+
+	for _, o := range r.Probes {
+		object, result := o.Object, "PASS"
+		if object == "" {
+			object = "-"
+		}
+		switch {
+		case o.Skipped:
+			result = "SKIP"
+		case !o.Success:
+			result = "FAIL"
+		}
+
+		table.AddRow(
+			Field{
+				Name:  "Phase",
+				Value: o.Phase,
+			},
+			Field{
+				Name:  "Object",
+				Value: object,
+			},
+			Field{
+				Name:  "Probe",
+				Value: o.Type,
+			},
+			Field{
+				Name:  "Result",
+				Value: result,
+			},
+			Field{
+				Name:  "Message",
+				Value: o.Message,
+			},
+		)
+	}
+
+	return table
+}
+
+type ProbeObjectSetConfig struct {
+	Namespace   string
+	Phase       string
+	SnapshotDir string
+}
+
+func (c *ProbeObjectSetConfig) Option(opts ...ProbeObjectSetOption) {
+	for _, opt := range opts {
+		opt.ConfigureProbeObjectSet(c)
+	}
+}
+
+type ProbeObjectSetOption interface {
+	ConfigureProbeObjectSet(*ProbeObjectSetConfig)
+}
+
+// objectSource looks up the current state of objects.
+type objectSource interface {
+	// Get returns the current state of the given object.
+	// The boolean return value is false if the object does not exist.
+	Get(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, bool, error)
+	// Resolver returns a ReferenceResolver for aggregate probes of an ObjectSet in the given namespace.
+	Resolver(ctx context.Context, namespace string) probing.ReferenceResolver
+}
+
+// liveObjects looks up objects in the cluster.
+type liveObjects struct {
+	client *Client
+}
+
+func (l *liveObjects) Get(
+	ctx context.Context, obj *unstructured.Unstructured,
+) (*unstructured.Unstructured, bool, error) {
+	return l.client.GetLiveObject(ctx, obj)
+}
+
+func (l *liveObjects) Resolver(ctx context.Context, namespace string) probing.ReferenceResolver {
+	return referenceResolver(ctx, l, namespace)
+}
+
+// objectSnapshot looks up objects from YAML files, e.g. collected via `kubectl get -o yaml`.
+type objectSnapshot map[string]*unstructured.Unstructured
+
+func (s objectSnapshot) Get(
+	_ context.Context, obj *unstructured.Unstructured,
+) (*unstructured.Unstructured, bool, error) {
+	if snap, ok := s[objectDiffKey(obj)]; ok {
+		return snap, true, nil
+	}
+
+	// Cluster-scoped objects may have been defaulted to the namespace of the ObjectSet.
+	clusterScoped := obj.DeepCopy()
+	clusterScoped.SetNamespace("")
+	snap, ok := s[objectDiffKey(clusterScoped)]
+
+	return snap, ok, nil
+}
+
+func (s objectSnapshot) Resolver(ctx context.Context, namespace string) probing.ReferenceResolver {
+	return referenceResolver(ctx, s, namespace)
+}
+
+// Returns a ReferenceResolver mirroring the one of the phase reconciler.
+// References of namespaced ObjectSets default to, and are restricted to, their namespace.
+func referenceResolver(ctx context.Context, source objectSource, namespace string) probing.ReferenceResolver {
+	return func(ref probing.ObjectReference) (*unstructured.Unstructured, error) {
+		refNamespace := ref.Namespace
+		if len(namespace) > 0 {
+			if len(refNamespace) > 0 && refNamespace != namespace {
+				return nil, fmt.Errorf("%w: %s", ErrCrossNamespaceReference, refNamespace)
+			}
+			refNamespace = namespace
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(ref.GroupVersionKind)
+		obj.SetNamespace(refNamespace)
+		obj.SetName(ref.Name)
+
+		current, found, err := source.Get(ctx, obj)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, apimachineryerrors.NewNotFound(schema.GroupResource{
+				Group:    ref.GroupVersionKind.Group,
+				Resource: ref.GroupVersionKind.Kind,
+			}, ref.Name)
+		}
+
+		return current, nil
+	}
+}
+
+// Loads all objects from .yaml, .yml and .json files within the given directory.
+// Lists, as returned by `kubectl get -o yaml`, are flattened into their items.
+func loadObjectSnapshot(dir string) (objectSnapshot, error) {
+	snapshot := objectSnapshot{}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		objs, err := readSnapshotFile(path)
+		if err != nil {
+			return fmt.Errorf("reading snapshot file %s: %w", path, err)
+		}
+
+		for _, obj := range objs {
+			snapshot[objectDiffKey(obj)] = obj
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading object snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+func readSnapshotFile(path string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var objs []*unstructured.Unstructured
+
+	decoder := yaml.NewYAMLOrJSONDecoder(f, 4096)
+
+	for {
+		obj := &unstructured.Unstructured{}
+
+		err := decoder.Decode(&obj.Object)
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if !obj.IsList() {
+			objs = append(objs, obj)
+
+			continue
+		}
+
+		if err := obj.EachListItem(func(item runtime.Object) error {
+			if u, ok := item.(*unstructured.Unstructured); ok {
+				objs = append(objs, u)
+			}
+
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestProbe_ProbeObjectSet(t *testing.T) {
+	t.Parallel()
+
+	configMap := func(name, ready string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Data:       map[string]string{"ready": ready},
+		}
+	}
+
+	objectSet := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-1",
+			Namespace: "test-ns",
+		},
+		Spec: corev1alpha1.ObjectSetSpec{
+			ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+				Phases: []corev1alpha1.ObjectSetTemplatePhase{
+					{
+						Name: "deploy",
+						Objects: []corev1alpha1.ObjectSetObject{
+							{Object: toUnstructured(t, configMap("ready", ""))},
+							{Object: toUnstructured(t, configMap("pending", ""))},
+							{Object: toUnstructured(t, configMap("missing", ""))},
+						},
+					},
+					{
+						Name: "other",
+					},
+				},
+				AvailabilityProbes: []corev1alpha1.ObjectSetProbe{{
+					Selector: corev1alpha1.ProbeSelector{
+						Kind: &corev1alpha1.PackageProbeKindSpec{Kind: "ConfigMap"},
+					},
+					Probes: []corev1alpha1.Probe{{
+						CEL: &corev1alpha1.ProbeCELSpec{
+							Rule:    `self.data.ready == "true"`,
+							Message: "not ready",
+						},
+					}},
+					Aggregate: []corev1alpha1.ProbeAggregateSpec{{
+						Rule:    `objects.size() >= 3`,
+						Message: "not enough ConfigMaps",
+					}},
+				}},
+			},
+		},
+	}
+
+	expected := &ProbeResult{
+		Probes: []ProbeOutcome{
+			{Phase: "deploy", Object: "ConfigMap test-ns/ready", Type: "CEL", Success: true},
+			{Phase: "deploy", Object: "ConfigMap test-ns/pending", Type: "CEL", Message: "not ready"},
+			{Phase: "deploy", Object: "ConfigMap test-ns/missing", Type: "NotFound", Message: "object not found"},
+			{Phase: "deploy", Type: "Aggregate", Message: "not enough ConfigMaps"},
+		},
+	}
+
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	t.Run("live", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objectSet.DeepCopy(), configMap("ready", "true"), configMap("pending", "false")).
+			Build())
+
+		res, err := NewProbe().ProbeObjectSet(
+			context.Background(), c, "test-1", WithNamespace("test-ns"), WithPhase("deploy"))
+		require.NoError(t, err)
+		assert.Equal(t, expected, res)
+
+		passed, failed := res.Count()
+		assert.Equal(t, 1, passed)
+		assert.Equal(t, 3, failed)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "configmaps.yaml"), []byte(`apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: ready
+    namespace: test-ns
+  data:
+    ready: "true"
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: pending
+    namespace: test-ns
+  data:
+    ready: "false"
+`), 0o600))
+
+		// Live ConfigMaps must not be used.
+		c := NewClient(fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objectSet.DeepCopy(), configMap("missing", "true")).
+			Build())
+
+		res, err := NewProbe().ProbeObjectSet(
+			context.Background(), c, "test-1", WithNamespace("test-ns"), WithSnapshotDir(dir))
+		require.NoError(t, err)
+		// Aggregate probes also run for the empty phase,
+		// but pass as it contains no selected objects.
+		assert.Equal(t, append(expected.Probes[:len(expected.Probes):len(expected.Probes)], ProbeOutcome{
+			Phase: "other", Type: "Aggregate", Success: true,
+		}), res.Probes)
+	})
+
+	t.Run("phase not found", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objectSet.DeepCopy()).
+			Build())
+
+		_, err := NewProbe().ProbeObjectSet(
+			context.Background(), c, "test-1", WithNamespace("test-ns"), WithPhase("banana"))
+		require.ErrorIs(t, err, ErrPhaseNotFound)
+	})
+}
+
+func TestProbe_ProbeObjectSet_outdatedAndSkipped(t *testing.T) {
+	t.Parallel()
+
+	outdated := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "outdated", Namespace: "test-ns"},
+	}
+	service := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test-ns"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "postgres", Port: 5432}},
+		},
+	}
+
+	objectSet := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-1", Namespace: "test-ns"},
+		Spec: corev1alpha1.ObjectSetSpec{
+			ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+				Phases: []corev1alpha1.ObjectSetTemplatePhase{{
+					Name: "deploy",
+					Objects: []corev1alpha1.ObjectSetObject{
+						{Object: toUnstructured(t, outdated)},
+						{Object: toUnstructured(t, service)},
+					},
+				}},
+				AvailabilityProbes: []corev1alpha1.ObjectSetProbe{
+					{
+						Selector: corev1alpha1.ProbeSelector{
+							Kind: &corev1alpha1.PackageProbeKindSpec{Kind: "ConfigMap"},
+						},
+						Probes: []corev1alpha1.Probe{
+							{CEL: &corev1alpha1.ProbeCELSpec{Rule: `true`, Message: "a"}},
+							{CEL: &corev1alpha1.ProbeCELSpec{Rule: `true`, Message: "b"}},
+						},
+					},
+					{
+						Selector: corev1alpha1.ProbeSelector{
+							Kind: &corev1alpha1.PackageProbeKindSpec{Kind: "Service"},
+						},
+						Probes: []corev1alpha1.Probe{
+							{TCPSocket: &corev1alpha1.ProbeTCPSocketSpec{}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "objects.yaml"), []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: outdated
+  namespace: test-ns
+  generation: 2
+status:
+  observedGeneration: 1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: db
+  namespace: test-ns
+spec:
+  ports:
+  - name: postgres
+    port: 5432
+`), 0o600))
+
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	c := NewClient(fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objectSet).
+		Build())
+
+	res, err := NewProbe().ProbeObjectSet(
+		context.Background(), c, "test-1", WithNamespace("test-ns"), WithSnapshotDir(dir))
+	require.NoError(t, err)
+	assert.Equal(t, []ProbeOutcome{
+		// Outdated objects are reported once, instead of for every probe.
+		{
+			Phase: "deploy", Object: "ConfigMap test-ns/outdated",
+			Type: "ObservedGeneration", Message: ".status outdated",
+		},
+		{
+			Phase: "deploy", Object: "Service test-ns/db",
+			Type: "TCPSocket", Skipped: true, Message: "not reachable from client",
+		},
+	}, res.Probes)
+
+	passed, failed := res.Count()
+	assert.Equal(t, 0, passed)
+	assert.Equal(t, 1, failed)
+}
+
+func TestProbe_ProbePackage_ObjectSetNotFound(t *testing.T) {
+	t.Parallel()
+
+	pkg := &corev1alpha1.ClusterPackage{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Status:     corev1alpha1.PackageStatus{Revision: 2},
+	}
+
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+
+	c := NewClient(fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pkg).
+		Build())
+
+	_, err = NewProbe().ProbePackage(context.Background(), c, "test")
+	require.ErrorIs(t, err, ErrObjectSetNotFound)
+}
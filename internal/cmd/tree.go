@@ -65,9 +65,9 @@ func (t *Tree) RenderPackage(ctx context.Context, srcPath string, opts ...Render
 
 	t.cfg.Log.Info("loading source from disk", "path", srcPath)
 
-	rawPkg, err := packages.FromFolder(ctx, srcPath)
+	rawPkg, err := getPackageFromPath(ctx, srcPath)
 	if err != nil {
-		return "", fmt.Errorf("loading package contents from folder: %w", err)
+		return "", fmt.Errorf("loading package contents: %w", err)
 	}
 
 	// TODO: show all components in the tree
@@ -9,6 +9,7 @@ import (
 	"reflect"
 
 	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/crane"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/yaml"
@@ -104,6 +105,9 @@ func (u Update) GenerateLockData(ctx context.Context, srcPath string, opts ...Ge
 	}
 
 	r := packages.BuildResolver{}
+	if cfg.Insecure {
+		r.CraneOptions = append(r.CraneOptions, crane.Insecure)
+	}
 	lckPtr, err := r.AddManifest(ctx, pkg.Manifest)
 	if err != nil {
 		return nil, err
@@ -77,7 +77,7 @@ func (v *Validate) ValidatePackage(ctx context.Context, opts ...ValidatePackageO
 			return fmt.Errorf("getting package from path: %w", err)
 		}
 
-		validators = append(validators, packages.NewTemplateTestValidator(cfg.Path))
+		validators = append(validators, templateTestValidators(cfg.Path)...)
 	} else {
 		var err error
 
@@ -99,14 +99,24 @@ func (v *Validate) ValidatePackage(ctx context.Context, opts ...ValidatePackageO
 	return nil
 }
 
+// Loads a package from a source folder, an OCI image layout directory or an image tar file.
 func getPackageFromPath(ctx context.Context, path string) (*packages.RawPackage, error) {
-	rawPkg, err := packages.FromFolder(ctx, path)
+	rawPkg, err := packages.FromPath(ctx, path)
 	if err != nil {
-		return nil, fmt.Errorf("importing package from folder: %w", err)
+		return nil, fmt.Errorf("importing package from path: %w", err)
 	}
 	return rawPkg, nil
 }
 
+// Template tests can only run against source folders,
+// because test fixtures are not part of package images.
+func templateTestValidators(path string) packages.PackageValidatorList {
+	if pathType, err := packages.DetectPathType(path); err != nil || pathType != packages.PathTypeFolder {
+		return nil
+	}
+	return packages.PackageValidatorList{packages.NewTemplateTestValidator(path)}
+}
+
 func (v *Validate) getPackageFromRemoteRef(
 	ctx context.Context, cfg ValidatePackageConfig,
 ) (*packages.RawPackage, error) {
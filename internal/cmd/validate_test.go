@@ -3,9 +3,12 @@ package cmd
 import (
 	"context"
 	_ "embed"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -139,3 +142,37 @@ func (m *pullerMock) Pull(ctx context.Context, ref string, opts ...crane.Option)
 	rawPkg, _ := args.Get(0).(*packages.RawPackage)
 	return rawPkg, args.Error(1)
 }
+
+func TestValidate_ValidatePackage_exported(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rawPkg, err := packages.FromFolder(ctx, "testdata")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	tarPath := filepath.Join(dir, "pkg.tar")
+	require.NoError(t, packages.ToOCIFile(tarPath, []string{"quay.io/pkg:v1"}, rawPkg))
+
+	image, err := packages.ToOCI(rawPkg)
+	require.NoError(t, err)
+	layoutPath := filepath.Join(dir, "layout")
+	p, err := layout.Write(layoutPath, empty.Index)
+	require.NoError(t, err)
+	require.NoError(t, p.AppendImage(image))
+
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+	validate := NewValidate(scheme)
+
+	for name, path := range map[string]string{
+		"image tar":  tarPath,
+		"OCI layout": layoutPath,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.NoError(t, validate.ValidatePackage(ctx, WithPath(path)))
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Drift condition reasons.
+const (
+	driftRevertedReason = "DriftReverted"
+	driftObservedReason = "DriftObserved"
+)
+
+// ObjectDrift describes how an object on the cluster differs from its desired state.
+type ObjectDrift struct {
+	// Paths of fields that differ from the desired state.
+	Paths []string
+	// Field managers that changed the drifted fields.
+	Managers []string
+}
+
+func (d ObjectDrift) IsZero() bool {
+	return len(d.Paths) == 0
+}
+
+func (d ObjectDrift) String() string {
+	msg := strings.Join(d.Paths, ", ")
+	if len(d.Managers) > 0 {
+		msg += fmt.Sprintf(" (changed by %s)", strings.Join(d.Managers, ", "))
+	}
+	return msg
+}
+
+// Detects fields of the desired object that have been changed on the cluster.
+//
+// Only fields specified in the desired object are compared,
+// so values defaulted by the API server or other controllers are not reported.
+// A field with a different value is only reported if it went missing
+// or if it is managed by a field manager other than Package Operator,
+// so values normalized by the API server (e.g. resource quantities) are not reported either.
+func DetectDrift(desiredObj, currentObj *unstructured.Unstructured) ObjectDrift {
+	d := &driftDetector{managers: sets.New[string]()}
+
+	var owners []fieldOwner
+	for _, entry := range currentObj.GetManagedFields() {
+		if oldFieldOwners.Has(entry.Manager) || entry.Subresource == "status" || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := map[string]any{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		owners = append(owners, fieldOwner{manager: entry.Manager, fields: fields})
+	}
+
+	for k, desired := range desiredObj.Object {
+		switch k {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			// Only labels and annotations are under our control.
+			desiredMeta, _ := desired.(map[string]any)
+			currentMeta, _ := currentObj.Object["metadata"].(map[string]any)
+			for _, mk := range []string{"labels", "annotations"} {
+				desiredValue, ok := desiredMeta[mk]
+				if !ok {
+					continue
+				}
+				currentValue, ok := currentMeta[mk]
+				if !ok {
+					if !isEmptyJSONValue(desiredValue) {
+						d.report([]string{"metadata", mk}, nil)
+					}
+					continue
+				}
+				d.compare(
+					[]string{"metadata", mk}, desiredValue, currentValue,
+					descendFieldOwners(descendFieldOwners(owners, "f:metadata"), "f:"+mk))
+			}
+			continue
+		}
+
+		current, ok := currentObj.Object[k]
+		if !ok {
+			if !isEmptyJSONValue(desired) {
+				d.report([]string{k}, nil)
+			}
+			continue
+		}
+		d.compare([]string{k}, desired, current, descendFieldOwners(owners, "f:"+k))
+	}
+
+	sort.Strings(d.paths)
+	return ObjectDrift{Paths: d.paths, Managers: sets.List(d.managers)}
+}
+
+type driftDetector struct {
+	paths    []string
+	managers sets.Set[string]
+}
+
+func (d *driftDetector) compare(path []string, desired, current any, owners []fieldOwner) {
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		currentValue, ok := current.(map[string]any)
+		if !ok {
+			d.reportIfManagedByOthers(path, owners)
+			return
+		}
+		for k, v := range desiredValue {
+			cv, ok := currentValue[k]
+			if !ok {
+				// The API server drops empty values.
+				if !isEmptyJSONValue(v) {
+					d.report(childFieldPath(path, k), nil)
+				}
+				continue
+			}
+			d.compare(childFieldPath(path, k), v, cv, descendFieldOwners(owners, "f:"+k))
+		}
+
+	case []any:
+		currentValue, ok := current.([]any)
+		if !ok || len(currentValue) != len(desiredValue) {
+			// Lists growing or shrinking is never caused by normalization.
+			d.report(path, ownerNames(owners))
+			return
+		}
+		for i := range desiredValue {
+			d.compare(
+				childFieldPath(path, "["+strconv.Itoa(i)+"]"), desiredValue[i], currentValue[i],
+				descendFieldOwnersListItem(owners, i, currentValue[i]))
+		}
+
+	default:
+		if !jsonValueEqual(desired, current) {
+			d.reportIfManagedByOthers(path, owners)
+		}
+	}
+}
+
+func (d *driftDetector) reportIfManagedByOthers(path []string, owners []fieldOwner) {
+	if len(owners) > 0 {
+		d.report(path, ownerNames(owners))
+	}
+}
+
+func (d *driftDetector) report(path []string, managers []string) {
+	d.paths = append(d.paths, formatFieldPath(path))
+	d.managers.Insert(managers...)
+}
+
+// fieldOwner tracks the position of a field manager within its managed fields tree.
+type fieldOwner struct {
+	manager string
+	// fields is nil, if the manager owns a parent field atomically.
+	fields map[string]any
+}
+
+func ownerNames(owners []fieldOwner) []string {
+	names := make([]string, len(owners))
+	for i, o := range owners {
+		names[i] = o.manager
+	}
+	return names
+}
+
+// Returns all owners that manage the given child field.
+func descendFieldOwners(owners []fieldOwner, key string) []fieldOwner {
+	var out []fieldOwner
+	for _, o := range owners {
+		if o.fields == nil || isAtomicFieldSet(o.fields) {
+			out = append(out, fieldOwner{manager: o.manager})
+			continue
+		}
+		if child, ok := o.fields[key].(map[string]any); ok {
+			out = append(out, fieldOwner{manager: o.manager, fields: child})
+		}
+	}
+	return out
+}
+
+// Returns all owners that manage the given list item.
+// List items are identified by index, by their key fields or by value.
+func descendFieldOwnersListItem(owners []fieldOwner, index int, item any) []fieldOwner {
+	var out []fieldOwner
+	for _, o := range owners {
+		if o.fields == nil || isAtomicFieldSet(o.fields) {
+			out = append(out, fieldOwner{manager: o.manager})
+			continue
+		}
+		for key, child := range o.fields {
+			childFields, _ := child.(map[string]any)
+			if listItemMatches(key, index, item) {
+				out = append(out, fieldOwner{manager: o.manager, fields: childFields})
+				break
+			}
+		}
+	}
+	return out
+}
+
+func listItemMatches(key string, index int, item any) bool {
+	prefix, value, ok := strings.Cut(key, ":")
+	if !ok {
+		return false
+	}
+	switch prefix {
+	case "i":
+		return value == strconv.Itoa(index)
+	case "v":
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return false
+		}
+		return jsonValueEqual(v, item)
+	case "k":
+		keyFields := map[string]any{}
+		if err := json.Unmarshal([]byte(value), &keyFields); err != nil {
+			return false
+		}
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range keyFields {
+			if !jsonValueEqual(v, itemMap[k]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// A fields set without children is owned as a whole.
+func isAtomicFieldSet(fields map[string]any) bool {
+	for k := range fields {
+		if k != "." {
+			return false
+		}
+	}
+	return true
+}
+
+func isEmptyJSONValue(v any) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(value) == 0
+	case []any:
+		return len(value) == 0
+	}
+	return false
+}
+
+// Compares two JSON values, ignoring differences in the numeric type.
+func jsonValueEqual(a, b any) bool {
+	if af, ok := jsonNumber(a); ok {
+		bf, ok := jsonNumber(b)
+		return ok && af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func jsonNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func childFieldPath(path []string, child string) []string {
+	out := make([]string, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, child)
+}
+
+func formatFieldPath(path []string) string {
+	var b strings.Builder
+	for _, p := range path {
+		switch {
+		case strings.HasPrefix(p, "["):
+			b.WriteString(p)
+		case strings.ContainsAny(p, "./"):
+			b.WriteString(`["` + p + `"]`)
+		default:
+			b.WriteString("." + p)
+		}
+	}
+	return b.String()
+}
+
+// Reports drift of the given object on its owner.
+func (r *PhaseReconciler) recordDrift(
+	owner PhaseObjectOwner, obj *unstructured.Unstructured,
+	drift ObjectDrift, reconcileMode corev1alpha1.ObjectReconcileMode,
+) {
+	reason := driftRevertedReason
+	if reconcileMode == corev1alpha1.ObjectReconcileModeObserveOnly {
+		reason = driftObservedReason
+	}
+
+	msg := fmt.Sprintf("%s %s: %s", obj.GroupVersionKind().GroupKind(),
+		strings.TrimPrefix(obj.GetNamespace()+"/"+obj.GetName(), "/"), drift)
+
+	if r.eventRecorder != nil {
+		r.eventRecorder.Event(owner.ClientObject(), corev1.EventTypeWarning, reason, msg)
+	}
+
+	// Multiple objects may have drifted within the same reconciliation.
+	if cond := meta.FindStatusCondition(*owner.GetConditions(), corev1alpha1.ObjectSetDrifted); cond != nil &&
+		cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == owner.ClientObject().GetGeneration() {
+		msg = cond.Message + "; " + msg
+	}
+	meta.SetStatusCondition(owner.GetConditions(), metav1.Condition{
+		Type:               corev1alpha1.ObjectSetDrifted,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: owner.ClientObject().GetGeneration(),
+	})
+}
+
+// Removes the Drifted condition before reconciling all phases,
+// so it only reports drift detected during the current reconciliation.
+func DeleteDriftedCondition(conditions *[]metav1.Condition) {
+	meta.RemoveStatusCondition(conditions, corev1alpha1.ObjectSetDrifted)
+}
@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/constants"
+)
+
+func TestDetectDrift(t *testing.T) {
+	t.Parallel()
+
+	desired := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":   "test",
+				"labels": map[string]any{"app": "test"},
+			},
+			"spec": map[string]any{
+				"replicas": int64(1),
+				"template": map[string]any{
+					"metadata": map[string]any{"creationTimestamp": nil},
+					"spec": map[string]any{
+						"containers": []any{
+							map[string]any{
+								"name":  "app",
+								"image": "quay.io/package-operator/test:v1",
+								"resources": map[string]any{
+									"limits": map[string]any{"memory": "1024Mi"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}}
+	}
+
+	for name, tc := range map[string]struct {
+		mutate           func(obj *unstructured.Unstructured)
+		managedFields    []metav1.ManagedFieldsEntry
+		expectedPaths    []string
+		expectedManagers []string
+	}{
+		"no drift": {},
+		"defaulted values": {
+			mutate: func(obj *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(obj.Object, "Always",
+					"spec", "template", "spec", "restartPolicy")
+				obj.SetAnnotations(map[string]string{"deployment.kubernetes.io/revision": "1"})
+				unstructured.RemoveNestedField(obj.Object, "spec", "template", "metadata", "creationTimestamp")
+			},
+		},
+		"normalized value": {
+			mutate: func(obj *unstructured.Unstructured) {
+				containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+				_ = unstructured.SetNestedField(containers[0].(map[string]any), "1Gi", "resources", "limits", "memory")
+				_ = unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+			},
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFieldsEntry(constants.FieldOwner, "", `{"f:spec":{"f:template":{"f:spec":{"f:containers":{}}}}}`),
+			},
+		},
+		"changed by other manager": {
+			mutate: func(obj *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(obj.Object, int64(3), "spec", "replicas")
+			},
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFieldsEntry("kubectl-edit", "", `{"f:spec":{"f:replicas":{}}}`),
+			},
+			expectedPaths:    []string{".spec.replicas"},
+			expectedManagers: []string{"kubectl-edit"},
+		},
+		"scaled via subresource": {
+			mutate: func(obj *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(obj.Object, int64(3), "spec", "replicas")
+			},
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFieldsEntry("kube-controller-manager", "scale", `{"f:spec":{"f:replicas":{}}}`),
+			},
+			expectedPaths:    []string{".spec.replicas"},
+			expectedManagers: []string{"kube-controller-manager"},
+		},
+		"missing label": {
+			mutate: func(obj *unstructured.Unstructured) {
+				obj.SetLabels(nil)
+			},
+			expectedPaths: []string{".metadata.labels"},
+		},
+		"list item by key": {
+			mutate: func(obj *unstructured.Unstructured) {
+				containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+				containers[0].(map[string]any)["image"] = "quay.io/package-operator/test:v2"
+				_ = unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+			},
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFieldsEntry("kubectl-set", "",
+					`{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{"f:image":{}}}}}}}`),
+			},
+			expectedPaths:    []string{".spec.template.spec.containers[0].image"},
+			expectedManagers: []string{"kubectl-set"},
+		},
+		"list length": {
+			mutate: func(obj *unstructured.Unstructured) {
+				_ = unstructured.SetNestedSlice(obj.Object, []any{}, "spec", "template", "spec", "containers")
+			},
+			expectedPaths: []string{".spec.template.spec.containers"},
+		},
+		"status is ignored": {
+			mutate: func(obj *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(obj.Object, int64(1), "status", "replicas")
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			current := desired()
+			if tc.mutate != nil {
+				tc.mutate(current)
+			}
+			current.SetManagedFields(tc.managedFields)
+
+			drift := DetectDrift(desired(), current)
+			assert.Equal(t, tc.expectedPaths, drift.Paths)
+			assert.Equal(t, len(tc.expectedPaths) == 0, drift.IsZero())
+			if len(tc.expectedManagers) > 0 {
+				assert.Equal(t, tc.expectedManagers, drift.Managers)
+			} else {
+				assert.Empty(t, drift.Managers)
+			}
+		})
+	}
+}
+
+func managedFieldsEntry(manager, subresource, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:     manager,
+		Operation:   metav1.ManagedFieldsOperationUpdate,
+		Subresource: subresource,
+		FieldsType:  "FieldsV1",
+		FieldsV1:    &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func TestPhaseReconciler_reconcileObject_drift(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		reconcileMode  corev1alpha1.ObjectReconcileMode
+		expectedReason string
+		expectPatch    bool
+	}{
+		"revert": {
+			expectedReason: driftRevertedReason,
+			expectPatch:    true,
+		},
+		"observe only": {
+			reconcileMode:  corev1alpha1.ObjectReconcileModeObserveOnly,
+			expectedReason: driftObservedReason,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dynamicCacheMock := &dynamicCacheMock{}
+			acMock := &adoptionCheckerMock{}
+			ownerStrategy := &ownerStrategyMock{}
+			patcher := &patcherMock{}
+			recorder := record.NewFakeRecorder(10)
+			r := &PhaseReconciler{
+				dynamicCache:    dynamicCacheMock,
+				adoptionChecker: acMock,
+				ownerStrategy:   ownerStrategy,
+				patcher:         patcher,
+				eventRecorder:   recorder,
+			}
+
+			ownerObj := &unstructured.Unstructured{}
+			ownerObj.SetGeneration(2)
+			var conditions []metav1.Condition
+			owner := &phaseObjectOwnerMock{}
+			owner.On("ClientObject").Return(ownerObj)
+			owner.On("GetConditions").Return(&conditions)
+
+			acMock.
+				On("Check", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(false, nil)
+			dynamicCacheMock.
+				On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					obj := args.Get(2).(*unstructured.Unstructured)
+					_ = unstructured.SetNestedField(obj.Object, "banana", "data", "key")
+					obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+						managedFieldsEntry("kubectl-edit", "", `{"f:data":{"f:key":{}}}`),
+					})
+				}).
+				Return(nil)
+			ownerStrategy.
+				On("IsController", mock.Anything, mock.Anything).
+				Return(true)
+			patcher.
+				On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			desired := &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]any{"name": "test", "namespace": "test"},
+				"data":       map[string]any{"key": "value"},
+			}}
+			_, err := r.reconcileObject(
+				context.Background(), owner, desired, nil,
+				corev1alpha1.CollisionProtectionPrevent, tc.reconcileMode)
+			require.NoError(t, err)
+
+			if tc.expectPatch {
+				patcher.AssertCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				patcher.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			cond := meta.FindStatusCondition(conditions, corev1alpha1.ObjectSetDrifted)
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionTrue, cond.Status)
+			assert.Equal(t, tc.expectedReason, cond.Reason)
+			assert.Equal(t, int64(2), cond.ObservedGeneration)
+			assert.Equal(t, "ConfigMap test/test: .data.key (changed by kubectl-edit)", cond.Message)
+
+			if assert.Len(t, recorder.Events, 1) {
+				assert.Contains(t, <-recorder.Events, tc.expectedReason)
+			}
+		})
+	}
+}
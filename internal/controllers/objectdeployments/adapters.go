@@ -13,7 +13,9 @@ type objectDeploymentAccessor interface {
 	GetConditions() *[]metav1.Condition
 	GetSelector() metav1.LabelSelector
 	GetObjectSetTemplate() corev1alpha1.ObjectSetTemplate
+	SetTemplateSpec(corev1alpha1.ObjectSetTemplateSpec)
 	GetRevisionHistoryLimit() *int32
+	GetRolloutStrategy() *corev1alpha1.ObjectDeploymentRolloutStrategy
 	SetStatusConditions(...metav1.Condition)
 	SetStatusCollisionCount(*int32)
 	GetStatusCollisionCount() *int32
@@ -51,6 +51,10 @@ func (o *genericObjectSetMock) SetPaused() {
 	o.Called()
 }
 
+func (o *genericObjectSetMock) SetActive() {
+	o.Called()
+}
+
 func (o *genericObjectSetMock) IsAvailable() bool {
 	args := o.Called()
 	return args.Bool(0)
@@ -148,6 +152,12 @@ func (o *genericObjectDeploymentMock) GetRevisionHistoryLimit() *int32 {
 	return args.Get(0).(*int32)
 }
 
+func (o *genericObjectDeploymentMock) GetRolloutStrategy() *corev1alpha1.ObjectDeploymentRolloutStrategy {
+	args := o.Called()
+	res, _ := args.Get(0).(*corev1alpha1.ObjectDeploymentRolloutStrategy)
+	return res
+}
+
 func (o *genericObjectDeploymentMock) GetStatusCollisionCount() *int32 {
 	args := o.Called()
 	res, _ := args.Get(0).(*int32)
@@ -173,6 +183,10 @@ func (o *genericObjectDeploymentMock) GetObjectSetTemplate() corev1alpha1.Object
 	return args.Get(0).(corev1alpha1.ObjectSetTemplate)
 }
 
+func (o *genericObjectDeploymentMock) SetTemplateSpec(spec corev1alpha1.ObjectSetTemplateSpec) {
+	o.Called(spec)
+}
+
 func (o *genericObjectDeploymentMock) SetStatusControllerOf(a []corev1alpha1.ControlledObjectReference) {
 	o.Called(a)
 }
@@ -231,6 +245,12 @@ func (o *genericObjectSetDeploymentMock) GetRevisionHistoryLimit() *int32 {
 	return args.Get(0).(*int32)
 }
 
+func (o *genericObjectSetDeploymentMock) GetRolloutStrategy() *corev1alpha1.ObjectDeploymentRolloutStrategy {
+	args := o.Called()
+	res, _ := args.Get(0).(*corev1alpha1.ObjectDeploymentRolloutStrategy)
+	return res
+}
+
 func (o *genericObjectSetDeploymentMock) GetStatusCollisionCount() *int32 {
 	args := o.Called()
 	res, _ := args.Get(0).(*int32)
@@ -256,6 +276,10 @@ func (o *genericObjectSetDeploymentMock) GetObjectSetTemplate() corev1alpha1.Obj
 	return args.Get(0).(corev1alpha1.ObjectSetTemplate)
 }
 
+func (o *genericObjectSetDeploymentMock) SetTemplateSpec(spec corev1alpha1.ObjectSetTemplateSpec) {
+	o.Called(spec)
+}
+
 func (o *genericObjectSetDeploymentMock) SetStatusControllerOf(a []corev1alpha1.ControlledObjectReference) {
 	o.Called(a)
 }
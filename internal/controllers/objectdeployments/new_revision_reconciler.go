@@ -7,11 +7,15 @@ import (
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 )
 
 type newRevisionReconciler struct {
@@ -37,6 +41,15 @@ func (r *newRevisionReconciler) Reconcile(ctx context.Context,
 		return ctrl.Result{}, nil
 	}
 
+	if latest := latestActiveObjectSet(prevObjectSets); latest != nil && hasPendingPreHooks(latest) {
+		// Hooks like database migrations must not run concurrently,
+		// so the next revision is only created after the hooks of the latest revision succeeded.
+		// Revisions whose hooks did not succeed within the progress deadline are paused
+		// by the progressDeadlineReconciler, so they can be replaced.
+		log.Info("waiting for hooks of latest revision", "revision", latest.GetRevision())
+		return ctrl.Result{}, nil
+	}
+
 	newObjectSet, err := r.newObjectSetFromDeployment(objectDeployment, prevObjectSets)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("errored while trying to create a new objectset in memory: %w", err)
@@ -62,11 +75,12 @@ func (r *newRevisionReconciler) Reconcile(ctx context.Context,
 		"collisionRev", conflictingObjectSet.GetRevision(),
 		"latestRev", latestRevisionNumber)
 	controllerRef := metav1.GetControllerOf(conflictingObjectSet.ClientObject())
+	isOwnRevision := controllerRef != nil &&
+		controllerRef.UID == objectDeployment.ClientObject().GetUID() &&
+		equality.Semantic.DeepEqual(newObjectSet.GetTemplateSpec(), conflictingObjectSet.GetTemplateSpec())
 	if !conflictingObjectSet.IsArchived() &&
 		conflictingObjectSet.GetRevision() >= latestRevisionNumber &&
-		controllerRef != nil &&
-		controllerRef.UID == objectDeployment.ClientObject().GetUID() &&
-		equality.Semantic.DeepEqual(newObjectSet.GetTemplateSpec(), conflictingObjectSet.GetTemplateSpec()) {
+		isOwnRevision {
 		// This ObjectDeployment is controller of the conflicting ObjectSet and the ObjectSet is deep equal to the
 		// desired new ObjectSet. So no conflict :) This case can happen if the local cache is a little bit slow to
 		// record the ObjectSet Create event.
@@ -74,6 +88,20 @@ func (r *newRevisionReconciler) Reconcile(ctx context.Context,
 		return ctrl.Result{}, nil
 	}
 
+	if conflictingObjectSet.IsArchived() && isOwnRevision {
+		// The template was rolled back to an archived revision.
+		// Archived ObjectSets are not reconciled anymore and their previous revisions are immutable,
+		// so the archived ObjectSet is replaced by a new one, becoming the latest revision.
+		// The deletion of the archived ObjectSet triggers the next reconcile creating it.
+		log.Info("reactivating archived revision", "revision", conflictingObjectSet.GetRevision())
+		if err := r.client.Delete(ctx, conflictingObjectSet.ClientObject(), client.Preconditions{
+			UID: ptr.To(conflictingObjectSet.ClientObject().GetUID()),
+		}); err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("deleting archived ObjectSet: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	log.Info("Got hash collision")
 	// Hash collision, we update the collision counter of the objectdeployment
 	currentCollisionCount := objectDeployment.GetStatusCollisionCount()
@@ -94,33 +122,46 @@ func (r *newRevisionReconciler) newObjectSetFromDeployment(
 	objectDeployment objectDeploymentAccessor,
 	prevObjectSets []genericObjectSet,
 ) (genericObjectSet, error) {
-	deploymentClientObj := objectDeployment.ClientObject()
-	newObjectSet := r.newObjectSet(r.scheme)
-	newObjectSetClientObj := newObjectSet.ClientObject()
-	newObjectSetClientObj.SetName(deploymentClientObj.GetName() + "-" + objectDeployment.GetStatusTemplateHash())
-	newObjectSetClientObj.SetNamespace(deploymentClientObj.GetNamespace())
-	newObjectSetClientObj.SetAnnotations(deploymentClientObj.GetAnnotations())
-	newObjectSetClientObj.SetLabels(objectDeployment.GetObjectSetTemplate().Metadata.Labels)
-	newObjectSet.SetTemplateSpec(
-		objectDeployment.GetObjectSetTemplate().Spec,
+	return newObjectSetForDeployment(
+		r.scheme, r.newObjectSet, objectDeployment,
+		objectDeployment.GetObjectSetTemplate().Spec, prevObjectSets,
 	)
-	newObjectSet.SetPreviousRevisions(prevObjectSets)
+}
 
-	if newObjectSetClientObj.GetLabels() == nil {
-		newObjectSetClientObj.SetLabels(map[string]string{})
+// Creates and returns a new objectset in memory for the given objectdeployment
+// using the given template spec instead of the template of the objectdeployment.
+func newObjectSetForDeployment(
+	scheme *runtime.Scheme,
+	newObjectSet genericObjectSetFactory,
+	objectDeployment objectDeploymentAccessor,
+	templateSpec corev1alpha1.ObjectSetTemplateSpec,
+	prevObjectSets []genericObjectSet,
+) (genericObjectSet, error) {
+	deploymentClientObj := objectDeployment.ClientObject()
+	objectSet := newObjectSet(scheme)
+	objectSetClientObj := objectSet.ClientObject()
+	objectSetClientObj.SetName(deploymentClientObj.GetName() + "-" + objectDeployment.GetStatusTemplateHash())
+	objectSetClientObj.SetNamespace(deploymentClientObj.GetNamespace())
+	objectSetClientObj.SetAnnotations(deploymentClientObj.GetAnnotations())
+	objectSetClientObj.SetLabels(objectDeployment.GetObjectSetTemplate().Metadata.Labels)
+	objectSet.SetTemplateSpec(templateSpec)
+	objectSet.SetPreviousRevisions(prevObjectSets)
+
+	if objectSetClientObj.GetLabels() == nil {
+		objectSetClientObj.SetLabels(map[string]string{})
 	}
-	newObjectSetClientObj.GetLabels()[ObjectSetObjectDeploymentLabel] = objectDeployment.ClientObject().GetName()
+	objectSetClientObj.GetLabels()[ObjectSetObjectDeploymentLabel] = objectDeployment.ClientObject().GetName()
 
-	if newObjectSetClientObj.GetAnnotations() == nil {
-		newObjectSetClientObj.SetAnnotations(map[string]string{})
+	if objectSetClientObj.GetAnnotations() == nil {
+		objectSetClientObj.SetAnnotations(map[string]string{})
 	}
-	newObjectSetClientObj.GetAnnotations()[ObjectSetHashAnnotation] = objectDeployment.GetStatusTemplateHash()
+	objectSetClientObj.GetAnnotations()[ObjectSetHashAnnotation] = objectDeployment.GetStatusTemplateHash()
 
 	if err := controllerutil.SetControllerReference(
-		deploymentClientObj, newObjectSetClientObj, r.scheme); err != nil {
+		deploymentClientObj, objectSetClientObj, scheme); err != nil {
 		return nil, err
 	}
-	return newObjectSet, nil
+	return objectSet, nil
 }
 
 func latestRevisionNumber(prevObjectSets []genericObjectSet) int64 {
@@ -129,3 +170,40 @@ func latestRevisionNumber(prevObjectSets []genericObjectSet) int64 {
 	}
 	return prevObjectSets[len(prevObjectSets)-1].GetRevision()
 }
+
+// Returns the ObjectSet with the highest revision that is neither archived nor paused.
+func latestActiveObjectSet(objectSets []genericObjectSet) genericObjectSet {
+	var latest genericObjectSet
+	for _, objectSet := range objectSets {
+		if objectSet.IsArchived() || objectSet.IsSpecPaused() {
+			continue
+		}
+		if latest == nil || objectSet.GetRevision() > latest.GetRevision() {
+			latest = objectSet
+		}
+	}
+	return latest
+}
+
+// Returns true while the PreInstall or PreUpgrade hooks of the given ObjectSet have not succeeded.
+func hasPendingPreHooks(objectSet genericObjectSet) bool {
+	var hasPreHooks bool
+	for _, phase := range objectSet.GetPhases() {
+		if phase.Hook == corev1alpha1.ObjectSetPhaseHookPreInstall ||
+			phase.Hook == corev1alpha1.ObjectSetPhaseHookPreUpgrade {
+			hasPreHooks = true
+			break
+		}
+	}
+	if !hasPreHooks {
+		return false
+	}
+
+	cond := meta.FindStatusCondition(objectSet.GetConditions(), corev1alpha1.ObjectSetPreHooksSucceeded)
+	if cond == nil {
+		// Either the ObjectSet has not been reconciled yet,
+		// or none of its hooks apply to this revision.
+		return meta.FindStatusCondition(objectSet.GetConditions(), corev1alpha1.ObjectSetAvailable) == nil
+	}
+	return cond.Status != metav1.ConditionTrue
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -51,6 +52,10 @@ func Test_newRevisionReconciler_createsObjectSet(t *testing.T) {
 	hashCollisionOS.Spec.ObjectSetTemplateSpec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
 		{}, {},
 	}
+	archivedHashCollisionOS := makeObjectSet("test-xyz", "test", 1, "xyz", true, true, true)
+	archivedHashCollisionOS.Spec.ObjectSetTemplateSpec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+		{}, {},
+	}
 
 	testCases := []struct {
 		name                       string
@@ -118,7 +123,7 @@ func Test_newRevisionReconciler_createsObjectSet(t *testing.T) {
 			deploymentGeneration:       5,
 			deploymentHash:             "xyz",
 			conflict:                   true,
-			conflictObject:             makeObjectSet("test-xyz", "test", 1, "xyz", true, true, true),
+			conflictObject:             archivedHashCollisionOS,
 			expectedHashCollisionCount: 1,
 		},
 	}
@@ -229,6 +234,74 @@ func Test_newRevisionReconciler_createsObjectSet(t *testing.T) {
 	}
 }
 
+func Test_newRevisionReconciler_reactivatesArchivedRevision(t *testing.T) {
+	t.Parallel()
+	log := testr.New(t)
+	ctx := logr.NewContext(context.Background(), log)
+	clientMock := testutil.NewClient()
+
+	deploymentController := NewObjectDeploymentController(clientMock, log, testScheme)
+	r := newRevisionReconciler{
+		client:       clientMock,
+		newObjectSet: deploymentController.newObjectSet,
+		scheme:       testScheme,
+	}
+
+	// Template rolled back to revision 1.
+	objectDeployment := adapters.NewObjectDeployment(testScheme)
+	objectDeployment.ClientObject().SetName("test")
+	objectDeployment.ClientObject().SetNamespace("test")
+	objectDeployment.SetTemplateSpec(corev1alpha1.ObjectSetTemplateSpec{
+		Phases: []corev1alpha1.ObjectSetTemplatePhase{{}},
+	})
+	objectDeployment.SetStatusTemplateHash("v1")
+
+	rev1 := makeObjectSet("test-v1", "test", 1, "v1", false, true, true)
+	rev1.UID = "rev1-uid"
+	rev2 := makeObjectSet("test-v2", "test", 2, "v2", true, true, false)
+	for _, obj := range []*corev1alpha1.ObjectSet{&rev1, &rev2} {
+		require.NoError(t, controllerutil.SetControllerReference(
+			objectDeployment.ClientObject(), obj, testScheme))
+	}
+
+	clientMock.On("Create", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewAlreadyExists(schema.GroupResource{}, rev1.Name)).Once()
+	clientMock.On("Get", mock.Anything, client.ObjectKeyFromObject(&rev1), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			rev1.DeepCopyInto(args.Get(2).(*corev1alpha1.ObjectSet))
+		}).
+		Return(nil)
+	clientMock.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	res, err := r.Reconcile(ctx, nil, []genericObjectSet{
+		&GenericObjectSet{rev1}, &GenericObjectSet{rev2},
+	}, objectDeployment)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	// The archived revision is replaced instead of being reported as hash collision.
+	assert.Nil(t, objectDeployment.GetStatusCollisionCount())
+	clientMock.AssertCalled(t, "Delete", mock.Anything,
+		mock.MatchedBy(func(obj *corev1alpha1.ObjectSet) bool {
+			return obj.Name == rev1.Name
+		}),
+		[]client.DeleteOption{client.Preconditions{UID: &rev1.UID}})
+
+	// Once the archived ObjectSet is gone, it is created again as the latest revision.
+	clientMock.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	res, err = r.Reconcile(ctx, nil, []genericObjectSet{&GenericObjectSet{rev2}}, objectDeployment)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	clientMock.AssertCalled(t, "Create", mock.Anything,
+		mock.MatchedBy(func(obj *corev1alpha1.ObjectSet) bool {
+			return obj.Name == rev1.Name &&
+				len(obj.Spec.Previous) == 1 && obj.Spec.Previous[0].Name == rev2.Name
+		}),
+		mock.Anything)
+}
+
 func requireObject(t *testing.T,
 	obj *corev1alpha1.ObjectSet,
 	expectedHash string,
@@ -248,3 +321,84 @@ func requireObject(t *testing.T,
 		require.Contains(t, objprevs, prev.Name)
 	}
 }
+
+func Test_newRevisionReconciler_waitsForPreHooks(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		conditions     []metav1.Condition
+		paused         bool
+		expectedCreate bool
+	}{
+		"not yet reconciled": {},
+		"hooks progressing": {
+			conditions: []metav1.Condition{
+				{Type: corev1alpha1.ObjectSetAvailable, Status: metav1.ConditionFalse},
+				{Type: corev1alpha1.ObjectSetPreHooksSucceeded, Status: metav1.ConditionFalse},
+			},
+		},
+		"hooks succeeded": {
+			conditions: []metav1.Condition{
+				{Type: corev1alpha1.ObjectSetAvailable, Status: metav1.ConditionFalse},
+				{Type: corev1alpha1.ObjectSetPreHooksSucceeded, Status: metav1.ConditionTrue},
+			},
+			expectedCreate: true,
+		},
+		"hooks do not apply": {
+			conditions: []metav1.Condition{
+				{Type: corev1alpha1.ObjectSetAvailable, Status: metav1.ConditionTrue},
+			},
+			expectedCreate: true,
+		},
+		"paused": {
+			conditions: []metav1.Condition{
+				{Type: corev1alpha1.ObjectSetPreHooksSucceeded, Status: metav1.ConditionFalse},
+			},
+			paused:         true,
+			expectedCreate: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			log := testr.New(t)
+			ctx := logr.NewContext(context.Background(), log)
+			clientMock := testutil.NewClient()
+			clientMock.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			deploymentController := NewObjectDeploymentController(clientMock, log, testScheme)
+			r := newRevisionReconciler{
+				client:       clientMock,
+				newObjectSet: deploymentController.newObjectSet,
+				scheme:       testScheme,
+			}
+
+			objectDeployment := adapters.NewObjectDeployment(testScheme)
+			objectDeployment.ClientObject().SetName("test")
+			objectDeployment.ClientObject().SetNamespace("test")
+			objectDeployment.SetTemplateSpec(corev1alpha1.ObjectSetTemplateSpec{
+				Phases: []corev1alpha1.ObjectSetTemplatePhase{{}},
+			})
+			objectDeployment.SetStatusTemplateHash("v2")
+
+			latest := makeObjectSet("test-v1", "test", 1, "v1", false, false, false)
+			latest.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+				{Name: "pre-upgrade", Hook: corev1alpha1.ObjectSetPhaseHookPreUpgrade},
+				{Name: "deploy"},
+			}
+			latest.Status.Conditions = tc.conditions
+			if tc.paused {
+				latest.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStatePaused
+			}
+
+			res, err := r.Reconcile(ctx, nil, []genericObjectSet{&GenericObjectSet{latest}}, objectDeployment)
+			require.NoError(t, err)
+			assert.True(t, res.IsZero())
+
+			if tc.expectedCreate {
+				clientMock.AssertCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				clientMock.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -57,6 +57,10 @@ func newGenericObjectDeploymentController(
 		newObjectSetList:    newObjectSetList,
 	}
 	controller.reconciler = []reconciler{
+		&rollbackReconciler{
+			client:                      c,
+			listObjectSetsForDeployment: controller.listObjectSetsByRevision,
+		},
 		&hashReconciler{
 			client: c,
 		},
@@ -73,6 +77,12 @@ func newGenericObjectDeploymentController(
 					client: c,
 				},
 			},
+			progressDeadlineReconciler: &progressDeadlineReconciler{
+				client:       c,
+				newObjectSet: newObjectSet,
+				scheme:       scheme,
+				clock:        defaultClock{},
+			},
 		},
 	}
 
@@ -17,6 +17,9 @@ type objectSetReconciler struct {
 	client                      client.Client
 	listObjectSetsForDeployment listObjectSetsForDeploymentFn
 	reconcilers                 []objectSetSubReconciler
+	// Runs after the ObjectDeployment status has been computed,
+	// so it may overrule the reported progress.
+	progressDeadlineReconciler objectSetSubReconciler
 }
 
 type objectSetSubReconciler interface {
@@ -83,7 +86,10 @@ func (o *objectSetReconciler) Reconcile(
 		return res, subReconcilerErr
 	}
 	o.setObjectDeploymentStatus(ctx, currentObjectSet, prevObjectSets, objectDeployment)
-	return ctrl.Result{}, nil
+	if o.progressDeadlineReconciler == nil {
+		return ctrl.Result{}, nil
+	}
+	return o.progressDeadlineReconciler.Reconcile(ctx, currentObjectSet, prevObjectSets, objectDeployment)
 }
 
 // Does current objectset exist?
@@ -105,13 +111,23 @@ func (o *objectSetReconciler) setObjectDeploymentStatus(ctx context.Context,
 	objectDeployment objectDeploymentAccessor,
 ) {
 	if currentObjectSet == nil {
-		objectDeployment.SetStatusConditions(
-			newProgressingCondition(
+		progressing := newProgressingCondition(
+			metav1.ConditionTrue,
+			progressingReasonProgressing,
+			"Progressing to a new ObjectSet.",
+			objectDeployment.ClientObject().GetGeneration(),
+		)
+		if latest := latestActiveObjectSet(prevObjectSets); latest != nil && hasPendingPreHooks(latest) {
+			progressing = newProgressingCondition(
 				metav1.ConditionTrue,
-				progressingReasonProgressing,
-				"Progressing to a new ObjectSet.",
+				progressingReasonHookPending,
+				fmt.Sprintf("Waiting for hooks of revision %d to succeed before progressing to a new ObjectSet.",
+					latest.GetRevision()),
 				objectDeployment.ClientObject().GetGeneration(),
-			),
+			)
+		}
+		objectDeployment.SetStatusConditions(
+			progressing,
 			conditionFromPreviousObjectSets(objectDeployment.GetGeneration(), prevObjectSets...),
 		)
 		if len(prevObjectSets) > 0 {
@@ -287,7 +303,11 @@ func (r progressingReason) String() string {
 }
 
 const (
-	progressingReasonIdle                    progressingReason = "Idle"
-	progressingReasonLatestRevPendingSuccess progressingReason = "LatestRevisionPendingSuccess"
-	progressingReasonProgressing             progressingReason = "Progressing"
+	progressingReasonIdle                     progressingReason = "Idle"
+	progressingReasonLatestRevPendingSuccess  progressingReason = "LatestRevisionPendingSuccess"
+	progressingReasonProgressing              progressingReason = "Progressing"
+	progressingReasonProgressDeadlineExceeded progressingReason = "ProgressDeadlineExceeded"
+	progressingReasonRolledBack               progressingReason = "RolledBack"
+	progressingReasonHookPending              progressingReason = "HookPending"
+	progressingReasonHookFailed               progressingReason = "HookFailed"
 )
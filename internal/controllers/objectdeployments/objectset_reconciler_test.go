@@ -28,6 +28,7 @@ func Test_ObjectSetReconciler(t *testing.T) {
 		expectedCurrentRevision string
 		expectedPrevRevisions   []string
 		expectedConditions      map[string]metav1.ConditionStatus
+		expectedProgressing     progressingReason
 	}{
 		{
 			name:   "latest revision available",
@@ -66,6 +67,31 @@ func Test_ObjectSetReconciler(t *testing.T) {
 				corev1alpha1.ObjectDeploymentProgressing: metav1.ConditionTrue,
 			},
 		},
+		{
+			name:   "hooks of latest revision pending",
+			client: testutil.NewClient(),
+			revisions: []corev1alpha1.ObjectSet{
+				makeObjectSet("rev1", "test", 1, "xyz", true, true, false),
+				func() corev1alpha1.ObjectSet {
+					objectSet := makeObjectSet("rev2", "test", 2, "abc", false, false, false)
+					objectSet.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+						{Name: "migrate", Hook: corev1alpha1.ObjectSetPhaseHookPreUpgrade},
+						{Name: "deploy"},
+					}
+					return objectSet
+				}(),
+			},
+			deploymentGeneration:    3,
+			deploymentHash:          "hhh",
+			expectedCurrentRevision: "",
+			expectedPrevRevisions:   []string{"rev1", "rev2"},
+			expectedConditions: map[string]metav1.ConditionStatus{
+				// rev1 still available
+				corev1alpha1.ObjectDeploymentAvailable:   metav1.ConditionTrue,
+				corev1alpha1.ObjectDeploymentProgressing: metav1.ConditionTrue,
+			},
+			expectedProgressing: progressingReasonHookPending,
+		},
 		{
 			name:   "latest revision unavailable",
 			client: testutil.NewClient(),
@@ -183,6 +209,10 @@ func Test_ObjectSetReconciler(t *testing.T) {
 				require.NotNil(t, cond, "condition: "+expectedCondition+" should be reported")
 				require.Equal(t, expectedStatus, cond.Status)
 			}
+			if len(testCase.expectedProgressing) > 0 {
+				cond := meta.FindStatusCondition(existingConditions, corev1alpha1.ObjectDeploymentProgressing)
+				require.Equal(t, testCase.expectedProgressing.String(), cond.Reason)
+			}
 		})
 	}
 }
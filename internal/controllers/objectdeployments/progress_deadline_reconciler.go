@@ -0,0 +1,269 @@
+package objectdeployments
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/constants"
+)
+
+// Marks ObjectSets created by an automatic rollback and references the failed revision.
+const ObjectSetRollbackFromRevisionAnnotation = "package-operator.run/rollback-from-revision"
+
+// progressDeadlineReconciler enforces the rollout strategy of an ObjectDeployment.
+// When the current revision does not succeed within the progress deadline,
+// it is reported as failed and - if enabled - paused and replaced by a new revision
+// using the template of the last successful revision.
+// Outdated revisions whose pre hooks did not succeed within the progress deadline
+// are paused, so the new revision is no longer blocked by them.
+type progressDeadlineReconciler struct {
+	client       client.Client
+	newObjectSet genericObjectSetFactory
+	scheme       *runtime.Scheme
+	clock        clock
+}
+
+func (r *progressDeadlineReconciler) Reconcile(ctx context.Context,
+	currentObjectSet genericObjectSet,
+	prevObjectSets []genericObjectSet,
+	objectDeployment objectDeploymentAccessor,
+) (ctrl.Result, error) {
+	if currentObjectSet == nil {
+		return r.replaceRevisionWithFailedHooks(ctx, prevObjectSets, objectDeployment)
+	}
+
+	rolledBackFrom, isRollback := currentObjectSet.ClientObject().
+		GetAnnotations()[ObjectSetRollbackFromRevisionAnnotation]
+	if meta.IsStatusConditionTrue(currentObjectSet.GetConditions(), corev1alpha1.ObjectSetSucceeded) {
+		if isRollback {
+			objectDeployment.SetStatusConditions(newProgressingCondition(
+				metav1.ConditionFalse,
+				progressingReasonRolledBack,
+				fmt.Sprintf("Revision %s did not become Available within the progress deadline and was rolled back.",
+					rolledBackFrom),
+				objectDeployment.GetGeneration(),
+			))
+		}
+		return ctrl.Result{}, nil
+	}
+
+	strategy := objectDeployment.GetRolloutStrategy()
+	if strategy == nil || strategy.ProgressDeadlineSeconds == nil {
+		return ctrl.Result{}, nil
+	}
+
+	deadline := currentObjectSet.ClientObject().GetCreationTimestamp().
+		Add(time.Duration(*strategy.ProgressDeadlineSeconds) * time.Second)
+	if remaining := deadline.Sub(r.clock.Now()); remaining > 0 {
+		// Check again when the deadline is reached.
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	if hasPendingPreHooks(currentObjectSet) {
+		objectDeployment.SetStatusConditions(newProgressingCondition(
+			metav1.ConditionFalse,
+			progressingReasonHookFailed,
+			fmt.Sprintf("Hooks of revision %d did not succeed within %ds.",
+				currentObjectSet.GetRevision(), *strategy.ProgressDeadlineSeconds),
+			objectDeployment.GetGeneration(),
+		))
+	} else {
+		objectDeployment.SetStatusConditions(newProgressingCondition(
+			metav1.ConditionFalse,
+			progressingReasonProgressDeadlineExceeded,
+			fmt.Sprintf("Revision %d did not become Available within %ds.",
+				currentObjectSet.GetRevision(), *strategy.ProgressDeadlineSeconds),
+			objectDeployment.GetGeneration(),
+		))
+	}
+
+	if !strategy.AutoRollback || isRollback {
+		// Never roll back a rollback, to prevent loops.
+		return ctrl.Result{}, nil
+	}
+
+	target, found := lastSucceededRevision(prevObjectSets)
+	if !found {
+		logr.FromContextOrDiscard(ctx).Info("no successful revision to roll back to")
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{}, r.rollback(ctx, currentObjectSet, target, prevObjectSets, objectDeployment)
+}
+
+// The new revision of an ObjectDeployment waits for the pre hooks of the latest revision, see newRevisionReconciler.
+// When these hooks don't succeed within the progress deadline, the latest revision is paused,
+// so the new revision replaces it.
+func (r *progressDeadlineReconciler) replaceRevisionWithFailedHooks(ctx context.Context,
+	prevObjectSets []genericObjectSet,
+	objectDeployment objectDeploymentAccessor,
+) (ctrl.Result, error) {
+	latest := latestActiveObjectSet(prevObjectSets)
+	if latest == nil || !hasPendingPreHooks(latest) {
+		return ctrl.Result{}, nil
+	}
+
+	strategy := objectDeployment.GetRolloutStrategy()
+	if strategy == nil || strategy.ProgressDeadlineSeconds == nil {
+		// Without deadline the hooks never fail.
+		return ctrl.Result{}, nil
+	}
+
+	deadline := latest.ClientObject().GetCreationTimestamp().
+		Add(time.Duration(*strategy.ProgressDeadlineSeconds) * time.Second)
+	if remaining := deadline.Sub(r.clock.Now()); remaining > 0 {
+		// Check again when the deadline is reached.
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	objectDeployment.SetStatusConditions(newProgressingCondition(
+		metav1.ConditionTrue,
+		progressingReasonHookFailed,
+		fmt.Sprintf("Hooks of revision %d did not succeed within %ds, replacing it.",
+			latest.GetRevision(), *strategy.ProgressDeadlineSeconds),
+		objectDeployment.GetGeneration(),
+	))
+
+	logr.FromContextOrDiscard(ctx).Info("pausing revision with failed hooks", "revision", latest.GetRevision())
+	latest.SetPaused()
+	if err := r.client.Update(ctx, latest.ClientObject()); err != nil {
+		return ctrl.Result{}, fmt.Errorf("pausing objectset with failed hooks: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// Pauses the failed ObjectSet and creates a new revision from the template of target.
+// The new ObjectSet carries the template hash of the failed revision,
+// so it is considered current until the template of the ObjectDeployment changes again.
+func (r *progressDeadlineReconciler) rollback(ctx context.Context,
+	failed, target genericObjectSet,
+	prevObjectSets []genericObjectSet,
+	objectDeployment objectDeploymentAccessor,
+) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !failed.IsSpecPaused() {
+		failed.SetPaused()
+		if err := r.client.Update(ctx, failed.ClientObject()); err != nil {
+			return fmt.Errorf("pausing failed objectset: %w", err)
+		}
+	}
+
+	previous := make([]genericObjectSet, 0, len(prevObjectSets)+1)
+	previous = append(previous, prevObjectSets...)
+	previous = append(previous, failed)
+
+	rollbackObjectSet, err := newObjectSetForDeployment(
+		r.scheme, r.newObjectSet, objectDeployment, target.GetTemplateSpec(), previous)
+	if err != nil {
+		return fmt.Errorf("errored while trying to create a rollback objectset in memory: %w", err)
+	}
+
+	obj := rollbackObjectSet.ClientObject()
+	obj.SetName(obj.GetName() + "-rollback")
+
+	// Copy annotations, so the ObjectDeployment is not modified.
+	annotations := make(map[string]string, len(obj.GetAnnotations())+2)
+	for k, v := range obj.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[ObjectSetRollbackFromRevisionAnnotation] = strconv.FormatInt(failed.GetRevision(), 10)
+	annotations[constants.ChangeCauseAnnotation] = fmt.Sprintf(
+		"Automatic rollback to revision %d.", target.GetRevision())
+	obj.SetAnnotations(annotations)
+
+	err = r.client.Create(ctx, obj)
+	if errors.IsAlreadyExists(err) {
+		return r.ensureExistingRollback(ctx, rollbackObjectSet, objectDeployment)
+	}
+	if err != nil {
+		return fmt.Errorf("errored while creating rollback ObjectSet: %w", err)
+	}
+
+	log.Info("rolled back failed revision",
+		"failedRevision", failed.GetRevision(),
+		"targetRevision", target.GetRevision())
+	return nil
+}
+
+// Checks an already existing rollback ObjectSet of the same name.
+// It is kept, if it rolls back the same revision to the same template,
+// which happens when the cache has not caught up with its creation yet.
+// Paused ObjectSets are activated again, while archived or stale ones are deleted,
+// so they are created again by the next reconcile.
+func (r *progressDeadlineReconciler) ensureExistingRollback(ctx context.Context,
+	desired genericObjectSet,
+	objectDeployment objectDeploymentAccessor,
+) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	existing := r.newObjectSet(r.scheme)
+	if err := r.client.Get(
+		ctx, client.ObjectKeyFromObject(desired.ClientObject()), existing.ClientObject(),
+	); err != nil {
+		return fmt.Errorf("getting existing rollback ObjectSet: %w", err)
+	}
+
+	existingObj := existing.ClientObject()
+	controllerRef := metav1.GetControllerOf(existingObj)
+	matches := controllerRef != nil &&
+		controllerRef.UID == objectDeployment.ClientObject().GetUID() &&
+		existingObj.GetAnnotations()[ObjectSetRollbackFromRevisionAnnotation] ==
+			desired.ClientObject().GetAnnotations()[ObjectSetRollbackFromRevisionAnnotation] &&
+		equality.Semantic.DeepEqual(existing.GetTemplateSpec(), desired.GetTemplateSpec())
+
+	switch {
+	case matches && existing.IsSpecPaused():
+		log.Info("activating paused rollback ObjectSet", "objectSet", existingObj.GetName())
+		existing.SetActive()
+		if err := r.client.Update(ctx, existingObj); err != nil {
+			return fmt.Errorf("activating rollback ObjectSet: %w", err)
+		}
+		return nil
+
+	case matches && !existing.IsArchived():
+		return nil
+	}
+
+	log.Info("replacing outdated rollback ObjectSet",
+		"objectSet", existingObj.GetName(), "archived", existing.IsArchived())
+	if err := r.client.Delete(ctx, existingObj, client.Preconditions{
+		UID: ptr.To(existingObj.GetUID()),
+	}); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("deleting outdated rollback ObjectSet: %w", err)
+	}
+	return nil
+}
+
+// Returns the latest ObjectSet that reported success.
+func lastSucceededRevision(objectSets []genericObjectSet) (genericObjectSet, bool) {
+	for i := len(objectSets) - 1; i >= 0; i-- {
+		if meta.IsStatusConditionTrue(objectSets[i].GetConditions(), corev1alpha1.ObjectSetSucceeded) {
+			return objectSets[i], true
+		}
+	}
+	return nil, false
+}
+
+type clock interface {
+	Now() time.Time
+}
+
+type defaultClock struct{}
+
+func (c defaultClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,369 @@
+package objectdeployments
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/testutil"
+)
+
+func TestProgressDeadlineReconciler(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		strategy          *corev1alpha1.ObjectDeploymentRolloutStrategy
+		age               time.Duration
+		currentSucceeded  bool
+		currentIsRollback bool
+		currentHooks      bool
+		expectedRequeue   time.Duration
+		expectedReason    string
+		expectRollback    bool
+	}{
+		"no strategy": {
+			age: time.Hour,
+		},
+		"deadline not reached": {
+			strategy:        &corev1alpha1.ObjectDeploymentRolloutStrategy{ProgressDeadlineSeconds: ptr.To[int32](600)},
+			age:             time.Minute,
+			expectedRequeue: 9 * time.Minute,
+		},
+		"deadline exceeded": {
+			strategy:       &corev1alpha1.ObjectDeploymentRolloutStrategy{ProgressDeadlineSeconds: ptr.To[int32](600)},
+			age:            time.Hour,
+			expectedReason: progressingReasonProgressDeadlineExceeded.String(),
+		},
+		"hooks exceeded deadline": {
+			strategy:       &corev1alpha1.ObjectDeploymentRolloutStrategy{ProgressDeadlineSeconds: ptr.To[int32](600)},
+			age:            time.Hour,
+			currentHooks:   true,
+			expectedReason: progressingReasonHookFailed.String(),
+		},
+		"deadline exceeded with autoRollback": {
+			strategy: &corev1alpha1.ObjectDeploymentRolloutStrategy{
+				ProgressDeadlineSeconds: ptr.To[int32](600),
+				AutoRollback:            true,
+			},
+			age:            time.Hour,
+			expectedReason: progressingReasonProgressDeadlineExceeded.String(),
+			expectRollback: true,
+		},
+		"rollback exceeded deadline": {
+			strategy: &corev1alpha1.ObjectDeploymentRolloutStrategy{
+				ProgressDeadlineSeconds: ptr.To[int32](600),
+				AutoRollback:            true,
+			},
+			age:               time.Hour,
+			currentIsRollback: true,
+			expectedReason:    progressingReasonProgressDeadlineExceeded.String(),
+		},
+		"succeeded": {
+			strategy: &corev1alpha1.ObjectDeploymentRolloutStrategy{
+				ProgressDeadlineSeconds: ptr.To[int32](600),
+				AutoRollback:            true,
+			},
+			age:              time.Hour,
+			currentSucceeded: true,
+		},
+		"rollback succeeded": {
+			strategy: &corev1alpha1.ObjectDeploymentRolloutStrategy{
+				ProgressDeadlineSeconds: ptr.To[int32](600),
+				AutoRollback:            true,
+			},
+			age:               time.Hour,
+			currentSucceeded:  true,
+			currentIsRollback: true,
+			expectedReason:    progressingReasonRolledBack.String(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			c.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			c.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			r := &progressDeadlineReconciler{
+				client:       c,
+				newObjectSet: newGenericObjectSet,
+				scheme:       testScheme,
+				clock:        testClock{now: now},
+			}
+
+			prev := makeObjectSet("test-abc", "test", 1, "abc", true, true, false)
+			prev.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{{Name: "good"}}
+
+			current := makeObjectSet("test-def", "test", 2, "def", tc.currentSucceeded, tc.currentSucceeded, false)
+			current.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{{Name: "bad"}}
+			if tc.currentHooks {
+				current.Spec.Phases = append(current.Spec.Phases, corev1alpha1.ObjectSetTemplatePhase{
+					Name: "migrate", Hook: corev1alpha1.ObjectSetPhaseHookPreUpgrade,
+				})
+			}
+			current.CreationTimestamp = metav1.NewTime(now.Add(-tc.age))
+			if tc.currentIsRollback {
+				current.Annotations[ObjectSetRollbackFromRevisionAnnotation] = "1"
+			}
+
+			od := adapters.NewObjectDeployment(testScheme).(*adapters.ObjectDeployment)
+			od.Name = "test"
+			od.Namespace = "test"
+			od.Spec.RolloutStrategy = tc.strategy
+			od.Status.TemplateHash = "def"
+
+			res, err := r.Reconcile(context.Background(),
+				&GenericObjectSet{current}, []genericObjectSet{&GenericObjectSet{prev}}, od)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRequeue, res.RequeueAfter)
+
+			cond := meta.FindStatusCondition(od.Status.Conditions, corev1alpha1.ObjectDeploymentProgressing)
+			if tc.expectedReason == "" {
+				assert.Nil(t, cond)
+			} else if assert.NotNil(t, cond) {
+				assert.Equal(t, metav1.ConditionFalse, cond.Status)
+				assert.Equal(t, tc.expectedReason, cond.Reason)
+			}
+
+			if !tc.expectRollback {
+				c.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			// failed revision is paused.
+			c.AssertCalled(t, "Update", mock.Anything, mock.MatchedBy(func(obj *corev1alpha1.ObjectSet) bool {
+				return obj.Name == "test-def" &&
+					obj.Spec.LifecycleState == corev1alpha1.ObjectSetLifecycleStatePaused
+			}), mock.Anything)
+
+			// good template is rolled out again.
+			c.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(obj *corev1alpha1.ObjectSet) bool {
+				return obj.Name == "test-def-rollback" &&
+					obj.Annotations[ObjectSetHashAnnotation] == "def" &&
+					obj.Annotations[ObjectSetRollbackFromRevisionAnnotation] == "2" &&
+					obj.Spec.Phases[0].Name == "good" &&
+					len(obj.Spec.Previous) == 2
+			}), mock.Anything)
+			assert.NotContains(t, od.Annotations, ObjectSetRollbackFromRevisionAnnotation)
+		})
+	}
+}
+
+func TestProgressDeadlineReconciler_existingRollback(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		lifecycleState corev1alpha1.ObjectSetLifecycleState
+		rollbackFrom   string
+		phase          string
+		expectUpdate   bool
+		expectDelete   bool
+	}{
+		"slow cache": {
+			rollbackFrom: "2",
+			phase:        "good",
+		},
+		"paused": {
+			lifecycleState: corev1alpha1.ObjectSetLifecycleStatePaused,
+			rollbackFrom:   "2",
+			phase:          "good",
+			expectUpdate:   true,
+		},
+		"archived": {
+			lifecycleState: corev1alpha1.ObjectSetLifecycleStateArchived,
+			rollbackFrom:   "2",
+			phase:          "good",
+			expectDelete:   true,
+		},
+		"other failed revision": {
+			rollbackFrom: "1",
+			phase:        "good",
+			expectDelete: true,
+		},
+		"other template": {
+			rollbackFrom: "2",
+			phase:        "older",
+			expectDelete: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			od := adapters.NewObjectDeployment(testScheme).(*adapters.ObjectDeployment)
+			od.Name = "test"
+			od.Namespace = "test"
+			od.UID = "od-uid"
+			od.Spec.RolloutStrategy = &corev1alpha1.ObjectDeploymentRolloutStrategy{
+				ProgressDeadlineSeconds: ptr.To[int32](600),
+				AutoRollback:            true,
+			}
+			od.Status.TemplateHash = "def"
+
+			prev := makeObjectSet("test-abc", "test", 1, "abc", true, true, false)
+			prev.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{{Name: "good"}}
+
+			current := makeObjectSet("test-def", "test", 2, "def", false, false, false)
+			current.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{{Name: "bad"}}
+			current.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+
+			existing := makeObjectSet("test-def-rollback", "test", 3, "def", false, false, false)
+			existing.UID = "existing-uid"
+			existing.Annotations[ObjectSetRollbackFromRevisionAnnotation] = tc.rollbackFrom
+			existing.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{{Name: tc.phase}}
+			existing.Spec.LifecycleState = tc.lifecycleState
+			require.NoError(t, controllerutil.SetControllerReference(od.ClientObject(), &existing, testScheme))
+
+			c := testutil.NewClient()
+			c.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			c.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			c.On("Create", mock.Anything, mock.Anything, mock.Anything).
+				Return(errors.NewAlreadyExists(schema.GroupResource{}, existing.Name))
+			c.On("Get", mock.Anything, client.ObjectKeyFromObject(&existing), mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					existing.DeepCopyInto(args.Get(2).(*corev1alpha1.ObjectSet))
+				}).
+				Return(nil)
+
+			r := &progressDeadlineReconciler{
+				client:       c,
+				newObjectSet: newGenericObjectSet,
+				scheme:       testScheme,
+				clock:        testClock{now: now},
+			}
+
+			_, err := r.Reconcile(context.Background(),
+				&GenericObjectSet{current}, []genericObjectSet{&GenericObjectSet{prev}}, od)
+			require.NoError(t, err)
+
+			isExisting := mock.MatchedBy(func(obj *corev1alpha1.ObjectSet) bool {
+				return obj.Name == existing.Name
+			})
+			if tc.expectUpdate {
+				c.AssertCalled(t, "Update", mock.Anything, mock.MatchedBy(func(obj *corev1alpha1.ObjectSet) bool {
+					return obj.Name == existing.Name &&
+						obj.Spec.LifecycleState == corev1alpha1.ObjectSetLifecycleStateActive
+				}), mock.Anything)
+			} else {
+				c.AssertNotCalled(t, "Update", mock.Anything, isExisting, mock.Anything)
+			}
+			if tc.expectDelete {
+				c.AssertCalled(t, "Delete", mock.Anything, isExisting,
+					[]client.DeleteOption{client.Preconditions{UID: &existing.UID}})
+			} else {
+				c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestProgressDeadlineReconciler_replaceRevisionWithFailedHooks(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		strategy        *corev1alpha1.ObjectDeploymentRolloutStrategy
+		age             time.Duration
+		hooksSucceeded  bool
+		expectedRequeue time.Duration
+		expectPause     bool
+	}{
+		"no strategy": {
+			age: time.Hour,
+		},
+		"deadline not reached": {
+			strategy:        &corev1alpha1.ObjectDeploymentRolloutStrategy{ProgressDeadlineSeconds: ptr.To[int32](600)},
+			age:             time.Minute,
+			expectedRequeue: 9 * time.Minute,
+		},
+		"deadline exceeded": {
+			strategy:    &corev1alpha1.ObjectDeploymentRolloutStrategy{ProgressDeadlineSeconds: ptr.To[int32](600)},
+			age:         time.Hour,
+			expectPause: true,
+		},
+		"hooks succeeded": {
+			strategy:       &corev1alpha1.ObjectDeploymentRolloutStrategy{ProgressDeadlineSeconds: ptr.To[int32](600)},
+			age:            time.Hour,
+			hooksSucceeded: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			c.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			r := &progressDeadlineReconciler{
+				client:       c,
+				newObjectSet: newGenericObjectSet,
+				scheme:       testScheme,
+				clock:        testClock{now: now},
+			}
+
+			latest := makeObjectSet("test-abc", "test", 1, "abc", false, false, false)
+			latest.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+				{Name: "migrate", Hook: corev1alpha1.ObjectSetPhaseHookPreUpgrade},
+				{Name: "deploy"},
+			}
+			latest.CreationTimestamp = metav1.NewTime(now.Add(-tc.age))
+			hooksStatus := metav1.ConditionFalse
+			if tc.hooksSucceeded {
+				hooksStatus = metav1.ConditionTrue
+			}
+			meta.SetStatusCondition(&latest.Status.Conditions, metav1.Condition{
+				Type:   corev1alpha1.ObjectSetPreHooksSucceeded,
+				Status: hooksStatus,
+			})
+
+			od := adapters.NewObjectDeployment(testScheme).(*adapters.ObjectDeployment)
+			od.Name = "test"
+			od.Namespace = "test"
+			od.Spec.RolloutStrategy = tc.strategy
+			od.Status.TemplateHash = "def"
+
+			res, err := r.Reconcile(context.Background(),
+				nil, []genericObjectSet{&GenericObjectSet{latest}}, od)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRequeue, res.RequeueAfter)
+
+			if !tc.expectPause {
+				c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			c.AssertCalled(t, "Update", mock.Anything, mock.MatchedBy(func(obj *corev1alpha1.ObjectSet) bool {
+				return obj.Name == "test-abc" &&
+					obj.Spec.LifecycleState == corev1alpha1.ObjectSetLifecycleStatePaused
+			}), mock.Anything)
+			cond := meta.FindStatusCondition(od.Status.Conditions, corev1alpha1.ObjectDeploymentProgressing)
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, progressingReasonHookFailed.String(), cond.Reason)
+			}
+		})
+	}
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c testClock) Now() time.Time {
+	return c.now
+}
@@ -0,0 +1,79 @@
+package objectdeployments
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/constants"
+)
+
+// rollbackReconciler handles rollback requests placed on an ObjectDeployment
+// via the ObjectDeploymentRollbackToRevisionAnnotation.
+// It copies the template of the requested (usually archived) ObjectSet back into the ObjectDeployment,
+// so the following reconcilers roll it out as a new revision.
+// If the requested ObjectSet is archived, it is replaced by the new revision, see newRevisionReconciler.
+type rollbackReconciler struct {
+	client                      client.Client
+	listObjectSetsForDeployment listObjectSetsForDeploymentFn
+}
+
+func (r *rollbackReconciler) Reconcile(
+	ctx context.Context, objectDeployment objectDeploymentAccessor,
+) (ctrl.Result, error) {
+	obj := objectDeployment.ClientObject()
+	rawRevision, ok := obj.GetAnnotations()[corev1alpha1.ObjectDeploymentRollbackToRevisionAnnotation]
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	log := logr.FromContextOrDiscard(ctx)
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, corev1alpha1.ObjectDeploymentRollbackToRevisionAnnotation)
+	obj.SetAnnotations(annotations)
+
+	revision, err := strconv.ParseInt(rawRevision, 10, 64)
+	if err != nil {
+		log.Error(err, "invalid rollback revision, ignoring request", "revision", rawRevision)
+		return ctrl.Result{}, r.update(ctx, obj)
+	}
+
+	objectSets, err := r.listObjectSetsForDeployment(ctx, objectDeployment)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing objectsets under deployment errored: %w", err)
+	}
+
+	target, found := findObjectSetByRevision(objectSets, revision)
+	if !found {
+		log.Info("rollback revision not found, ignoring request", "revision", revision)
+		return ctrl.Result{}, r.update(ctx, obj)
+	}
+
+	log.Info("rolling back", "revision", revision, "objectSet", target.ClientObject().GetName())
+	annotations[constants.ChangeCauseAnnotation] = fmt.Sprintf("Rollback to revision %d.", revision)
+	obj.SetAnnotations(annotations)
+	objectDeployment.SetTemplateSpec(target.GetTemplateSpec())
+
+	return ctrl.Result{}, r.update(ctx, obj)
+}
+
+func (r *rollbackReconciler) update(ctx context.Context, obj client.Object) error {
+	if err := r.client.Update(ctx, obj); err != nil {
+		return fmt.Errorf("updating ObjectDeployment for rollback: %w", err)
+	}
+	return nil
+}
+
+func findObjectSetByRevision(objectSets []genericObjectSet, revision int64) (genericObjectSet, bool) {
+	for _, objectSet := range objectSets {
+		if objectSet.GetRevision() == revision {
+			return objectSet, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,116 @@
+package objectdeployments
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/constants"
+	"package-operator.run/internal/testutil"
+)
+
+func TestRollbackReconciler(t *testing.T) {
+	t.Parallel()
+
+	oldTemplate := corev1alpha1.ObjectSetTemplateSpec{
+		Phases: []corev1alpha1.ObjectSetTemplatePhase{{Name: "old"}},
+	}
+	newTemplate := corev1alpha1.ObjectSetTemplateSpec{
+		Phases: []corev1alpha1.ObjectSetTemplatePhase{{Name: "new"}},
+	}
+
+	newObjectSets := func() []genericObjectSet {
+		rev1 := &GenericObjectSet{}
+		rev1.Name = "test-1"
+		rev1.Status.Revision = 1
+		rev1.Spec.ObjectSetTemplateSpec = oldTemplate
+
+		rev2 := &GenericObjectSet{}
+		rev2.Name = "test-2"
+		rev2.Status.Revision = 2
+		rev2.Spec.ObjectSetTemplateSpec = newTemplate
+
+		return []genericObjectSet{rev1, rev2}
+	}
+
+	for name, tc := range map[string]struct {
+		annotations         map[string]string
+		expectUpdate        bool
+		expectedTemplate    corev1alpha1.ObjectSetTemplateSpec
+		expectedChangeCause string
+	}{
+		"no annotation": {
+			expectedTemplate: newTemplate,
+		},
+		"rollback": {
+			annotations: map[string]string{
+				corev1alpha1.ObjectDeploymentRollbackToRevisionAnnotation: "1",
+			},
+			expectUpdate:        true,
+			expectedTemplate:    oldTemplate,
+			expectedChangeCause: "Rollback to revision 1.",
+		},
+		"revision not found": {
+			annotations: map[string]string{
+				corev1alpha1.ObjectDeploymentRollbackToRevisionAnnotation: "5",
+			},
+			expectUpdate:     true,
+			expectedTemplate: newTemplate,
+		},
+		"invalid revision": {
+			annotations: map[string]string{
+				corev1alpha1.ObjectDeploymentRollbackToRevisionAnnotation: "banana",
+			},
+			expectUpdate:     true,
+			expectedTemplate: newTemplate,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			c.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			r := &rollbackReconciler{
+				client: c,
+				listObjectSetsForDeployment: func(
+					context.Context, objectDeploymentAccessor,
+				) ([]genericObjectSet, error) {
+					return newObjectSets(), nil
+				},
+			}
+
+			od := &adapters.ObjectDeployment{
+				ObjectDeployment: corev1alpha1.ObjectDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Annotations: tc.annotations,
+					},
+				},
+			}
+			od.SetTemplateSpec(newTemplate)
+
+			res, err := r.Reconcile(context.Background(), od)
+			require.NoError(t, err)
+			assert.True(t, res.IsZero())
+
+			if !tc.expectUpdate {
+				c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				c.AssertCalled(t, "Update", mock.Anything, od.ClientObject(), mock.Anything)
+			}
+
+			assert.Equal(t, tc.expectedTemplate, od.GetTemplateSpec())
+			assert.NotContains(t, od.GetAnnotations(), corev1alpha1.ObjectDeploymentRollbackToRevisionAnnotation)
+			if tc.expectedChangeCause != "" {
+				assert.Equal(t, tc.expectedChangeCause, od.GetAnnotations()[constants.ChangeCauseAnnotation])
+			}
+		})
+	}
+}
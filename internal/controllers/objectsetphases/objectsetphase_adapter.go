@@ -19,6 +19,8 @@ type genericObjectSetPhase interface {
 	GetGeneration() int64
 	IsPaused() bool
 	SetStatusControllerOf([]corev1alpha1.ControlledObjectReference)
+	GetProbeResults() []corev1alpha1.ProbeResult
+	SetProbeResults([]corev1alpha1.ProbeResult)
 	UpdateStatusPhase()
 }
 
@@ -104,6 +106,14 @@ func (a *GenericObjectSetPhase) SetStatusControllerOf(controllerOf []corev1alpha
 	a.Status.ControllerOf = controllerOf
 }
 
+func (a *GenericObjectSetPhase) GetProbeResults() []corev1alpha1.ProbeResult {
+	return a.Status.ProbeResults
+}
+
+func (a *GenericObjectSetPhase) SetProbeResults(results []corev1alpha1.ProbeResult) {
+	a.Status.ProbeResults = results
+}
+
 type GenericClusterObjectSetPhase struct {
 	corev1alpha1.ClusterObjectSetPhase
 }
@@ -149,4 +159,12 @@ func (a *GenericClusterObjectSetPhase) IsPaused() bool {
 func (a *GenericClusterObjectSetPhase) SetStatusControllerOf(controllerOf []corev1alpha1.ControlledObjectReference) {
 	a.Status.ControllerOf = controllerOf
 }
+
+func (a *GenericClusterObjectSetPhase) GetProbeResults() []corev1alpha1.ProbeResult {
+	return a.Status.ProbeResults
+}
+
+func (a *GenericClusterObjectSetPhase) SetProbeResults(results []corev1alpha1.ProbeResult) {
+	a.Status.ProbeResults = results
+}
 func (a *GenericClusterObjectSetPhase) UpdateStatusPhase() {}
@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -20,6 +21,7 @@ import (
 	"package-operator.run/internal/controllers"
 	"package-operator.run/internal/ownerhandling"
 	"package-operator.run/internal/preflight"
+	"package-operator.run/pkg/probing"
 )
 
 type reconciler interface {
@@ -82,6 +84,8 @@ func NewMultiClusterObjectSetPhaseController(
 	client client.Client, // client to get and update ObjectSetPhases (management cluster).
 	targetWriter client.Writer, // client to patch objects with (hosted cluster).
 	targetRESTMapper meta.RESTMapper,
+	eventRecorder record.EventRecorder,
+	probeEndpoints probing.Endpoints,
 ) *GenericObjectSetPhaseController {
 	return NewGenericObjectSetPhaseController(
 		newGenericObjectSetPhase,
@@ -96,6 +100,7 @@ func NewMultiClusterObjectSetPhaseController(
 				preflight.NewDryRun(targetWriter),
 			},
 		),
+		eventRecorder, probeEndpoints,
 	)
 }
 
@@ -107,6 +112,8 @@ func NewMultiClusterClusterObjectSetPhaseController(
 	client client.Client, // client to get and update ObjectSetPhases (management cluster).
 	targetWriter client.Writer, // client to patch objects with (hosted cluster).
 	targetRESTMapper meta.RESTMapper,
+	eventRecorder record.EventRecorder,
+	probeEndpoints probing.Endpoints,
 ) *GenericObjectSetPhaseController {
 	return NewGenericObjectSetPhaseController(
 		newGenericClusterObjectSetPhase,
@@ -121,6 +128,7 @@ func NewMultiClusterClusterObjectSetPhaseController(
 				preflight.NewNoOwnerReferences(targetRESTMapper),
 			},
 		),
+		eventRecorder, probeEndpoints,
 	)
 }
 
@@ -131,6 +139,8 @@ func NewSameClusterObjectSetPhaseController(
 	class string,
 	client client.Client, // client to get and update ObjectSetPhases.
 	restMapper meta.RESTMapper,
+	eventRecorder record.EventRecorder,
+	probeEndpoints probing.Endpoints,
 ) *GenericObjectSetPhaseController {
 	return NewGenericObjectSetPhaseController(
 		newGenericObjectSetPhase,
@@ -146,6 +156,7 @@ func NewSameClusterObjectSetPhaseController(
 				preflight.NewNoOwnerReferences(restMapper),
 			},
 		),
+		eventRecorder, probeEndpoints,
 	)
 }
 
@@ -156,6 +167,8 @@ func NewSameClusterClusterObjectSetPhaseController(
 	class string,
 	client client.Client, // client to get and update ObjectSetPhases.
 	restMapper meta.RESTMapper,
+	eventRecorder record.EventRecorder,
+	probeEndpoints probing.Endpoints,
 ) *GenericObjectSetPhaseController {
 	return NewGenericObjectSetPhaseController(
 		newGenericClusterObjectSetPhase,
@@ -170,6 +183,7 @@ func NewSameClusterClusterObjectSetPhaseController(
 				preflight.NewNoOwnerReferences(restMapper),
 			},
 		),
+		eventRecorder, probeEndpoints,
 	)
 }
 
@@ -184,6 +198,8 @@ func NewGenericObjectSetPhaseController(
 	client client.Client, // client to get and update ObjectSetPhases.
 	targetWriter client.Writer, // client to patch objects with.
 	preflightChecker preflightChecker,
+	eventRecorder record.EventRecorder,
+	probeEndpoints probing.Endpoints,
 ) *GenericObjectSetPhaseController {
 	controller := &GenericObjectSetPhaseController{
 		newObjectSetPhase: newObjectSetPhase,
@@ -200,12 +216,14 @@ func NewGenericObjectSetPhaseController(
 	phaseReconciler := newObjectSetPhaseReconciler(
 		scheme,
 		controllers.NewPhaseReconciler(
-			scheme, targetWriter, dynamicCache, uncachedClient, ownerStrategy, preflightChecker),
+			scheme, targetWriter, dynamicCache, uncachedClient, ownerStrategy, preflightChecker,
+			eventRecorder),
 		controllers.NewPreviousRevisionLookup(
 			scheme, func(s *runtime.Scheme) controllers.PreviousObjectSet {
 				return newObjectSet(s)
 			}, client).Lookup,
 		ownerStrategy,
+		withProbeEndpoints{Endpoints: probeEndpoints},
 	)
 	controller.teardownHandler = phaseReconciler
 	controller.reconciler = []reconciler{
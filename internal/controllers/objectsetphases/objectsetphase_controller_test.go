@@ -14,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -24,6 +25,7 @@ import (
 	"package-operator.run/internal/constants"
 	"package-operator.run/internal/ownerhandling"
 	"package-operator.run/internal/testutil"
+	"package-operator.run/pkg/probing"
 )
 
 type dynamicCacheMock struct {
@@ -297,7 +299,7 @@ func TestInitializers(t *testing.T) {
 		ctrl := NewMultiClusterObjectSetPhaseController(
 			log, scheme,
 			dc, client, class, client, client,
-			mapper,
+			mapper, record.NewFakeRecorder(10), &probing.DirectEndpoints{},
 		)
 
 		require.NotNil(t, ctrl)
@@ -309,7 +311,7 @@ func TestInitializers(t *testing.T) {
 		ctrl := NewMultiClusterClusterObjectSetPhaseController(
 			log, scheme,
 			dc, client, class, client, client,
-			mapper,
+			mapper, record.NewFakeRecorder(10), &probing.DirectEndpoints{},
 		)
 
 		require.NotNil(t, ctrl)
@@ -321,7 +323,7 @@ func TestInitializers(t *testing.T) {
 		ctrl := NewSameClusterObjectSetPhaseController(
 			log, scheme,
 			dc, client, class, client,
-			mapper,
+			mapper, record.NewFakeRecorder(10), &probing.DirectEndpoints{},
 		)
 
 		require.NotNil(t, ctrl)
@@ -333,7 +335,7 @@ func TestInitializers(t *testing.T) {
 		ctrl := NewSameClusterClusterObjectSetPhaseController(
 			log, scheme,
 			dc, client, class, client,
-			mapper,
+			mapper, record.NewFakeRecorder(10), &probing.DirectEndpoints{},
 		)
 
 		require.NotNil(t, ctrl)
@@ -26,6 +26,7 @@ type objectSetPhaseReconciler struct {
 	lookupPreviousRevisions lookupPreviousRevisions
 	ownerStrategy           ownerStrategy
 	backoff                 *flowcontrol.Backoff
+	probeEndpoints          probing.Endpoints
 }
 
 func newObjectSetPhaseReconciler(
@@ -46,6 +47,7 @@ func newObjectSetPhaseReconciler(
 		lookupPreviousRevisions: lookupPreviousRevisions,
 		ownerStrategy:           ownerStrategy,
 		backoff:                 cfg.GetBackoff(),
+		probeEndpoints:          cfg.ProbeEndpoints,
 	}
 }
 
@@ -72,6 +74,7 @@ func (r *objectSetPhaseReconciler) Reconcile(
 	defer r.backoff.GC()
 
 	controllers.DeleteMappedConditions(ctx, objectSetPhase.GetConditions())
+	controllers.DeleteDriftedCondition(objectSetPhase.GetConditions())
 
 	previous, err := r.lookupPreviousRevisions(ctx, objectSetPhase)
 	if err != nil {
@@ -79,7 +82,8 @@ func (r *objectSetPhaseReconciler) Reconcile(
 	}
 
 	probe, err := internalprobing.Parse(
-		ctx, objectSetPhase.GetAvailabilityProbes())
+		ctx, objectSetPhase.GetAvailabilityProbes(),
+		internalprobing.WithEndpoints{Endpoints: r.probeEndpoints})
 	if err != nil {
 		return res, fmt.Errorf("parsing probes: %w", err)
 	}
@@ -101,6 +105,14 @@ func (r *objectSetPhaseReconciler) Reconcile(
 	if err := r.reportOwnActiveObjects(ctx, objectSetPhase, actualObjects); err != nil {
 		return res, fmt.Errorf("reporting active objects: %w", err)
 	}
+	objectSetPhase.SetProbeResults(controllers.UpdateProbeResults(
+		objectSetPhase.GetProbeResults(), probingResult, metav1.Now()))
+
+	// Probes checking state outside of the probed objects may start failing at any time,
+	// so they are re-evaluated periodically, even while available.
+	if internalprobing.RequiresPolling(objectSetPhase.GetAvailabilityProbes()) {
+		res.RequeueAfter = internalprobing.PollingInterval
+	}
 
 	if !probingResult.IsZero() {
 		meta.SetStatusCondition(
@@ -111,7 +123,6 @@ func (r *objectSetPhaseReconciler) Reconcile(
 				Message:            probingResult.StringWithoutPhase(),
 				ObservedGeneration: objectSetPhase.ClientObject().GetGeneration(),
 			})
-
 		return res, nil
 	}
 
@@ -123,7 +134,7 @@ func (r *objectSetPhaseReconciler) Reconcile(
 		ObservedGeneration: objectSetPhase.ClientObject().GetGeneration(),
 	})
 
-	return ctrl.Result{}, nil
+	return res, nil
 }
 
 func (r *objectSetPhaseReconciler) Teardown(
@@ -153,6 +164,8 @@ func (r *objectSetPhaseReconciler) reportOwnActiveObjects(
 }
 
 type objectSetPhaseReconcilerConfig struct {
+	// Endpoints HTTPGet and TCPSocket probes connect to.
+	ProbeEndpoints probing.Endpoints
 	controllers.BackoffConfig
 }
 
@@ -169,3 +182,11 @@ func (c *objectSetPhaseReconcilerConfig) Default() {
 type objectSetPhaseReconcilerOption interface {
 	ConfigureObjectSetPhaseReconciler(*objectSetPhaseReconcilerConfig)
 }
+
+type withProbeEndpoints struct {
+	Endpoints probing.Endpoints
+}
+
+func (w withProbeEndpoints) ConfigureObjectSetPhaseReconciler(c *objectSetPhaseReconcilerConfig) {
+	c.ProbeEndpoints = w.Endpoints
+}
@@ -26,6 +26,8 @@ type genericObjectSet interface {
 	SetRemotePhases([]corev1alpha1.RemotePhaseReference)
 	GetStatusControllerOf() []corev1alpha1.ControlledObjectReference
 	SetStatusControllerOf([]corev1alpha1.ControlledObjectReference)
+	GetProbeResults() []corev1alpha1.ProbeResult
+	SetProbeResults([]corev1alpha1.ProbeResult)
 }
 
 type genericObjectSetFactory func(
@@ -131,6 +133,14 @@ func (a *GenericObjectSet) GetStatusControllerOf() []corev1alpha1.ControlledObje
 	return a.Status.ControllerOf
 }
 
+func (a *GenericObjectSet) GetProbeResults() []corev1alpha1.ProbeResult {
+	return a.Status.ProbeResults
+}
+
+func (a *GenericObjectSet) SetProbeResults(results []corev1alpha1.ProbeResult) {
+	a.Status.ProbeResults = results
+}
+
 type GenericClusterObjectSet struct {
 	corev1alpha1.ClusterObjectSet
 }
@@ -199,6 +209,14 @@ func (a *GenericClusterObjectSet) GetStatusControllerOf() []corev1alpha1.Control
 	return a.Status.ControllerOf
 }
 
+func (a *GenericClusterObjectSet) GetProbeResults() []corev1alpha1.ProbeResult {
+	return a.Status.ProbeResults
+}
+
+func (a *GenericClusterObjectSet) SetProbeResults(results []corev1alpha1.ProbeResult) {
+	a.Status.ProbeResults = results
+}
+
 func objectSetStatusPhase(conditions []metav1.Condition) corev1alpha1.ObjectSetStatusPhase {
 	if meta.IsStatusConditionTrue(
 		conditions,
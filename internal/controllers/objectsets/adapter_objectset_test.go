@@ -133,6 +133,10 @@ func TestGenericObjectSet(t *testing.T) {
 	controllerOf := []corev1alpha1.ControlledObjectReference{{}}
 	objectSet.SetStatusControllerOf(controllerOf)
 	assert.Equal(t, controllerOf, objectSet.Status.ControllerOf)
+
+	probeResults := []corev1alpha1.ProbeResult{{}}
+	objectSet.SetProbeResults(probeResults)
+	assert.Equal(t, probeResults, objectSet.GetProbeResults())
 }
 
 func TestGenericClusterObjectSet(t *testing.T) {
@@ -177,4 +181,8 @@ func TestGenericClusterObjectSet(t *testing.T) {
 	controllerOf := []corev1alpha1.ControlledObjectReference{{}}
 	objectSet.SetStatusControllerOf(controllerOf)
 	assert.Equal(t, controllerOf, objectSet.Status.ControllerOf)
+
+	probeResults := []corev1alpha1.ProbeResult{{}}
+	objectSet.SetProbeResults(probeResults)
+	assert.Equal(t, probeResults, objectSet.GetProbeResults())
 }
@@ -18,6 +18,7 @@ type genericObjectSetPhase interface {
 	SetRevision(revision int64)
 	SetPrevious([]corev1alpha1.PreviousRevisionReference)
 	GetStatusControllerOf() []corev1alpha1.ControlledObjectReference
+	GetProbeResults() []corev1alpha1.ProbeResult
 }
 
 type genericObjectSetPhaseFactory func(
@@ -99,6 +100,10 @@ func (a *GenericObjectSetPhase) GetStatusControllerOf() []corev1alpha1.Controlle
 	return a.Status.ControllerOf
 }
 
+func (a *GenericObjectSetPhase) GetProbeResults() []corev1alpha1.ProbeResult {
+	return a.Status.ProbeResults
+}
+
 type GenericClusterObjectSetPhase struct {
 	corev1alpha1.ClusterObjectSetPhase
 }
@@ -142,3 +147,7 @@ func (a *GenericClusterObjectSetPhase) SetPrevious(previous []corev1alpha1.Previ
 func (a *GenericClusterObjectSetPhase) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
 	return a.Status.ControllerOf
 }
+
+func (a *GenericClusterObjectSetPhase) GetProbeResults() []corev1alpha1.ProbeResult {
+	return a.Status.ProbeResults
+}
@@ -40,6 +40,9 @@ func TestGenericObjectSetPhase(t *testing.T) {
 	}
 	assert.Equal(t, objectSet.Status.ControllerOf, objectSet.GetStatusControllerOf())
 
+	objectSet.Status.ProbeResults = []corev1alpha1.ProbeResult{{}}
+	assert.Equal(t, objectSet.Status.ProbeResults, objectSet.GetProbeResults())
+
 	probes := []corev1alpha1.ObjectSetProbe{{}}
 	objectSet.SetAvailabilityProbes(probes)
 	assert.Equal(t, probes, objectSet.Spec.AvailabilityProbes)
@@ -85,6 +88,9 @@ func TestGenericClusterObjectSetPhase(t *testing.T) {
 	}
 	assert.Equal(t, objectSet.Status.ControllerOf, objectSet.GetStatusControllerOf())
 
+	objectSet.Status.ProbeResults = []corev1alpha1.ProbeResult{{}}
+	assert.Equal(t, objectSet.Status.ProbeResults, objectSet.GetProbeResults())
+
 	probes := []corev1alpha1.ObjectSetProbe{{}}
 	objectSet.SetAvailabilityProbes(probes)
 	assert.Equal(t, probes, objectSet.Spec.AvailabilityProbes)
@@ -3,12 +3,14 @@ package objectsets
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,6 +26,7 @@ import (
 	"package-operator.run/internal/metrics"
 	"package-operator.run/internal/ownerhandling"
 	"package-operator.run/internal/preflight"
+	"package-operator.run/pkg/probing"
 )
 
 // Generic reconciler for both ObjectSet and ClusterObjectSet objects.
@@ -60,6 +63,8 @@ type teardownHandler interface {
 
 type metricsRecorder interface {
 	RecordObjectSetMetrics(objectSet metrics.GenericObjectSet)
+	RecordObjectSetProbeResults(
+		objectSet metrics.ProbedObjectSet, recovered []corev1alpha1.ProbeResult, now time.Time)
 }
 
 func NewObjectSetController(
@@ -67,13 +72,15 @@ func NewObjectSetController(
 	scheme *runtime.Scheme,
 	dw dynamicCache, uc client.Reader,
 	r metricsRecorder, restMapper meta.RESTMapper,
+	eventRecorder record.EventRecorder,
+	probeEndpoints probing.Endpoints,
 ) *GenericObjectSetController {
 	return newGenericObjectSetController(
 		newGenericObjectSet,
 		newGenericObjectSetPhase,
 		adapters.NewObjectSlice,
 		c, log, scheme, dw, uc, r,
-		restMapper,
+		restMapper, eventRecorder, probeEndpoints,
 	)
 }
 
@@ -82,13 +89,15 @@ func NewClusterObjectSetController(
 	scheme *runtime.Scheme,
 	dw dynamicCache, uc client.Reader,
 	r metricsRecorder, restMapper meta.RESTMapper,
+	eventRecorder record.EventRecorder,
+	probeEndpoints probing.Endpoints,
 ) *GenericObjectSetController {
 	return newGenericObjectSetController(
 		newGenericClusterObjectSet,
 		newGenericClusterObjectSetPhase,
 		adapters.NewClusterObjectSlice,
 		c, log, scheme, dw, uc, r,
-		restMapper,
+		restMapper, eventRecorder, probeEndpoints,
 	)
 }
 
@@ -100,6 +109,8 @@ func newGenericObjectSetController(
 	scheme *runtime.Scheme,
 	dynamicCache dynamicCache, uncachedClient client.Reader,
 	recorder metricsRecorder, restMapper meta.RESTMapper,
+	eventRecorder record.EventRecorder,
+	probeEndpoints probing.Endpoints,
 ) *GenericObjectSetController {
 	controller := &GenericObjectSetController{
 		newObjectSet:      newObjectSet,
@@ -126,6 +137,7 @@ func newGenericObjectSetController(
 					preflight.NewDryRun(client),
 				},
 			),
+			eventRecorder,
 		),
 		newObjectSetRemotePhaseReconciler(
 			client, uncachedClient, scheme, newObjectSetPhase),
@@ -136,6 +148,7 @@ func newGenericObjectSetController(
 		preflight.PhasesCheckerList{
 			preflight.NewObjectDuplicate(),
 		},
+		withProbeEndpoints{Endpoints: probeEndpoints},
 	)
 
 	controller.teardownHandler = phasesReconciler
@@ -187,12 +200,19 @@ func (c *GenericObjectSetController) Reconcile(ctx context.Context, req ctrl.Req
 		ctx, req.NamespacedName, objectSet.ClientObject()); err != nil {
 		return res, client.IgnoreNotFound(err)
 	}
+	previousProbeResults := objectSet.GetProbeResults()
 	defer func() {
 		if err != nil {
 			return
 		}
 		if c.recorder != nil {
 			c.recorder.RecordObjectSetMetrics(objectSet)
+			// Probe results are dropped on archival and deletion,
+			// without the probes having recovered.
+			if !objectSet.IsArchived() && objectSet.ClientObject().GetDeletionTimestamp().IsZero() {
+				c.recorder.RecordObjectSetProbeResults(objectSet, controllers.RecoveredProbeResults(
+					previousProbeResults, objectSet.GetProbeResults()), time.Now())
+			}
 		}
 	}()
 
@@ -364,6 +384,7 @@ func (c *GenericObjectSetController) handleDeletionAndArchival(
 			ObservedGeneration: objectSet.ClientObject().GetGeneration(),
 		})
 		objectSet.SetStatusControllerOf(nil) // we are no longer controlling anything.
+		objectSet.SetProbeResults(nil)
 	}
 
 	return nil
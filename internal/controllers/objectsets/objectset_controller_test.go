@@ -18,6 +18,7 @@ import (
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/constants"
 	"package-operator.run/internal/controllers"
+	"package-operator.run/internal/metrics"
 	"package-operator.run/internal/preflight"
 	"package-operator.run/internal/testutil"
 	"package-operator.run/internal/testutil/dynamiccachemocks"
@@ -414,6 +415,97 @@ func TestGenericObjectSetController_handleDeletionAndArchival(t *testing.T) {
 	}
 }
 
+type metricsRecorderMock struct {
+	mock.Mock
+}
+
+func (m *metricsRecorderMock) RecordObjectSetMetrics(objectSet metrics.GenericObjectSet) {
+	m.Called(objectSet)
+}
+
+func (m *metricsRecorderMock) RecordObjectSetProbeResults(
+	objectSet metrics.ProbedObjectSet, recovered []corev1alpha1.ProbeResult, now time.Time,
+) {
+	m.Called(objectSet, recovered, now)
+}
+
+func TestGenericObjectSetController_Reconcile_probeResultMetrics(t *testing.T) {
+	t.Parallel()
+
+	failing := corev1alpha1.ProbeResult{Phase: "deploy", Type: "Aggregate", Message: "not enough"}
+
+	for name, tc := range map[string]struct {
+		deletionTimestamp *metav1.Time
+		lifecycleState    corev1alpha1.ObjectSetLifecycleState
+		expectRecorded    bool
+	}{
+		"recovered": {
+			expectRecorded: true,
+		},
+		"archived": {
+			lifecycleState: corev1alpha1.ObjectSetLifecycleStateArchived,
+		},
+		"deleted": {
+			deletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			controller, c, dc, pr, rr := newControllerAndMocks()
+			recorder := &metricsRecorderMock{}
+			controller.recorder = recorder
+
+			c.On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil).Maybe()
+			c.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil).Maybe()
+			dc.On("Free", mock.Anything, mock.Anything).Return(nil).Maybe()
+			rr.On("Reconcile", mock.Anything, mock.Anything).
+				Return(ctrl.Result{}, nil).Maybe()
+			pr.On("Teardown", mock.Anything, mock.Anything).
+				Return(true, nil).Maybe()
+			pr.On("Reconcile", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					// Probes succeed.
+					args.Get(1).(genericObjectSet).SetProbeResults(nil)
+				}).
+				Return(ctrl.Result{}, nil).Maybe()
+
+			recorder.On("RecordObjectSetMetrics", mock.Anything)
+			recorder.On("RecordObjectSetProbeResults", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+			objectSet := GenericObjectSet{
+				ObjectSet: corev1alpha1.ObjectSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Finalizers: []string{constants.CachedFinalizer},
+					},
+				},
+			}
+			objectSet.ClientObject().SetDeletionTimestamp(tc.deletionTimestamp)
+			objectSet.Spec.LifecycleState = tc.lifecycleState
+			objectSet.Status.ProbeResults = []corev1alpha1.ProbeResult{failing}
+
+			c.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					objectSet.DeepCopyInto(args.Get(2).(*corev1alpha1.ObjectSet))
+				}).
+				Return(nil)
+
+			_, err := controller.Reconcile(context.Background(), ctrl.Request{})
+			require.NoError(t, err)
+
+			if tc.expectRecorded {
+				recorder.AssertCalled(t, "RecordObjectSetProbeResults",
+					mock.Anything, []corev1alpha1.ProbeResult{failing}, mock.Anything)
+			} else {
+				recorder.AssertNotCalled(t, "RecordObjectSetProbeResults",
+					mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
 var errTest = errors.New("explosion")
 
 func TestGenericObjectSetController_updateStatusError(t *testing.T) {
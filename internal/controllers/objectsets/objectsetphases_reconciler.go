@@ -115,6 +115,7 @@ func (r *objectSetPhasesReconciler) Reconcile(
 	}
 
 	controllers.DeleteMappedConditions(ctx, objectSet.GetConditions())
+	controllers.DeleteDriftedCondition(objectSet.GetConditions())
 
 	controllerOf, probingResult, err := r.reconcile(ctx, objectSet)
 	if controllers.IsExternalResourceNotFound(err) {
@@ -129,6 +130,8 @@ func (r *objectSetPhasesReconciler) Reconcile(
 		return res, err
 	}
 	objectSet.SetStatusControllerOf(controllerOf)
+	objectSet.SetProbeResults(controllers.UpdateProbeResults(
+		objectSet.GetProbeResults(), probingResult, metav1.NewTime(r.cfg.Clock.Now())))
 
 	inTransition := isObjectSetInTransition(objectSet, controllerOf)
 	if inTransition {
@@ -143,6 +146,12 @@ func (r *objectSetPhasesReconciler) Reconcile(
 		meta.RemoveStatusCondition(objectSet.GetConditions(), corev1alpha1.ObjectSetInTransition)
 	}
 
+	// Probes checking state outside of the probed objects may start failing at any time,
+	// so they are re-evaluated periodically, even while available.
+	if internalprobing.RequiresPolling(objectSet.GetAvailabilityProbes()) {
+		res.RequeueAfter = internalprobing.PollingInterval
+	}
+
 	if !probingResult.IsZero() {
 		meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
 			Type:               corev1alpha1.ObjectSetAvailable,
@@ -151,7 +160,6 @@ func (r *objectSetPhasesReconciler) Reconcile(
 			Message:            probingResult.String(),
 			ObservedGeneration: objectSet.ClientObject().GetGeneration(),
 		})
-
 		return res, nil
 	}
 
@@ -191,13 +199,31 @@ func (r *objectSetPhasesReconciler) reconcile(
 	}
 
 	probe, err := internalprobing.Parse(
-		ctx, objectSet.GetAvailabilityProbes())
+		ctx, objectSet.GetAvailabilityProbes(),
+		internalprobing.WithEndpoints{Endpoints: r.cfg.ProbeEndpoints})
 	if err != nil {
 		return nil, controllers.ProbingResult{}, fmt.Errorf("parsing probes: %w", err)
 	}
 
+	// Pre hooks need to succeed before the first regular phase is reconciled.
+	preHook := corev1alpha1.ObjectSetPhaseHookPreInstall
+	if len(objectSet.GetPrevious()) > 0 {
+		preHook = corev1alpha1.ObjectSetPhaseHookPreUpgrade
+	}
+	if probingResult, err := r.reconcileLifecycleHook(
+		ctx, objectSet, preHook, corev1alpha1.ObjectSetPreHooksSucceeded); err != nil {
+		return nil, controllers.ProbingResult{}, fmt.Errorf("%s hooks: %w", preHook, err)
+	} else if !probingResult.IsZero() {
+		return nil, probingResult, nil
+	}
+
 	var controllerOfAll []corev1alpha1.ControlledObjectReference
 	for _, phase := range objectSet.GetPhases() {
+		if len(phase.Hook) > 0 {
+			// Hook phases are reconciled separately.
+			continue
+		}
+
 		controllerOf, probingResult, err := r.reconcilePhase(
 			ctx, objectSet, phase, probe, previous)
 		if err != nil {
@@ -213,9 +239,105 @@ func (r *objectSetPhasesReconciler) reconcile(
 		}
 	}
 
+	if len(objectSet.GetPrevious()) > 0 {
+		if probingResult, err := r.reconcileLifecycleHook(
+			ctx, objectSet, corev1alpha1.ObjectSetPhaseHookPostUpgrade,
+			corev1alpha1.ObjectSetPostHooksSucceeded); err != nil {
+			return nil, controllers.ProbingResult{}, fmt.Errorf("%s hooks: %w",
+				corev1alpha1.ObjectSetPhaseHookPostUpgrade, err)
+		} else if !probingResult.IsZero() {
+			return controllerOfAll, probingResult, nil
+		}
+	}
+
 	return controllerOfAll, controllers.ProbingResult{}, nil
 }
 
+// Hook condition reasons.
+const (
+	hooksProgressingReason = "Progressing"
+	hooksCleanupReason     = "CleanupInProgress"
+	hooksSucceededReason   = "Succeeded"
+)
+
+// Runs the phases of an install or upgrade hook once per ObjectSet.
+// Hook objects are removed after all hook phases passed their probes,
+// so hooks like Jobs don't collide with the hooks of later revisions.
+// Progress is tracked in the given condition and
+// a non-zero ProbingResult is returned until the hook finished.
+func (r *objectSetPhasesReconciler) reconcileLifecycleHook(
+	ctx context.Context, objectSet genericObjectSet,
+	hook corev1alpha1.ObjectSetPhaseHook, conditionType string,
+) (controllers.ProbingResult, error) {
+	hookPhases := phasesWithHook(objectSet.GetPhases(), hook)
+	if len(hookPhases) == 0 {
+		return controllers.ProbingResult{}, nil
+	}
+
+	cond := meta.FindStatusCondition(*objectSet.GetConditions(), conditionType)
+	if cond != nil && cond.Status == metav1.ConditionTrue {
+		return controllers.ProbingResult{}, nil
+	}
+
+	if cond == nil || cond.Reason != hooksCleanupReason {
+		probingResult, err := r.reconcileHookPhases(ctx, objectSet, hook)
+		if err != nil {
+			return controllers.ProbingResult{}, err
+		}
+		if !probingResult.IsZero() {
+			meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
+				Type:               conditionType,
+				Status:             metav1.ConditionFalse,
+				Reason:             hooksProgressingReason,
+				Message:            probingResult.String(),
+				ObservedGeneration: objectSet.ClientObject().GetGeneration(),
+			})
+			return probingResult, nil
+		}
+
+		meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             hooksCleanupReason,
+			Message:            "Hooks succeeded, removing hook objects.",
+			ObservedGeneration: objectSet.ClientObject().GetGeneration(),
+		})
+	}
+
+	reverse(hookPhases)
+	for _, phase := range hookPhases {
+		if cleanupDone, err := r.teardownPhase(ctx, objectSet, phase); err != nil {
+			return controllers.ProbingResult{}, fmt.Errorf("removing hook phase: %w", err)
+		} else if !cleanupDone {
+			return controllers.ProbingResult{
+				PhaseName:    phase.Name,
+				FailedProbes: []string{"removing hook objects"},
+			}, nil
+		}
+	}
+
+	meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             hooksSucceededReason,
+		Message:            fmt.Sprintf("All %s hooks succeeded.", hook),
+		ObservedGeneration: objectSet.ClientObject().GetGeneration(),
+	})
+	return controllers.ProbingResult{}, nil
+}
+
+func phasesWithHook(
+	phases []corev1alpha1.ObjectSetTemplatePhase, hook corev1alpha1.ObjectSetPhaseHook,
+) []corev1alpha1.ObjectSetTemplatePhase {
+	var out []corev1alpha1.ObjectSetTemplatePhase
+	for _, phase := range phases {
+		if phase.Hook == hook {
+			out = append(out, phase)
+		}
+	}
+	return out
+}
+
 func (r *objectSetPhasesReconciler) reconcilePhase(
 	ctx context.Context, objectSet genericObjectSet,
 	phase corev1alpha1.ObjectSetTemplatePhase,
@@ -262,7 +384,27 @@ func (r *objectSetPhasesReconciler) Teardown(
 		return true, nil
 	}
 
-	phases := objectSet.GetPhases()
+	// Hooks only run when the ObjectSet is deleted,
+	// not when it is archived after a newer revision took over.
+	runHooks := !objectSet.IsArchived()
+
+	if runHooks {
+		if probingResult, err := r.reconcileHookPhases(
+			ctx, objectSet, corev1alpha1.ObjectSetPhaseHookPreDelete); err != nil {
+			return false, fmt.Errorf("pre-delete hooks: %w", err)
+		} else if !probingResult.IsZero() {
+			return false, nil
+		}
+	}
+
+	var phases, hookPhases []corev1alpha1.ObjectSetTemplatePhase
+	for _, phase := range objectSet.GetPhases() {
+		if len(phase.Hook) > 0 {
+			hookPhases = append(hookPhases, phase)
+		} else {
+			phases = append(phases, phase)
+		}
+	}
 	reverse(phases) // teardown in reverse order
 
 	for _, phase := range phases {
@@ -274,9 +416,57 @@ func (r *objectSetPhasesReconciler) Teardown(
 		log.Info("cleanup done", "phase", phase.Name)
 	}
 
+	if runHooks {
+		if probingResult, err := r.reconcileHookPhases(
+			ctx, objectSet, corev1alpha1.ObjectSetPhaseHookPostDelete); err != nil {
+			return false, fmt.Errorf("post-delete hooks: %w", err)
+		} else if !probingResult.IsZero() {
+			return false, nil
+		}
+	}
+
+	// Hook objects are removed last.
+	reverse(hookPhases)
+	for _, phase := range hookPhases {
+		if cleanupDone, err := r.teardownPhase(ctx, objectSet, phase); err != nil {
+			return false, fmt.Errorf("error archiving hook phase: %w", err)
+		} else if !cleanupDone {
+			return false, nil
+		}
+		log.Info("cleanup done", "phase", phase.Name)
+	}
+
 	return true, nil
 }
 
+// Reconciles all phases of the given hook and
+// returns the first failing probe, until all of them pass availability probes.
+func (r *objectSetPhasesReconciler) reconcileHookPhases(
+	ctx context.Context, objectSet genericObjectSet,
+	hook corev1alpha1.ObjectSetPhaseHook,
+) (controllers.ProbingResult, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	probe, err := internalprobing.Parse(
+		ctx, objectSet.GetAvailabilityProbes(),
+		internalprobing.WithEndpoints{Endpoints: r.cfg.ProbeEndpoints})
+	if err != nil {
+		return controllers.ProbingResult{}, fmt.Errorf("parsing probes: %w", err)
+	}
+
+	for _, phase := range phasesWithHook(objectSet.GetPhases(), hook) {
+		_, probingResult, err := r.reconcilePhase(ctx, objectSet, phase, probe, nil)
+		if err != nil {
+			return controllers.ProbingResult{}, err
+		}
+		if !probingResult.IsZero() {
+			log.Info("waiting for hook", "phase", phase.Name, "hook", hook, "probes", probingResult.String())
+			return probingResult, nil
+		}
+	}
+	return controllers.ProbingResult{}, nil
+}
+
 func (r *objectSetPhasesReconciler) teardownPhase(
 	ctx context.Context, objectSet genericObjectSet,
 	phase corev1alpha1.ObjectSetTemplatePhase,
@@ -310,6 +500,9 @@ func isObjectSetInTransition(
 	// Build a lookup map of all objects that may be managed by this ObjectSet.
 	allObjectsThatMayBeUnderManagement := map[corev1alpha1.ControlledObjectReference]struct{}{}
 	for _, phase := range objectSet.GetPhases() {
+		if len(phase.Hook) > 0 {
+			continue
+		}
 		for _, obj := range phase.Objects {
 			gvk := obj.Object.GroupVersionKind()
 			ns := obj.Object.GetNamespace()
@@ -370,6 +563,8 @@ func (r *objectSetPhasesReconciler) hasSurvivedDelay(objectSet genericObjectSet)
 
 type objectSetPhasesReconcilerConfig struct {
 	Clock clock
+	// Endpoints HTTPGet and TCPSocket probes connect to.
+	ProbeEndpoints probing.Endpoints
 	controllers.BackoffConfig
 }
 
@@ -399,6 +594,14 @@ func (w withClock) ConfigureObjectSetPhasesReconciler(c *objectSetPhasesReconcil
 	c.Clock = w.Clock
 }
 
+type withProbeEndpoints struct {
+	Endpoints probing.Endpoints
+}
+
+func (w withProbeEndpoints) ConfigureObjectSetPhasesReconciler(c *objectSetPhasesReconcilerConfig) {
+	c.ProbeEndpoints = w.Endpoints
+}
+
 type clock interface {
 	Now() time.Time
 }
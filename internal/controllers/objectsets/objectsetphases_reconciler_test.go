@@ -18,6 +18,7 @@ import (
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/controllers"
 	"package-operator.run/internal/preflight"
+	internalprobing "package-operator.run/internal/probing"
 	"package-operator.run/internal/testutil/controllersmocks"
 )
 
@@ -109,6 +110,47 @@ func TestObjectSetPhasesReconciler_Reconcile(t *testing.T) {
 	assert.Equal(t, metav1.ConditionTrue, availableCond.Status)
 }
 
+func TestObjectSetPhasesReconciler_Reconcile_polling(t *testing.T) {
+	t.Parallel()
+
+	for name, probe := range map[string]corev1alpha1.Probe{
+		"http probe": {HTTPGet: &corev1alpha1.ProbeHTTPGetSpec{Path: "/healthz"}},
+		"time-based cel probe": {CEL: &corev1alpha1.ProbeCELSpec{
+			Rule:    `time.since(self.metadata.creationTimestamp) < duration("1h")`,
+			Message: "expired",
+		}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pr := &phaseReconcilerMock{}
+			remotePr := &remotePhaseReconcilerMock{}
+			lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
+				return []controllers.PreviousObjectSet{}, nil
+			}
+			checker := &phasesCheckerMock{}
+			r := newObjectSetPhasesReconciler(testScheme, pr, remotePr, lookup, checker)
+
+			os := &GenericObjectSet{}
+			os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{{Name: "phase1"}}
+			os.Spec.AvailabilityProbes = []corev1alpha1.ObjectSetProbe{{
+				Probes: []corev1alpha1.Probe{probe},
+			}}
+
+			pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return([]client.Object{}, controllers.ProbingResult{}, nil)
+			checker.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+
+			res, err := r.Reconcile(context.Background(), os)
+			require.NoError(t, err)
+
+			// Probes are evaluated again while available.
+			assert.True(t, meta.IsStatusConditionTrue(*os.GetConditions(), corev1alpha1.ObjectSetAvailable))
+			assert.Equal(t, reconcile.Result{RequeueAfter: internalprobing.PollingInterval}, res)
+		})
+	}
+}
+
 func TestPhaseReconciler_ReconcileBackoff(t *testing.T) {
 	t.Parallel()
 
@@ -199,6 +241,273 @@ func TestObjectSetPhasesReconciler_Teardown(t *testing.T) {
 	}
 }
 
+func TestObjectSetPhasesReconciler_Teardown_hooks(t *testing.T) {
+	t.Parallel()
+
+	preDelete := corev1alpha1.ObjectSetTemplatePhase{
+		Name: "pre-delete",
+		Hook: corev1alpha1.ObjectSetPhaseHookPreDelete,
+	}
+	deploy := corev1alpha1.ObjectSetTemplatePhase{
+		Name: "deploy",
+	}
+	postDelete := corev1alpha1.ObjectSetTemplatePhase{
+		Name: "post-delete",
+		Hook: corev1alpha1.ObjectSetPhaseHookPostDelete,
+	}
+
+	for name, tc := range map[string]struct {
+		archived          bool
+		preDeleteResult   controllers.ProbingResult
+		expectedDone      bool
+		expectedReconcile []corev1alpha1.ObjectSetTemplatePhase
+		expectedTeardown  []corev1alpha1.ObjectSetTemplatePhase
+	}{
+		"waiting for pre-delete hook": {
+			preDeleteResult: controllers.ProbingResult{
+				PhaseName: "pre-delete", FailedProbes: []string{"Job not complete"},
+			},
+			expectedReconcile: []corev1alpha1.ObjectSetTemplatePhase{preDelete},
+		},
+		"hooks succeeded": {
+			expectedDone:      true,
+			expectedReconcile: []corev1alpha1.ObjectSetTemplatePhase{preDelete, postDelete},
+			expectedTeardown:  []corev1alpha1.ObjectSetTemplatePhase{deploy, postDelete, preDelete},
+		},
+		"archived": {
+			archived:         true,
+			expectedDone:     true,
+			expectedTeardown: []corev1alpha1.ObjectSetTemplatePhase{deploy, postDelete, preDelete},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pr := &phaseReconcilerMock{}
+			lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
+				return []controllers.PreviousObjectSet{}, nil
+			}
+			r := newObjectSetPhasesReconciler(
+				testScheme, pr, &remotePhaseReconcilerMock{}, lookup, &phasesCheckerMock{})
+
+			os := &GenericObjectSet{}
+			os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{preDelete, deploy, postDelete}
+			if tc.archived {
+				os.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateArchived
+			}
+
+			var reconciled, tornDown []corev1alpha1.ObjectSetTemplatePhase
+			pr.On("ReconcilePhase", mock.Anything, mock.Anything, preDelete, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					reconciled = append(reconciled, args.Get(2).(corev1alpha1.ObjectSetTemplatePhase))
+				}).
+				Return([]client.Object{}, tc.preDeleteResult, nil)
+			pr.On("ReconcilePhase", mock.Anything, mock.Anything, postDelete, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					reconciled = append(reconciled, args.Get(2).(corev1alpha1.ObjectSetTemplatePhase))
+				}).
+				Return([]client.Object{}, controllers.ProbingResult{}, nil)
+			pr.On("TeardownPhase", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					tornDown = append(tornDown, args.Get(2).(corev1alpha1.ObjectSetTemplatePhase))
+				}).
+				Return(true, nil)
+
+			done, err := r.Teardown(context.Background(), os)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDone, done)
+			assert.Equal(t, tc.expectedReconcile, reconciled)
+			assert.Equal(t, tc.expectedTeardown, tornDown)
+		})
+	}
+}
+
+func TestObjectSetPhasesReconciler_Reconcile_skipsHooks(t *testing.T) {
+	t.Parallel()
+
+	pr := &phaseReconcilerMock{}
+	lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
+		return []controllers.PreviousObjectSet{}, nil
+	}
+	checker := &phasesCheckerMock{}
+	r := newObjectSetPhasesReconciler(testScheme, pr, &remotePhaseReconcilerMock{}, lookup, checker)
+
+	deploy := corev1alpha1.ObjectSetTemplatePhase{Name: "deploy"}
+	hook := corev1alpha1.ObjectSetTemplatePhase{
+		Name: "pre-delete",
+		Hook: corev1alpha1.ObjectSetPhaseHookPreDelete,
+		Objects: []corev1alpha1.ObjectSetObject{
+			{Object: unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "batch/v1", "kind": "Job",
+				"metadata": map[string]any{"name": "cleanup"},
+			}}},
+		},
+	}
+	os := &GenericObjectSet{}
+	os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{hook, deploy}
+
+	pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]client.Object{}, controllers.ProbingResult{}, nil)
+	checker.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+
+	_, err := r.Reconcile(context.Background(), os)
+	require.NoError(t, err)
+
+	pr.AssertNumberOfCalls(t, "ReconcilePhase", 1)
+	pr.AssertCalled(t, "ReconcilePhase", mock.Anything, mock.Anything, deploy, mock.Anything, mock.Anything)
+	// Hook objects must not keep the ObjectSet in transition.
+	assert.False(t, meta.IsStatusConditionTrue(*os.GetConditions(), corev1alpha1.ObjectSetInTransition))
+}
+
+func TestObjectSetPhasesReconciler_Reconcile_lifecycleHooks(t *testing.T) {
+	t.Parallel()
+
+	preInstall := corev1alpha1.ObjectSetTemplatePhase{
+		Name: "pre-install",
+		Hook: corev1alpha1.ObjectSetPhaseHookPreInstall,
+	}
+	preUpgrade := corev1alpha1.ObjectSetTemplatePhase{
+		Name: "pre-upgrade",
+		Hook: corev1alpha1.ObjectSetPhaseHookPreUpgrade,
+	}
+	deploy := corev1alpha1.ObjectSetTemplatePhase{
+		Name: "deploy",
+	}
+	postUpgrade := corev1alpha1.ObjectSetTemplatePhase{
+		Name: "post-upgrade",
+		Hook: corev1alpha1.ObjectSetPhaseHookPostUpgrade,
+	}
+	failed := controllers.ProbingResult{
+		PhaseName: "pre-install", FailedProbes: []string{"Job not complete"},
+	}
+
+	for name, tc := range map[string]struct {
+		upgrade            bool
+		conditions         []metav1.Condition
+		hookResult         controllers.ProbingResult
+		cleanupDone        bool
+		expectedReconcile  []corev1alpha1.ObjectSetTemplatePhase
+		expectedTeardown   []corev1alpha1.ObjectSetTemplatePhase
+		expectedPreReason  string
+		expectedPostReason string
+		expectedAvailable  metav1.ConditionStatus
+	}{
+		"waiting for pre-install hook": {
+			hookResult:        failed,
+			expectedReconcile: []corev1alpha1.ObjectSetTemplatePhase{preInstall},
+			expectedPreReason: hooksProgressingReason,
+			expectedAvailable: metav1.ConditionFalse,
+		},
+		"pre-install hook succeeded": {
+			cleanupDone:       true,
+			expectedReconcile: []corev1alpha1.ObjectSetTemplatePhase{preInstall, deploy},
+			expectedTeardown:  []corev1alpha1.ObjectSetTemplatePhase{preInstall},
+			expectedPreReason: hooksSucceededReason,
+			expectedAvailable: metav1.ConditionTrue,
+		},
+		"removing pre-install hook": {
+			expectedReconcile: []corev1alpha1.ObjectSetTemplatePhase{preInstall},
+			expectedTeardown:  []corev1alpha1.ObjectSetTemplatePhase{preInstall},
+			expectedPreReason: hooksCleanupReason,
+			expectedAvailable: metav1.ConditionFalse,
+		},
+		"cleanup is not rerunning hook": {
+			conditions: []metav1.Condition{
+				{
+					Type:   corev1alpha1.ObjectSetPreHooksSucceeded,
+					Status: metav1.ConditionFalse,
+					Reason: hooksCleanupReason,
+				},
+			},
+			cleanupDone:       true,
+			expectedReconcile: []corev1alpha1.ObjectSetTemplatePhase{deploy},
+			expectedTeardown:  []corev1alpha1.ObjectSetTemplatePhase{preInstall},
+			expectedPreReason: hooksSucceededReason,
+			expectedAvailable: metav1.ConditionTrue,
+		},
+		"pre-install hook already succeeded": {
+			conditions: []metav1.Condition{
+				{
+					Type:   corev1alpha1.ObjectSetPreHooksSucceeded,
+					Status: metav1.ConditionTrue,
+					Reason: hooksSucceededReason,
+				},
+			},
+			expectedReconcile: []corev1alpha1.ObjectSetTemplatePhase{deploy},
+			expectedPreReason: hooksSucceededReason,
+			expectedAvailable: metav1.ConditionTrue,
+		},
+		"upgrade": {
+			upgrade:            true,
+			cleanupDone:        true,
+			expectedReconcile:  []corev1alpha1.ObjectSetTemplatePhase{preUpgrade, deploy, postUpgrade},
+			expectedTeardown:   []corev1alpha1.ObjectSetTemplatePhase{preUpgrade, postUpgrade},
+			expectedPreReason:  hooksSucceededReason,
+			expectedPostReason: hooksSucceededReason,
+			expectedAvailable:  metav1.ConditionTrue,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pr := &phaseReconcilerMock{}
+			lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
+				return []controllers.PreviousObjectSet{}, nil
+			}
+			checker := &phasesCheckerMock{}
+			r := newObjectSetPhasesReconciler(testScheme, pr, &remotePhaseReconcilerMock{}, lookup, checker)
+
+			os := &GenericObjectSet{}
+			os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{preInstall, preUpgrade, deploy, postUpgrade}
+			os.Status.Conditions = tc.conditions
+			if tc.upgrade {
+				os.Spec.Previous = []corev1alpha1.PreviousRevisionReference{{Name: "test-v1"}}
+			}
+
+			var reconciled, tornDown []corev1alpha1.ObjectSetTemplatePhase
+			pr.On("ReconcilePhase", mock.Anything, mock.Anything, deploy, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					reconciled = append(reconciled, args.Get(2).(corev1alpha1.ObjectSetTemplatePhase))
+				}).
+				Return([]client.Object{}, controllers.ProbingResult{}, nil)
+			pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					reconciled = append(reconciled, args.Get(2).(corev1alpha1.ObjectSetTemplatePhase))
+				}).
+				Return([]client.Object{}, tc.hookResult, nil)
+			pr.On("TeardownPhase", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					tornDown = append(tornDown, args.Get(2).(corev1alpha1.ObjectSetTemplatePhase))
+				}).
+				Return(tc.cleanupDone, nil)
+			checker.On("Check", mock.Anything, mock.Anything).Return([]preflight.Violation{}, nil)
+
+			_, err := r.Reconcile(context.Background(), os)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReconcile, reconciled)
+			assert.Equal(t, tc.expectedTeardown, tornDown)
+
+			for condType, reason := range map[string]string{
+				corev1alpha1.ObjectSetPreHooksSucceeded:  tc.expectedPreReason,
+				corev1alpha1.ObjectSetPostHooksSucceeded: tc.expectedPostReason,
+			} {
+				cond := meta.FindStatusCondition(os.Status.Conditions, condType)
+				if len(reason) == 0 {
+					assert.Nil(t, cond, condType)
+					continue
+				}
+				if assert.NotNil(t, cond, condType) {
+					assert.Equal(t, reason, cond.Reason, condType)
+				}
+			}
+
+			available := meta.FindStatusCondition(os.Status.Conditions, corev1alpha1.ObjectSetAvailable)
+			require.NotNil(t, available)
+			assert.Equal(t, tc.expectedAvailable, available.Status)
+		})
+	}
+}
+
 func TestObjectSetPhasesReconciler_SuccessDelay(t *testing.T) {
 	t.Parallel()
 
@@ -196,12 +196,13 @@ func (r *objectSetRemotePhaseReconciler) Reconcile(
 	}
 
 	// Remote Phase is not Available!
-	// Reports its message as failed probe output.
+	// Reports its message and probe results as failed probe output.
 	return activeObjects, controllers.ProbingResult{
 		PhaseName: phase.Name,
 		FailedProbes: []string{
 			availableCond.Message,
 		},
+		Failures: controllers.ProbeFailuresFromResults(currentObjectSetPhase.GetProbeResults()),
 	}, nil
 }
 
@@ -0,0 +1,76 @@
+package packagerepositories
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+type genericPackageRepository interface {
+	ClientObject() client.Object
+	GetSpec() *corev1alpha1.PackageRepositorySpec
+	GetStatus() *corev1alpha1.PackageRepositoryStatus
+}
+
+type genericPackageRepositoryFactory func(
+	scheme *runtime.Scheme) genericPackageRepository
+
+var (
+	packageRepositoryGVK        = corev1alpha1.GroupVersion.WithKind("PackageRepository")
+	clusterPackageRepositoryGVK = corev1alpha1.GroupVersion.WithKind("ClusterPackageRepository")
+)
+
+func newGenericPackageRepository(scheme *runtime.Scheme) genericPackageRepository {
+	obj, err := scheme.New(packageRepositoryGVK)
+	if err != nil {
+		panic(err)
+	}
+
+	return &GenericPackageRepository{
+		PackageRepository: *obj.(*corev1alpha1.PackageRepository),
+	}
+}
+
+func newGenericClusterPackageRepository(scheme *runtime.Scheme) genericPackageRepository {
+	obj, err := scheme.New(clusterPackageRepositoryGVK)
+	if err != nil {
+		panic(err)
+	}
+
+	return &GenericClusterPackageRepository{
+		ClusterPackageRepository: *obj.(*corev1alpha1.ClusterPackageRepository),
+	}
+}
+
+type GenericPackageRepository struct {
+	corev1alpha1.PackageRepository
+}
+
+func (r *GenericPackageRepository) ClientObject() client.Object {
+	return &r.PackageRepository
+}
+
+func (r *GenericPackageRepository) GetSpec() *corev1alpha1.PackageRepositorySpec {
+	return &r.Spec
+}
+
+func (r *GenericPackageRepository) GetStatus() *corev1alpha1.PackageRepositoryStatus {
+	return &r.Status
+}
+
+type GenericClusterPackageRepository struct {
+	corev1alpha1.ClusterPackageRepository
+}
+
+func (r *GenericClusterPackageRepository) ClientObject() client.Object {
+	return &r.ClusterPackageRepository
+}
+
+func (r *GenericClusterPackageRepository) GetSpec() *corev1alpha1.PackageRepositorySpec {
+	return &r.Spec
+}
+
+func (r *GenericClusterPackageRepository) GetStatus() *corev1alpha1.PackageRepositoryStatus {
+	return &r.Status
+}
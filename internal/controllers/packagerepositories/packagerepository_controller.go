@@ -0,0 +1,347 @@
+package packagerepositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/environment"
+	"package-operator.run/internal/packages"
+)
+
+// Interval in which repository images are checked for changes by default.
+const defaultInterval = 10 * time.Minute
+
+type repositoryLoader interface {
+	// Digest returns the digest of the given repository image.
+	Digest(ctx context.Context, ref string, opts ...crane.Option) (string, error)
+	// Load pulls and loads the given repository image.
+	Load(ctx context.Context, ref string, opts ...crane.Option) (*packages.RepositoryIndex, error)
+}
+
+var _ environment.Sinker = (*GenericPackageRepositoryController)(nil)
+
+// GenericPackageRepositoryController pulls package repository images
+// and reports the packages available in them.
+type GenericPackageRepositoryController struct {
+	newRepository  genericPackageRepositoryFactory
+	log            logr.Logger
+	scheme         *runtime.Scheme
+	client         client.Client
+	uncachedClient client.Client
+	loader         repositoryLoader
+	clock          func() time.Time
+	cfg            ControllerConfig
+
+	// Last loaded index per repository object,
+	// so unchanged repository images are not pulled again.
+	indexesMux sync.Mutex
+	indexes    map[client.ObjectKey]cachedIndex
+}
+
+type cachedIndex struct {
+	digest string
+	index  *packages.RepositoryIndex
+}
+
+// ControllerConfig holds the configuration for the PackageRepository controller.
+type ControllerConfig struct {
+	// Namespace Package Operator is running in.
+	// Image pull secrets of ClusterPackageRepositories and default image pull secrets are looked up here.
+	ManagerNamespace string
+	// Names of image pull secrets used for repositories not referencing any.
+	DefaultImagePullSecrets []string
+}
+
+func NewPackageRepositoryController(
+	client, uncachedClient client.Client,
+	log logr.Logger,
+	scheme *runtime.Scheme,
+	cfg ControllerConfig,
+) *GenericPackageRepositoryController {
+	return newGenericPackageRepositoryController(
+		client, uncachedClient, log, scheme, newGenericPackageRepository, cfg)
+}
+
+func NewClusterPackageRepositoryController(
+	client, uncachedClient client.Client,
+	log logr.Logger,
+	scheme *runtime.Scheme,
+	cfg ControllerConfig,
+) *GenericPackageRepositoryController {
+	return newGenericPackageRepositoryController(
+		client, uncachedClient, log, scheme, newGenericClusterPackageRepository, cfg)
+}
+
+func newGenericPackageRepositoryController(
+	client, uncachedClient client.Client,
+	log logr.Logger,
+	scheme *runtime.Scheme,
+	newRepository genericPackageRepositoryFactory,
+	cfg ControllerConfig,
+) *GenericPackageRepositoryController {
+	return &GenericPackageRepositoryController{
+		newRepository:  newRepository,
+		log:            log,
+		scheme:         scheme,
+		client:         client,
+		uncachedClient: uncachedClient,
+		loader:         craneRepositoryLoader{},
+		clock:          time.Now,
+		cfg:            cfg,
+		indexes:        map[client.ObjectKey]cachedIndex{},
+	}
+}
+
+func (c *GenericPackageRepositoryController) Reconcile(
+	ctx context.Context, req ctrl.Request,
+) (res ctrl.Result, err error) {
+	log := c.log.WithValues("PackageRepository", req.String())
+	defer log.Info("reconciled")
+	ctx = logr.NewContext(ctx, log)
+
+	repo := c.newRepository(c.scheme)
+	if err := c.client.Get(ctx, req.NamespacedName, repo.ClientObject()); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			c.forgetIndex(req.NamespacedName)
+		}
+		return res, client.IgnoreNotFound(err)
+	}
+	if !repo.ClientObject().GetDeletionTimestamp().IsZero() {
+		c.forgetIndex(req.NamespacedName)
+		return res, nil
+	}
+
+	spec, status := repo.GetSpec(), repo.GetStatus()
+	interval := defaultInterval
+	if spec.Interval != nil && spec.Interval.Duration > 0 {
+		interval = spec.Interval.Duration
+	}
+	res.RequeueAfter = interval
+
+	now := c.clock()
+	cond := meta.FindStatusCondition(status.Conditions, corev1alpha1.PackageRepositoryLoaded)
+	if status.LastCheckTime != nil && cond != nil && cond.Status == metav1.ConditionTrue &&
+		cond.ObservedGeneration == repo.ClientObject().GetGeneration() {
+		// Don't hit the registry on every reconcile,
+		// unless the spec changed.
+		if next := status.LastCheckTime.Add(interval); now.Before(next) {
+			res.RequeueAfter = next.Sub(now)
+			return res, nil
+		}
+	}
+
+	if err := c.load(ctx, repo, now); err != nil {
+		// Keep reporting the last loaded packages.
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               corev1alpha1.PackageRepositoryLoaded,
+			Status:             metav1.ConditionFalse,
+			Reason:             "LoadFailed",
+			Message:            err.Error(),
+			ObservedGeneration: repo.ClientObject().GetGeneration(),
+		})
+	}
+
+	if err := c.client.Status().Update(ctx, repo.ClientObject()); err != nil {
+		return res, fmt.Errorf("updating PackageRepository status: %w", err)
+	}
+	return res, nil
+}
+
+// Loads the repository image and reports the packages contained in it.
+func (c *GenericPackageRepositoryController) load(
+	ctx context.Context, repo genericPackageRepository, now time.Time,
+) error {
+	spec, status := repo.GetSpec(), repo.GetStatus()
+	key := client.ObjectKeyFromObject(repo.ClientObject())
+
+	opts, err := c.craneOptions(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	ref, err := name.ParseReference(spec.Image)
+	if err != nil {
+		return fmt.Errorf("parsing repository image: %w", err)
+	}
+	digest, err := c.loader.Digest(ctx, ref.String(), opts...)
+	if err != nil {
+		return fmt.Errorf("resolving repository image digest: %w", err)
+	}
+
+	idx, ok := c.cachedIndex(key, digest)
+	if !ok {
+		// Pull by digest, in case the tag moved in the meantime.
+		idx, err = c.loader.Load(ctx, ref.Context().Digest(digest).String(), opts...)
+		if err != nil {
+			return fmt.Errorf("loading repository image: %w", err)
+		}
+		c.storeIndex(key, digest, idx)
+	}
+
+	status.RepositoryName = ""
+	if md := idx.Metadata(); md != nil {
+		status.RepositoryName = md.Name
+	}
+	status.Digest = digest
+	status.LastCheckTime = &metav1.Time{Time: now}
+	status.Packages = repositoryPackages(idx)
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               corev1alpha1.PackageRepositoryLoaded,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Loaded",
+		Message:            fmt.Sprintf("Loaded %d packages.", len(status.Packages)),
+		ObservedGeneration: repo.ClientObject().GetGeneration(),
+	})
+	return nil
+}
+
+// Lists the packages of a repository index ordered by name.
+func repositoryPackages(idx *packages.RepositoryIndex) []corev1alpha1.PackageRepositoryPackage {
+	names := map[string]struct{}{}
+	for _, entry := range idx.ListAllEntries() {
+		names[entry.Data.Name] = struct{}{}
+	}
+
+	pkgs := make([]corev1alpha1.PackageRepositoryPackage, 0, len(names))
+	for pkgName := range names {
+		pkg := corev1alpha1.PackageRepositoryPackage{Name: pkgName}
+		// Lookups can't fail for package names taken from the index itself.
+		pkg.Versions, _ = idx.ListVersions(pkgName)
+		if latest, err := idx.GetLatestEntry(pkgName); err == nil {
+			pkg.Image = latest.Data.Image
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	slices.SortFunc(pkgs, func(a, b corev1alpha1.PackageRepositoryPackage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return pkgs
+}
+
+// Repository images are pulled with the image pull secrets of the repository
+// or the default image pull secrets, if the repository does not reference any.
+func (c *GenericPackageRepositoryController) craneOptions(
+	ctx context.Context, repo genericPackageRepository,
+) ([]crane.Option, error) {
+	secrets, err := c.getImagePullSecrets(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+
+	keychain, err := packages.NewSecretKeychain(secrets...)
+	if err != nil {
+		return nil, fmt.Errorf("loading image pull secrets: %w", err)
+	}
+	return []crane.Option{
+		crane.WithAuthFromKeychain(authn.NewMultiKeychain(keychain, authn.DefaultKeychain)),
+	}, nil
+}
+
+// Secrets are read with the uncached client, to not cache all Secrets of the cluster.
+func (c *GenericPackageRepositoryController) getImagePullSecrets(
+	ctx context.Context, repo genericPackageRepository,
+) ([]corev1.Secret, error) {
+	namespace := repo.ClientObject().GetNamespace()
+	if len(namespace) == 0 {
+		namespace = c.cfg.ManagerNamespace
+	}
+
+	refs := repo.GetSpec().ImagePullSecrets
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	if len(names) == 0 {
+		namespace = c.cfg.ManagerNamespace
+		names = c.cfg.DefaultImagePullSecrets
+	}
+
+	secrets := make([]corev1.Secret, 0, len(names))
+	for _, secretName := range names {
+		secret := corev1.Secret{}
+		if err := c.uncachedClient.Get(ctx, client.ObjectKey{
+			Name: secretName, Namespace: namespace,
+		}, &secret); err != nil {
+			return nil, fmt.Errorf("getting image pull secret %s/%s: %w", namespace, secretName, err)
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+func (c *GenericPackageRepositoryController) cachedIndex(
+	key client.ObjectKey, digest string,
+) (*packages.RepositoryIndex, bool) {
+	c.indexesMux.Lock()
+	defer c.indexesMux.Unlock()
+
+	cached, ok := c.indexes[key]
+	if !ok || cached.digest != digest {
+		return nil, false
+	}
+	return cached.index, true
+}
+
+func (c *GenericPackageRepositoryController) storeIndex(
+	key client.ObjectKey, digest string, idx *packages.RepositoryIndex,
+) {
+	c.indexesMux.Lock()
+	defer c.indexesMux.Unlock()
+
+	c.indexes[key] = cachedIndex{digest: digest, index: idx}
+}
+
+func (c *GenericPackageRepositoryController) forgetIndex(key client.ObjectKey) {
+	c.indexesMux.Lock()
+	defer c.indexesMux.Unlock()
+
+	delete(c.indexes, key)
+}
+
+// Repositories don't depend on the environment.
+func (c *GenericPackageRepositoryController) SetEnvironment(*manifests.PackageEnvironment) {}
+
+func (c *GenericPackageRepositoryController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(c.newRepository(c.scheme).ClientObject()).
+		Complete(c)
+}
+
+// Pulls repository images from their registry.
+type craneRepositoryLoader struct{}
+
+func (craneRepositoryLoader) Digest(
+	ctx context.Context, ref string, opts ...crane.Option,
+) (string, error) {
+	return crane.Digest(ref, append(opts, crane.WithContext(ctx))...)
+}
+
+func (craneRepositoryLoader) Load(
+	ctx context.Context, ref string, opts ...crane.Option,
+) (*packages.RepositoryIndex, error) {
+	img, err := crane.Pull(ref, append(opts, crane.WithContext(ctx))...)
+	if err != nil {
+		return nil, fmt.Errorf("pull repository image: %w", err)
+	}
+	return packages.LoadRepositoryFromOCI(ctx, img)
+}
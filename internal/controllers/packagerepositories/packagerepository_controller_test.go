@@ -0,0 +1,232 @@
+package packagerepositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages"
+)
+
+var (
+	testScheme = runtime.NewScheme()
+	errTest    = errors.New("explosion")
+)
+
+func init() {
+	if err := corev1alpha1.AddToScheme(testScheme); err != nil {
+		panic(err)
+	}
+}
+
+func TestPackageRepositoryController_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	loadedCond := metav1.Condition{
+		Type:               corev1alpha1.PackageRepositoryLoaded,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Loaded",
+		ObservedGeneration: 1,
+	}
+	loadedPackages := []corev1alpha1.PackageRepositoryPackage{
+		{Name: "banana", Image: "quay.io/banana", Versions: []string{"v1.0.0"}},
+		{Name: "pkg", Image: "quay.io/pkg", Versions: []string{"v1.1.0", "v1.0.0"}},
+	}
+
+	for name, tc := range map[string]struct {
+		status               corev1alpha1.PackageRepositoryStatus
+		digestErr            error
+		expectLoad           bool
+		expectedStatus       metav1.ConditionStatus
+		expectedPackages     []corev1alpha1.PackageRepositoryPackage
+		expectedRequeueAfter time.Duration
+	}{
+		"load": {
+			expectLoad:           true,
+			expectedStatus:       metav1.ConditionTrue,
+			expectedPackages:     loadedPackages,
+			expectedRequeueAfter: defaultInterval,
+		},
+		"recently checked": {
+			status: corev1alpha1.PackageRepositoryStatus{
+				Conditions:    []metav1.Condition{loadedCond},
+				LastCheckTime: &metav1.Time{Time: now.Add(-4 * time.Minute)},
+			},
+			expectedStatus:       metav1.ConditionTrue,
+			expectedRequeueAfter: 6 * time.Minute,
+		},
+		"check interval passed": {
+			status: corev1alpha1.PackageRepositoryStatus{
+				Conditions:    []metav1.Condition{loadedCond},
+				LastCheckTime: &metav1.Time{Time: now.Add(-11 * time.Minute)},
+			},
+			expectLoad:           true,
+			expectedStatus:       metav1.ConditionTrue,
+			expectedPackages:     loadedPackages,
+			expectedRequeueAfter: defaultInterval,
+		},
+		"load failed": {
+			status: corev1alpha1.PackageRepositoryStatus{
+				Conditions:    []metav1.Condition{loadedCond},
+				LastCheckTime: &metav1.Time{Time: now.Add(-time.Hour)},
+				Packages:      loadedPackages[:1],
+			},
+			digestErr:            errTest,
+			expectedStatus:       metav1.ConditionFalse,
+			expectedPackages:     loadedPackages[:1],
+			expectedRequeueAfter: defaultInterval,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &corev1alpha1.PackageRepository{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test",
+					Namespace:  "test",
+					Generation: 1,
+				},
+				Spec: corev1alpha1.PackageRepositorySpec{
+					Image: "quay.io/repo:latest",
+				},
+				Status: tc.status,
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(repo).
+				WithStatusSubresource(repo).
+				Build()
+
+			loader := &repositoryLoaderMock{}
+			loader.
+				On("Digest", mock.Anything, "quay.io/repo:latest", mock.Anything).
+				Return("sha256:abc", tc.digestErr)
+			loader.
+				On("Load", mock.Anything, "quay.io/repo@sha256:abc", mock.Anything).
+				Return(newTestIndex(t), nil)
+
+			controller := NewPackageRepositoryController(
+				c, c, testr.New(t), testScheme, ControllerConfig{})
+			controller.loader = loader
+			controller.clock = func() time.Time { return now }
+
+			res, err := controller.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: client.ObjectKeyFromObject(repo),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRequeueAfter, res.RequeueAfter)
+
+			if tc.expectLoad {
+				loader.AssertCalled(t, "Load", mock.Anything, "quay.io/repo@sha256:abc", mock.Anything)
+			} else {
+				loader.AssertNotCalled(t, "Load", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			actual := &corev1alpha1.PackageRepository{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(repo), actual))
+			cond := meta.FindStatusCondition(actual.Status.Conditions, corev1alpha1.PackageRepositoryLoaded)
+			require.NotNil(t, cond)
+			assert.Equal(t, tc.expectedStatus, cond.Status)
+			assert.Equal(t, tc.expectedPackages, actual.Status.Packages)
+			if tc.expectLoad {
+				assert.Equal(t, "repo", actual.Status.RepositoryName)
+				assert.Equal(t, "sha256:abc", actual.Status.Digest)
+			}
+		})
+	}
+}
+
+func TestPackageRepositoryController_cachedIndex(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	repo := &corev1alpha1.ClusterPackageRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 1},
+		Spec: corev1alpha1.PackageRepositorySpec{
+			Image: "quay.io/repo:latest",
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(repo).
+		WithStatusSubresource(repo).
+		Build()
+
+	loader := &repositoryLoaderMock{}
+	loader.
+		On("Digest", mock.Anything, "quay.io/repo:latest", mock.Anything).
+		Return("sha256:abc", nil)
+	loader.
+		On("Load", mock.Anything, "quay.io/repo@sha256:abc", mock.Anything).
+		Return(newTestIndex(t), nil)
+
+	controller := NewClusterPackageRepositoryController(
+		c, c, testr.New(t), testScheme, ControllerConfig{})
+	controller.loader = loader
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(repo)}
+
+	// Unchanged repository images are only pulled once.
+	for i := range 2 {
+		controller.clock = func() time.Time { return now.Add(time.Duration(i) * time.Hour) }
+		_, err := controller.Reconcile(ctx, req)
+		require.NoError(t, err)
+	}
+	loader.AssertNumberOfCalls(t, "Digest", 2)
+	loader.AssertNumberOfCalls(t, "Load", 1)
+
+	// Deleted repositories are dropped from the cache.
+	require.NoError(t, c.Delete(ctx, repo))
+	_, err := controller.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, controller.indexes)
+}
+
+func newTestIndex(t *testing.T) *packages.RepositoryIndex {
+	t.Helper()
+
+	idx := packages.NewRepositoryIndex(metav1.ObjectMeta{Name: "repo"})
+	for _, entry := range []manifests.RepositoryEntryData{
+		{Image: "quay.io/pkg", Digest: "sha256:1", Name: "pkg", Versions: []string{"1.0.0"}},
+		{Image: "quay.io/pkg", Digest: "sha256:2", Name: "pkg", Versions: []string{"1.1.0"}},
+		{Image: "quay.io/banana", Digest: "sha256:3", Name: "banana", Versions: []string{"1.0.0"}},
+	} {
+		require.NoError(t, idx.Add(context.Background(), &manifests.RepositoryEntry{Data: entry}))
+	}
+	return idx
+}
+
+type repositoryLoaderMock struct {
+	mock.Mock
+}
+
+func (m *repositoryLoaderMock) Digest(
+	ctx context.Context, ref string, opts ...crane.Option,
+) (string, error) {
+	args := m.Called(ctx, ref, opts)
+	return args.String(0), args.Error(1)
+}
+
+func (m *repositoryLoaderMock) Load(
+	ctx context.Context, ref string, opts ...crane.Option,
+) (*packages.RepositoryIndex, error) {
+	args := m.Called(ctx, ref, opts)
+	return args.Get(0).(*packages.RepositoryIndex), args.Error(1)
+}
@@ -0,0 +1,177 @@
+package packages
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/controllers"
+	"package-operator.run/internal/utils"
+)
+
+var (
+	// ErrConfigFromNamespace is returned when a Package references a config source in another namespace.
+	ErrConfigFromNamespace = errors.New("config sources of Packages must be in the Package namespace")
+	// ErrConfigFromKeyNotFound is returned when the referenced key does not exist.
+	ErrConfigFromKeyNotFound = errors.New("key not found")
+	// ErrConfigFromDestination is returned when the destination is not a path with a leading dot.
+	ErrConfigFromDestination = errors.New("destination must be a path with a leading dot")
+)
+
+// ConfigFromError is returned when a config source can not be resolved
+// because of an issue with the Package or the referenced object.
+type ConfigFromError struct {
+	Kind string
+	Key  client.ObjectKey
+	Err  error
+}
+
+func (e *ConfigFromError) Error() string {
+	return fmt.Sprintf("config from %s %s: %s", e.Kind, e.Key, e.Err)
+}
+
+func (e *ConfigFromError) Unwrap() error {
+	return e.Err
+}
+
+// Resolves and merges all ConfigMaps and Secrets referenced in .spec.configFrom.
+// Referenced objects are watched via the dynamic cache, so changes trigger a new reconcile.
+// Returns nil if the package does not reference any config sources.
+func (r *unpackReconciler) resolveConfigFrom(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) (map[string]any, error) {
+	sources := pkg.GetConfigFrom()
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	config := map[string]any{}
+	for _, src := range sources {
+		kind, ref := "ConfigMap", src.ConfigMapKeyRef
+		if src.SecretKeyRef != nil {
+			kind, ref = "Secret", src.SecretKeyRef
+		}
+		if ref == nil {
+			continue
+		}
+
+		key := client.ObjectKey{Name: ref.Name, Namespace: r.configFromNamespace(pkg, *ref)}
+		if len(ref.Namespace) > 0 && ref.Namespace != key.Namespace {
+			return nil, &ConfigFromError{
+				Kind: kind, Key: client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace},
+				Err: ErrConfigFromNamespace,
+			}
+		}
+
+		value, err := r.getConfigFromValue(ctx, pkg, kind, key, ref.Key)
+		if err != nil {
+			return nil, err
+		}
+		if err := setConfigFromValue(config, src, value); err != nil {
+			return nil, &ConfigFromError{Kind: kind, Key: key, Err: err}
+		}
+	}
+	return config, nil
+}
+
+// Packages can only reference objects in their own namespace,
+// ClusterPackages default to the namespace Package Operator is running in.
+func (r *unpackReconciler) configFromNamespace(
+	pkg adapters.GenericPackageAccessor, ref corev1alpha1.PackageConfigKeySelector,
+) string {
+	if namespace := pkg.ClientObject().GetNamespace(); len(namespace) > 0 {
+		return namespace
+	}
+	if len(ref.Namespace) > 0 {
+		return ref.Namespace
+	}
+	return r.managerNamespace
+}
+
+// Returns the value of the given key of a ConfigMap or Secret.
+func (r *unpackReconciler) getConfigFromValue(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+	kind string, key client.ObjectKey, dataKey string,
+) (string, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind(kind)
+	obj.SetName(key.Name)
+	obj.SetNamespace(key.Namespace)
+
+	if err := r.dynamicCache.Watch(ctx, pkg.ClientObject(), obj); err != nil {
+		return "", fmt.Errorf("watching config source: %w", err)
+	}
+
+	err := r.dynamicCache.Get(ctx, key, obj)
+	switch {
+	case apimachineryerrors.IsNotFound(err):
+		// the referenced object might not be labeled correctly for the cache to pick up,
+		// fallback to an uncached read to discover.
+		if err := r.uncachedClient.Get(ctx, key, obj); apimachineryerrors.IsNotFound(err) {
+			return "", &ConfigFromError{Kind: kind, Key: key, Err: err}
+		} else if err != nil {
+			return "", fmt.Errorf("getting config source %s %s: %w", kind, key, err)
+		}
+
+		// Update object to ensure it is part of our cache and we get events to reconcile.
+		if _, err := controllers.AddDynamicCacheLabel(ctx, r.uncachedClient, obj); err != nil {
+			return "", fmt.Errorf("patching config source for cache: %w", err)
+		}
+	case err != nil:
+		return "", fmt.Errorf("getting config source %s %s: %w", kind, key, err)
+	}
+
+	value, found, err := unstructured.NestedString(obj.Object, "data", dataKey)
+	if err != nil {
+		return "", &ConfigFromError{Kind: kind, Key: key, Err: err}
+	}
+	if !found {
+		return "", &ConfigFromError{
+			Kind: kind, Key: key,
+			Err: fmt.Errorf("%w: %s", ErrConfigFromKeyNotFound, dataKey),
+		}
+	}
+	if kind != "Secret" {
+		return value, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", &ConfigFromError{Kind: kind, Key: key, Err: err}
+	}
+	return string(decoded), nil
+}
+
+// Stores the value at the destination of the config source
+// or merges it into the configuration root, if no destination is set.
+func setConfigFromValue(config map[string]any, src corev1alpha1.PackageConfigSource, value string) error {
+	if len(src.Destination) == 0 {
+		obj := map[string]any{}
+		if err := yaml.Unmarshal([]byte(value), &obj); err != nil {
+			return fmt.Errorf("value must be a YAML or JSON object: %w", err)
+		}
+		for k, v := range utils.MergeConfig(config, obj) {
+			config[k] = v
+		}
+		return nil
+	}
+
+	if !strings.HasPrefix(src.Destination, ".") || len(src.Destination) == 1 {
+		return fmt.Errorf("%w: %s", ErrConfigFromDestination, src.Destination)
+	}
+	path := strings.Split(strings.TrimPrefix(src.Destination, "."), ".")
+	if err := unstructured.SetNestedField(config, value, path...); err != nil {
+		return fmt.Errorf("setting value at %s: %w", src.Destination, err)
+	}
+	return nil
+}
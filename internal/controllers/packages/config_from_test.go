@@ -0,0 +1,182 @@
+package packages
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/testutil"
+	"package-operator.run/internal/testutil/dynamiccachemocks"
+)
+
+func TestUnpackReconciler_resolveConfigFrom(t *testing.T) {
+	t.Parallel()
+
+	// Data of objects in the test namespace by kind and name.
+	objects := map[string]map[string]map[string]any{
+		"ConfigMap": {
+			"defaults": {
+				"config.yaml": "replicas: 1\ndatabase:\n  host: db\n",
+			},
+			"overrides": {
+				"config.yaml": `{"replicas": 3}`,
+			},
+		},
+		"Secret": {
+			"db": {
+				"password": base64.StdEncoding.EncodeToString([]byte("hunter2")),
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		namespace      string
+		sources        []corev1alpha1.PackageConfigSource
+		expectedConfig map[string]any
+		expectedErr    error
+	}{
+		"no sources": {
+			namespace: "test",
+		},
+		"merged in order": {
+			namespace: "test",
+			sources: []corev1alpha1.PackageConfigSource{
+				{ConfigMapKeyRef: &corev1alpha1.PackageConfigKeySelector{Name: "defaults", Key: "config.yaml"}},
+				{ConfigMapKeyRef: &corev1alpha1.PackageConfigKeySelector{Name: "overrides", Key: "config.yaml"}},
+				{
+					SecretKeyRef: &corev1alpha1.PackageConfigKeySelector{Name: "db", Key: "password"},
+					Destination:  ".database.password",
+				},
+			},
+			expectedConfig: map[string]any{
+				"replicas": float64(3),
+				"database": map[string]any{
+					"host":     "db",
+					"password": "hunter2",
+				},
+			},
+		},
+		"cluster package": {
+			sources: []corev1alpha1.PackageConfigSource{
+				{
+					SecretKeyRef: &corev1alpha1.PackageConfigKeySelector{Name: "db", Namespace: "test", Key: "password"},
+					Destination:  ".password",
+				},
+			},
+			expectedConfig: map[string]any{"password": "hunter2"},
+		},
+		"other namespace": {
+			namespace: "other",
+			sources: []corev1alpha1.PackageConfigSource{
+				{ConfigMapKeyRef: &corev1alpha1.PackageConfigKeySelector{Name: "defaults", Namespace: "test", Key: "config.yaml"}},
+			},
+			expectedErr: ErrConfigFromNamespace,
+		},
+		"key not found": {
+			namespace: "test",
+			sources: []corev1alpha1.PackageConfigSource{
+				{ConfigMapKeyRef: &corev1alpha1.PackageConfigKeySelector{Name: "defaults", Key: "banana"}},
+			},
+			expectedErr: ErrConfigFromKeyNotFound,
+		},
+		"invalid destination": {
+			namespace: "test",
+			sources: []corev1alpha1.PackageConfigSource{
+				{
+					SecretKeyRef: &corev1alpha1.PackageConfigKeySelector{Name: "db", Key: "password"},
+					Destination:  "password",
+				},
+			},
+			expectedErr: ErrConfigFromDestination,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dc := &dynamiccachemocks.DynamicCacheMock{}
+			dc.On("Watch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			dc.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					key := args.Get(1).(client.ObjectKey)
+					obj := args.Get(2).(*unstructured.Unstructured)
+					if data, ok := objects[obj.GetKind()][key.Name]; ok && key.Namespace == "test" {
+						obj.Object["data"] = data
+					}
+				}).
+				Return(nil)
+
+			r := &unpackReconciler{
+				uncachedClient:   testutil.NewClient(),
+				dynamicCache:     dc,
+				managerNamespace: "pko",
+			}
+
+			var pkg adapters.GenericPackageAccessor
+			if len(tc.namespace) > 0 {
+				pkg = &adapters.GenericPackage{
+					Package: corev1alpha1.Package{
+						ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: tc.namespace},
+						Spec:       corev1alpha1.PackageSpec{ConfigFrom: tc.sources},
+					},
+				}
+			} else {
+				pkg = &adapters.GenericClusterPackage{
+					ClusterPackage: corev1alpha1.ClusterPackage{
+						ObjectMeta: metav1.ObjectMeta{Name: "test"},
+						Spec:       corev1alpha1.PackageSpec{ConfigFrom: tc.sources},
+					},
+				}
+			}
+
+			config, err := r.resolveConfigFrom(context.Background(), pkg)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				var configFromErr *ConfigFromError
+				assert.ErrorAs(t, err, &configFromErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, config)
+		})
+	}
+}
+
+func TestUnpackReconciler_resolveConfigFrom_notFound(t *testing.T) {
+	t.Parallel()
+
+	dc := &dynamiccachemocks.DynamicCacheMock{}
+	dc.On("Watch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	dc.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(apimachineryerrors.NewNotFound(schema.GroupResource{}, ""))
+	uc := testutil.NewClient()
+	uc.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(apimachineryerrors.NewNotFound(schema.GroupResource{}, ""))
+
+	r := &unpackReconciler{uncachedClient: uc, dynamicCache: dc}
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Spec: corev1alpha1.PackageSpec{
+				ConfigFrom: []corev1alpha1.PackageConfigSource{
+					{ConfigMapKeyRef: &corev1alpha1.PackageConfigKeySelector{Name: "missing", Key: "config.yaml"}},
+				},
+			},
+		},
+	}
+
+	_, err := r.resolveConfigFrom(context.Background(), pkg)
+	var configFromErr *ConfigFromError
+	require.ErrorAs(t, err, &configFromErr)
+	assert.True(t, apimachineryerrors.IsNotFound(configFromErr.Err))
+}
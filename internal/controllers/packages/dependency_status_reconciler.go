@@ -0,0 +1,152 @@
+package packages
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/apis/manifests"
+)
+
+// Package dependency condition reasons.
+const (
+	dependenciesAvailableReason   = "Available"
+	dependenciesUnavailableReason = "DependenciesUnavailable"
+)
+
+// Reports the availability of all dependency (Cluster)Packages on the dependent package.
+// Runs on every reconcile, as dependencies become available long after they have been deployed.
+type dependencyStatusReconciler struct {
+	client              client.Client
+	scheme              *runtime.Scheme
+	newObjectDeployment adapters.ObjectDeploymentFactory
+}
+
+func (r *dependencyStatusReconciler) Reconcile(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) (ctrl.Result, error) {
+	objDep := r.newObjectDeployment(r.scheme)
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(pkg.ClientObject()), objDep.ClientObject())
+	if apimachineryerrors.IsNotFound(err) {
+		// Nothing deployed yet.
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("getting ObjectDeployment: %w", err)
+	}
+
+	dependencies := dependencyObjects(pkg, objDep)
+	if len(dependencies) == 0 {
+		meta.RemoveStatusCondition(pkg.GetConditions(), corev1alpha1.PackageDependenciesAvailable)
+		return ctrl.Result{}, nil
+	}
+
+	var unavailable []string
+	for _, dep := range dependencies {
+		var depPkg adapters.GenericPackageAccessor
+		if dep.Kind == "ClusterPackage" {
+			depPkg = adapters.NewGenericClusterPackage(r.scheme)
+		} else {
+			depPkg = adapters.NewGenericPackage(r.scheme)
+		}
+
+		err := r.client.Get(ctx, dep.ObjectKey, depPkg.ClientObject())
+		switch {
+		case apimachineryerrors.IsNotFound(err):
+			unavailable = append(unavailable, dep.Name+" (not found)")
+			continue
+		case err != nil:
+			return ctrl.Result{}, fmt.Errorf("getting dependency %s: %w", dep.Name, err)
+		}
+
+		available := meta.FindStatusCondition(*depPkg.GetConditions(), corev1alpha1.PackageAvailable)
+		if available == nil ||
+			available.Status != metav1.ConditionTrue ||
+			available.ObservedGeneration != depPkg.ClientObject().GetGeneration() {
+			unavailable = append(unavailable, dep.Name)
+		}
+	}
+
+	if len(unavailable) > 0 {
+		meta.SetStatusCondition(pkg.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.PackageDependenciesAvailable,
+			Status:             metav1.ConditionFalse,
+			Reason:             dependenciesUnavailableReason,
+			Message:            "Dependencies not Available: " + strings.Join(unavailable, ", "),
+			ObservedGeneration: pkg.ClientObject().GetGeneration(),
+		})
+		return ctrl.Result{}, nil
+	}
+
+	meta.SetStatusCondition(pkg.GetConditions(), metav1.Condition{
+		Type:               corev1alpha1.PackageDependenciesAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             dependenciesAvailableReason,
+		Message:            "All dependencies are Available.",
+		ObservedGeneration: pkg.ClientObject().GetGeneration(),
+	})
+	return ctrl.Result{}, nil
+}
+
+type dependencyRef struct {
+	Kind string
+	client.ObjectKey
+}
+
+// Returns all dependency (Cluster)Packages installed by the ObjectDeployment of the given package.
+func dependencyObjects(
+	pkg adapters.GenericPackageAccessor, objDep adapters.ObjectDeploymentAccessor,
+) []dependencyRef {
+	var deps []dependencyRef
+	for _, phase := range objDep.GetTemplateSpec().Phases {
+		for _, obj := range phase.Objects {
+			gvk := obj.Object.GroupVersionKind()
+			if gvk.Group != corev1alpha1.GroupVersion.Group ||
+				(gvk.Kind != "Package" && gvk.Kind != "ClusterPackage") {
+				continue
+			}
+			if obj.Object.GetLabels()[manifests.PackageDependencyOfLabel] != pkg.ClientObject().GetName() {
+				continue
+			}
+			deps = append(deps, dependencyRef{
+				Kind:      gvk.Kind,
+				ObjectKey: client.ObjectKeyFromObject(&obj.Object),
+			})
+		}
+	}
+	return deps
+}
+
+// Enqueues the package that installed the changed dependency (Cluster)Package.
+func enqueueDependent(clusterScoped bool) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(dependentRequests(clusterScoped))
+}
+
+// Maps a dependency (Cluster)Package to the package that installed it.
+// Dependencies of Packages are always installed into the same namespace,
+// while dependencies of ClusterPackages may be installed into any namespace.
+func dependentRequests(clusterScoped bool) handler.MapFunc {
+	return func(_ context.Context, obj client.Object) []reconcile.Request {
+		parent, ok := obj.GetLabels()[manifests.PackageDependencyOfLabel]
+		if !ok {
+			return nil
+		}
+
+		key := client.ObjectKey{Name: parent}
+		if !clusterScoped {
+			key.Namespace = obj.GetNamespace()
+		}
+		return []reconcile.Request{{NamespacedName: key}}
+	}
+}
@@ -0,0 +1,189 @@
+package packages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/testutil"
+)
+
+var testScheme = runtime.NewScheme()
+
+func init() {
+	if err := corev1alpha1.AddToScheme(testScheme); err != nil {
+		panic(err)
+	}
+}
+
+func TestDependencyStatusReconciler(t *testing.T) {
+	t.Parallel()
+
+	dep := unstructured.Unstructured{}
+	dep.SetAPIVersion(corev1alpha1.GroupVersion.String())
+	dep.SetKind("Package")
+	dep.SetName("parent-dep")
+	dep.SetNamespace("test")
+	dep.SetLabels(map[string]string{manifests.PackageDependencyOfLabel: "parent"})
+
+	for name, tc := range map[string]struct {
+		objects        []corev1alpha1.ObjectSetObject
+		getErr         error
+		available      metav1.ConditionStatus
+		expectedStatus metav1.ConditionStatus
+	}{
+		"no dependencies": {},
+		"available": {
+			objects:        []corev1alpha1.ObjectSetObject{{Object: dep}},
+			available:      metav1.ConditionTrue,
+			expectedStatus: metav1.ConditionTrue,
+		},
+		"unavailable": {
+			objects:        []corev1alpha1.ObjectSetObject{{Object: dep}},
+			available:      metav1.ConditionFalse,
+			expectedStatus: metav1.ConditionFalse,
+		},
+		"not found": {
+			objects:        []corev1alpha1.ObjectSetObject{{Object: dep}},
+			getErr:         errors.NewNotFound(schema.GroupResource{}, ""),
+			expectedStatus: metav1.ConditionFalse,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			c.On("Get", mock.Anything, mock.Anything,
+				mock.AnythingOfType("*v1alpha1.ObjectDeployment"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					objDep := args.Get(2).(*corev1alpha1.ObjectDeployment)
+					objDep.Spec.Template.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+						{Name: "deps", Objects: tc.objects},
+					}
+				}).
+				Return(nil)
+			c.On("Get", mock.Anything, client.ObjectKey{Name: "parent-dep", Namespace: "test"},
+				mock.AnythingOfType("*v1alpha1.Package"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					pkg := args.Get(2).(*corev1alpha1.Package)
+					pkg.Status.Conditions = []metav1.Condition{
+						{Type: corev1alpha1.PackageAvailable, Status: tc.available},
+					}
+				}).
+				Return(tc.getErr)
+
+			r := &dependencyStatusReconciler{
+				client:              c,
+				scheme:              testScheme,
+				newObjectDeployment: adapters.NewObjectDeployment,
+			}
+			pkg := &adapters.GenericPackage{
+				Package: corev1alpha1.Package{
+					ObjectMeta: metav1.ObjectMeta{Name: "parent", Namespace: "test"},
+					Status: corev1alpha1.PackageStatus{
+						Conditions: []metav1.Condition{
+							{Type: corev1alpha1.PackageDependenciesAvailable, Status: metav1.ConditionTrue},
+						},
+					},
+				},
+			}
+
+			res, err := r.Reconcile(context.Background(), pkg)
+			require.NoError(t, err)
+			assert.True(t, res.IsZero())
+
+			cond := meta.FindStatusCondition(pkg.Status.Conditions, corev1alpha1.PackageDependenciesAvailable)
+			if len(tc.expectedStatus) == 0 {
+				assert.Nil(t, cond)
+				return
+			}
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, tc.expectedStatus, cond.Status)
+			}
+		})
+	}
+}
+
+func TestDependencyStatusReconciler_noObjectDeployment(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.On("Get", mock.Anything, mock.Anything,
+		mock.AnythingOfType("*v1alpha1.ObjectDeployment"), mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+	r := &dependencyStatusReconciler{
+		client:              c,
+		scheme:              testScheme,
+		newObjectDeployment: adapters.NewObjectDeployment,
+	}
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: "parent", Namespace: "test"},
+		},
+	}
+
+	res, err := r.Reconcile(context.Background(), pkg)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	assert.Empty(t, pkg.Status.Conditions)
+}
+
+func TestDependentRequests(t *testing.T) {
+	t.Parallel()
+
+	child := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "parent-dep",
+			Namespace: "test",
+			Labels:    map[string]string{manifests.PackageDependencyOfLabel: "parent"},
+		},
+	}
+	unrelated := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test"},
+	}
+
+	for name, tc := range map[string]struct {
+		clusterScoped bool
+		obj           client.Object
+		expected      []reconcile.Request
+	}{
+		"namespaced": {
+			obj: child,
+			expected: []reconcile.Request{{
+				NamespacedName: client.ObjectKey{Name: "parent", Namespace: "test"},
+			}},
+		},
+		"cluster scoped": {
+			clusterScoped: true,
+			obj:           child,
+			expected: []reconcile.Request{{
+				NamespacedName: client.ObjectKey{Name: "parent"},
+			}},
+		},
+		"not a dependency": {
+			obj: unrelated,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			reqs := dependentRequests(tc.clusterScoped)(context.Background(), tc.obj)
+			assert.Equal(t, tc.expected, reqs)
+		})
+	}
+}
@@ -7,13 +7,21 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/adapters"
 	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/constants"
 	"package-operator.run/internal/controllers"
+	"package-operator.run/internal/dynamiccache"
 	"package-operator.run/internal/environment"
 	"package-operator.run/internal/metrics"
 	"package-operator.run/internal/packages"
@@ -23,6 +31,14 @@ const loaderJobFinalizer = "package-operator.run/loader-job"
 
 var _ environment.Sinker = (*GenericPackageController)(nil)
 
+type dynamicCache interface {
+	client.Reader
+	Source(handler handler.EventHandler, predicates ...predicate.Predicate) source.Source
+	Free(ctx context.Context, obj client.Object) error
+	Watch(ctx context.Context, owner client.Object, obj runtime.Object) error
+	OwnersForGKV(gvk schema.GroupVersionKind) []dynamiccache.OwnerReference
+}
+
 type reconciler interface {
 	Reconcile(ctx context.Context, pkg adapters.GenericPackageAccessor) (ctrl.Result, error)
 }
@@ -41,6 +57,7 @@ type GenericPackageController struct {
 	client           client.Client
 	log              logr.Logger
 	scheme           *runtime.Scheme
+	dynamicCache     dynamicCache
 	reconciler       []reconciler
 	unpackReconciler *unpackReconciler
 }
@@ -48,28 +65,34 @@ type GenericPackageController struct {
 func NewPackageController(
 	c client.Client, uncachedClient client.Client, log logr.Logger,
 	scheme *runtime.Scheme,
+	dynamicCache dynamicCache,
 	imagePuller imagePuller,
 	metricsRecorder metricsRecorder,
 	packageHashModifier *int32,
+	opts ...UnpackReconcilerOption,
 ) *GenericPackageController {
 	return newGenericPackageController(
 		adapters.NewGenericPackage, adapters.NewObjectDeployment,
-		c, uncachedClient, log, scheme, imagePuller, packages.NewPackageDeployer(c, uncachedClient, scheme),
-		metricsRecorder, packageHashModifier,
+		c, uncachedClient, log, scheme, dynamicCache, imagePuller,
+		packages.NewPackageDeployer(c, uncachedClient, scheme),
+		metricsRecorder, packageHashModifier, opts...,
 	)
 }
 
 func NewClusterPackageController(
 	c client.Client, uncachedClient client.Client, log logr.Logger,
 	scheme *runtime.Scheme,
+	dynamicCache dynamicCache,
 	imagePuller imagePuller,
 	metricsRecorder metricsRecorder,
 	packageHashModifier *int32,
+	opts ...UnpackReconcilerOption,
 ) *GenericPackageController {
 	return newGenericPackageController(
 		adapters.NewGenericClusterPackage, adapters.NewClusterObjectDeployment,
-		c, uncachedClient, log, scheme, imagePuller, packages.NewClusterPackageDeployer(c, scheme),
-		metricsRecorder, packageHashModifier,
+		c, uncachedClient, log, scheme, dynamicCache, imagePuller,
+		packages.NewClusterPackageDeployer(c, scheme),
+		metricsRecorder, packageHashModifier, opts...,
 	)
 }
 
@@ -78,10 +101,12 @@ func newGenericPackageController(
 	newObjectDeployment adapters.ObjectDeploymentFactory,
 	client client.Client, uncachedClient client.Client, log logr.Logger,
 	scheme *runtime.Scheme,
+	dynamicCache dynamicCache,
 	imagePuller imagePuller,
 	packageDeployer packageDeployer,
 	metricsRecorder metricsRecorder,
 	packageHashModifier *int32,
+	opts ...UnpackReconcilerOption,
 ) *GenericPackageController {
 	controller := &GenericPackageController{
 		newPackage:          newPackage,
@@ -90,19 +115,30 @@ func newGenericPackageController(
 		client:              client,
 		log:                 log,
 		scheme:              scheme,
+		dynamicCache:        dynamicCache,
 		unpackReconciler: newUnpackReconciler(
-			client, uncachedClient, imagePuller, packageDeployer,
-			metricsRecorder, packageHashModifier,
+			client, uncachedClient, dynamicCache, imagePuller, packageDeployer,
+			metricsRecorder, packageHashModifier, opts...,
 		),
 	}
 
 	controller.reconciler = []reconciler{
+		&versionTrackingReconciler{
+			resolver:        packages.TrackResolver{},
+			imagePullSecret: controller.unpackReconciler.getImagePullSecrets,
+			clock:           time.Now,
+		},
 		controller.unpackReconciler,
 		&objectDeploymentStatusReconciler{
 			client:              client,
 			scheme:              scheme,
 			newObjectDeployment: newObjectDeployment,
 		},
+		&dependencyStatusReconciler{
+			client:              client,
+			scheme:              scheme,
+			newObjectDeployment: newObjectDeployment,
+		},
 	}
 
 	return controller
@@ -115,12 +151,24 @@ func (c *GenericPackageController) SetEnvironment(env *manifests.PackageEnvironm
 func (c *GenericPackageController) SetupWithManager(mgr ctrl.Manager) error {
 	pkg := c.newPackage(c.scheme).ClientObject()
 	objDep := c.newObjectDeployment(c.scheme).ClientObject()
+	_, clusterScoped := pkg.(*corev1alpha1.ClusterPackage)
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 5}).
 		For(pkg).
 		Owns(objDep).
-		Complete(c)
+		WatchesRawSource(
+			// ConfigMaps and Secrets referenced in .spec.configFrom.
+			c.dynamicCache.Source(
+				dynamiccache.NewEnqueueWatchingObjects(c.dynamicCache, pkg, mgr.GetScheme()),
+			),
+		).
+		// Dependencies report their availability on the package installing them.
+		Watches(&corev1alpha1.Package{}, enqueueDependent(clusterScoped))
+	if clusterScoped {
+		b = b.Watches(&corev1alpha1.ClusterPackage{}, enqueueDependent(clusterScoped))
+	}
+	return b.Complete(c)
 }
 
 func (c *GenericPackageController) Reconcile(
@@ -152,11 +200,28 @@ func (c *GenericPackageController) Reconcile(
 		return res, nil
 	}
 
+	if err := c.reconcileCachedFinalizer(ctx, pkg); err != nil {
+		return res, err
+	}
+
 	for _, r := range c.reconciler {
-		res, err = r.Reconcile(ctx, pkg)
-		if err != nil || !res.IsZero() {
+		var subres ctrl.Result
+		subres, err = r.Reconcile(ctx, pkg)
+		if err != nil {
 			break
 		}
+		if subres.Requeue {
+			res = subres
+			break
+		}
+		// Errors and Requeue stop the chain.
+		// Reconcilers waiting for a point in time, e.g. a maintenance window or the next version lookup,
+		// must not block reporting the status of the current deployment,
+		// so the earliest RequeueAfter is returned after all reconcilers ran.
+		if subres.RequeueAfter > 0 &&
+			(res.RequeueAfter == 0 || subres.RequeueAfter < res.RequeueAfter) {
+			res.RequeueAfter = subres.RequeueAfter
+		}
 	}
 	if err != nil {
 		return res, err
@@ -173,9 +238,28 @@ func (c *GenericPackageController) updateStatus(ctx context.Context, pkg adapter
 	return nil
 }
 
+// Packages referencing ConfigMaps or Secrets watch them via the dynamic cache,
+// which needs to be freed when the package is deleted or no longer references any.
+func (c *GenericPackageController) reconcileCachedFinalizer(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) error {
+	if len(pkg.GetConfigFrom()) > 0 {
+		return controllers.EnsureCachedFinalizer(ctx, c.client, pkg.ClientObject())
+	}
+	if controllerutil.ContainsFinalizer(pkg.ClientObject(), constants.CachedFinalizer) {
+		return controllers.FreeCacheAndRemoveFinalizer(ctx, c.client, pkg.ClientObject(), c.dynamicCache)
+	}
+	return nil
+}
+
 func (c *GenericPackageController) handleDeletion(
 	ctx context.Context, pkg adapters.GenericPackageAccessor,
 ) error {
+	if err := controllers.FreeCacheAndRemoveFinalizer(
+		ctx, c.client, pkg.ClientObject(), c.dynamicCache); err != nil {
+		return err
+	}
+
 	// Remove finalizer from previous versions of PKO.
 	if err := controllers.RemoveFinalizer(
 		ctx, c.client, pkg.ClientObject(), loaderJobFinalizer); err != nil {
@@ -0,0 +1,129 @@
+package packages
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/testutil"
+)
+
+type reconcilerMock struct {
+	mock.Mock
+}
+
+func (r *reconcilerMock) Reconcile(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) (ctrl.Result, error) {
+	args := r.Called(ctx, pkg)
+	return args.Get(0).(ctrl.Result), args.Error(1)
+}
+
+func TestGenericPackageController_Reconcile_chain(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("explosion")
+
+	for name, tc := range map[string]struct {
+		results         []ctrl.Result
+		errs            []error
+		expectedCalls   int
+		expectedResult  ctrl.Result
+		expectedErr     error
+		expectStatusSet bool
+	}{
+		"all succeed": {
+			results:         []ctrl.Result{{}, {}, {}},
+			expectedCalls:   3,
+			expectStatusSet: true,
+		},
+		"requeueAfter does not stop the chain": {
+			// e.g. waiting for a maintenance window or the next version lookup,
+			// later reconcilers still report the status of the current deployment.
+			results: []ctrl.Result{
+				{RequeueAfter: 10 * time.Minute},
+				{RequeueAfter: 5 * time.Minute},
+				{},
+			},
+			expectedCalls:   3,
+			expectedResult:  ctrl.Result{RequeueAfter: 5 * time.Minute},
+			expectStatusSet: true,
+		},
+		"requeue stops the chain": {
+			results: []ctrl.Result{
+				{RequeueAfter: 10 * time.Minute},
+				{Requeue: true},
+				{},
+			},
+			expectedCalls:   2,
+			expectedResult:  ctrl.Result{Requeue: true},
+			expectStatusSet: true,
+		},
+		"error stops the chain": {
+			results:       []ctrl.Result{{}, {}, {}},
+			errs:          []error{nil, errTest, nil},
+			expectedCalls: 2,
+			expectedErr:   errTest,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			c.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			c.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			mocks := make([]*reconcilerMock, len(tc.results))
+			reconcilers := make([]reconciler, len(tc.results))
+			for i, res := range tc.results {
+				var err error
+				if len(tc.errs) > i {
+					err = tc.errs[i]
+				}
+				mocks[i] = &reconcilerMock{}
+				mocks[i].On("Reconcile", mock.Anything, mock.Anything).Return(res, err)
+				reconcilers[i] = mocks[i]
+			}
+
+			controller := &GenericPackageController{
+				newPackage: adapters.NewGenericPackage,
+				client:     c,
+				log:        testr.New(t),
+				scheme:     testScheme,
+				reconciler: reconcilers,
+			}
+
+			res, err := controller.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: "test", Namespace: "test"},
+			})
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedResult, res)
+
+			for i, m := range mocks {
+				if i < tc.expectedCalls {
+					m.AssertCalled(t, "Reconcile", mock.Anything, mock.Anything)
+				} else {
+					m.AssertNotCalled(t, "Reconcile", mock.Anything, mock.Anything)
+				}
+			}
+			if tc.expectStatusSet {
+				c.StatusMock.AssertCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				c.StatusMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
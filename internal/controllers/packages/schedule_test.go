@@ -0,0 +1,111 @@
+package packages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronSchedule_next(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday.
+	from := time.Date(2024, time.January, 10, 10, 30, 15, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		schedule string
+		expected time.Time
+	}{
+		"every minute": {
+			schedule: "* * * * *",
+			expected: time.Date(2024, time.January, 10, 10, 31, 0, 0, time.UTC),
+		},
+		"step": {
+			schedule: "*/15 * * * *",
+			expected: time.Date(2024, time.January, 10, 10, 45, 0, 0, time.UTC),
+		},
+		"next day": {
+			schedule: "0 2 * * *",
+			expected: time.Date(2024, time.January, 11, 2, 0, 0, 0, time.UTC),
+		},
+		"weekday name": {
+			schedule: "0 2 * * SAT",
+			expected: time.Date(2024, time.January, 13, 2, 0, 0, 0, time.UTC),
+		},
+		"sunday as 7": {
+			schedule: "0 0 * * 7",
+			expected: time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC),
+		},
+		"range and list": {
+			schedule: "0 9-17 * * 1-5,6",
+			expected: time.Date(2024, time.January, 10, 11, 0, 0, 0, time.UTC),
+		},
+		"month name": {
+			schedule: "0 0 1 mar *",
+			expected: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"leap day": {
+			schedule: "0 0 29 2 *",
+			expected: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"day of month or week": {
+			schedule: "0 0 20 * MON",
+			expected: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			schedule: "0 0 31 2 *",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := parseCronSchedule(tc.schedule)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, s.next(from))
+		})
+	}
+}
+
+func TestCronSchedule_next_timeZone(t *testing.T) {
+	t.Parallel()
+
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	s, err := parseCronSchedule("0 2 * * *")
+	require.NoError(t, err)
+
+	from := time.Date(2024, time.March, 29, 12, 0, 0, 0, loc)
+	next := s.next(from)
+	assert.Equal(t, time.Date(2024, time.March, 30, 2, 0, 0, 0, loc), next)
+
+	// 02:00 does not exist on the day clocks are set forward.
+	next = s.next(next)
+	assert.Equal(t, time.Date(2024, time.April, 1, 2, 0, 0, 0, loc), next)
+}
+
+func TestParseCronSchedule_invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, schedule := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"banana * * * *",
+	} {
+		t.Run(schedule, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseCronSchedule(schedule)
+			require.ErrorIs(t, err, ErrInvalidSchedule)
+		})
+	}
+}
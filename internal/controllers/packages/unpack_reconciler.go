@@ -2,10 +2,12 @@ package packages
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/flowcontrol"
@@ -19,6 +21,7 @@ import (
 	"package-operator.run/internal/environment"
 	"package-operator.run/internal/metrics"
 	"package-operator.run/internal/packages"
+	"package-operator.run/internal/utils"
 )
 
 // Loads/unpack and templates packages into an ObjectDeployment.
@@ -26,6 +29,7 @@ type unpackReconciler struct {
 	*environment.Sink
 
 	uncachedClient client.Client
+	dynamicCache   dynamicCache
 
 	imagePuller         imagePuller
 	packageDeployer     packageDeployer
@@ -33,6 +37,9 @@ type unpackReconciler struct {
 
 	backoff             *flowcontrol.Backoff
 	packageHashModifier *int32
+
+	managerNamespace        string
+	defaultImagePullSecrets []string
 }
 
 type packageLoadRecorder interface {
@@ -43,11 +50,12 @@ type packageLoadRecorder interface {
 func newUnpackReconciler(
 	c client.Client,
 	uncachedClient client.Client,
+	dynamicCache dynamicCache,
 	imagePuller imagePuller,
 	packageDeployer packageDeployer,
 	packageLoadRecorder packageLoadRecorder,
 	packageHashModifier *int32,
-	opts ...unpackReconcilerOption,
+	opts ...UnpackReconcilerOption,
 ) *unpackReconciler {
 	var cfg unpackReconcilerConfig
 
@@ -58,16 +66,19 @@ func newUnpackReconciler(
 		environment.NewSink(c),
 
 		uncachedClient,
+		dynamicCache,
 		imagePuller,
 		packageDeployer,
 		packageLoadRecorder,
 		cfg.GetBackoff(),
 		packageHashModifier,
+		cfg.ManagerNamespace,
+		cfg.DefaultImagePullSecrets,
 	}
 }
 
 type imagePuller interface {
-	Pull(ctx context.Context, image string) (*packages.RawPackage, error)
+	Pull(ctx context.Context, image string, opts ...packages.PullOption) (*packages.RawPackage, error)
 }
 
 type packageDeployer interface {
@@ -76,6 +87,7 @@ type packageDeployer interface {
 		apiPkg adapters.GenericPackageAccessor,
 		rawPkg *packages.RawPackage,
 		env manifests.PackageEnvironment,
+		opts ...packages.DeployOption,
 	) error
 }
 
@@ -85,21 +97,58 @@ func (r *unpackReconciler) Reconcile(
 	// run back off garbage collection to prevent stale data building up.
 	defer r.backoff.GC()
 
+	configFrom, err := r.resolveConfigFrom(ctx, pkg)
+	var configFromErr *ConfigFromError
+	if errors.As(err, &configFromErr) {
+		// Force a new deployment as soon as the config sources are fixed.
+		pkg.SetUnpackedHash("")
+		meta.SetStatusCondition(
+			pkg.GetConditions(), metav1.Condition{
+				Type:               corev1alpha1.PackageUnpacked,
+				Status:             metav1.ConditionFalse,
+				Reason:             "ConfigFromInvalid",
+				Message:            err.Error(),
+				ObservedGeneration: pkg.ClientObject().GetGeneration(),
+			})
+		return res, nil
+	}
+	if err != nil {
+		return res, fmt.Errorf("resolving config from: %w", err)
+	}
+
 	specHash := pkg.GetSpecHash(r.packageHashModifier)
+	if configFrom != nil {
+		// Re-deploy when referenced ConfigMaps or Secrets change.
+		specHash = utils.ComputeSHA256Hash([]any{specHash, configFrom}, nil)
+	}
 	if pkg.GetUnpackedHash() == specHash {
 		// We have already unpacked this package \o/
+		clearUpgradePending(pkg)
+		return res, nil
+	}
+	if len(pkg.GetImage()) == 0 {
+		// The version of tracked packages has not been resolved yet,
+		// reported via the VersionResolved condition.
+		return res, nil
+	}
+	if proceed, res := checkUpgradePolicy(pkg, specHash, time.Now()); !proceed {
 		return res, nil
 	}
 
 	pullStart := time.Now()
 	log := logr.FromContextOrDiscard(ctx)
-	rawPkg, err := r.imagePuller.Pull(ctx, pkg.GetImage())
+	rawPkg, err := r.pull(ctx, pkg)
 	if err != nil {
+		reason := "ImagePullBackOff"
+		var verifyErr *packages.SignatureVerificationError
+		if errors.As(err, &verifyErr) {
+			reason = "SignatureInvalid"
+		}
 		meta.SetStatusCondition(
 			pkg.GetConditions(), metav1.Condition{
 				Type:               corev1alpha1.PackageUnpacked,
 				Status:             metav1.ConditionFalse,
-				Reason:             "ImagePullBackOff",
+				Reason:             reason,
 				Message:            err.Error(),
 				ObservedGeneration: pkg.ClientObject().GetGeneration(),
 			})
@@ -114,7 +163,9 @@ func (r *unpackReconciler) Reconcile(
 	}
 
 	env, err := r.GetEnvironment(ctx, pkg.ClientObject().GetNamespace())
-	if err := r.packageDeployer.Deploy(ctx, pkg, rawPkg, *env); err != nil {
+	if err := r.packageDeployer.Deploy(
+		ctx, pkg, rawPkg, *env, packages.WithConfigFrom(configFrom),
+	); err != nil {
 		return res, fmt.Errorf("deploying package: %w", err)
 	}
 
@@ -123,6 +174,7 @@ func (r *unpackReconciler) Reconcile(
 			pkg, time.Since(pullStart))
 	}
 	pkg.SetUnpackedHash(specHash)
+	clearUpgradePending(pkg)
 	meta.SetStatusCondition(
 		pkg.GetConditions(), metav1.Condition{
 			Type:               corev1alpha1.PackageUnpacked,
@@ -135,11 +187,66 @@ func (r *unpackReconciler) Reconcile(
 	return
 }
 
+// Pulls the package image with the image pull secrets of the package.
+func (r *unpackReconciler) pull(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) (*packages.RawPackage, error) {
+	secrets, err := r.getImagePullSecrets(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []packages.PullOption
+	if len(secrets) > 0 {
+		opts = append(opts, packages.WithPullSecrets(secrets))
+	}
+	return r.imagePuller.Pull(ctx, pkg.GetImage(), opts...)
+}
+
+// Looks up the image pull secrets referenced by the package
+// or the default image pull secrets, if the package does not reference any.
+// Secrets are read with the uncached client, to not cache all Secrets of the cluster.
+func (r *unpackReconciler) getImagePullSecrets(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) ([]corev1.Secret, error) {
+	namespace := pkg.ClientObject().GetNamespace()
+	if len(namespace) == 0 {
+		namespace = r.managerNamespace
+	}
+
+	names := make([]string, 0, len(pkg.GetImagePullSecrets()))
+	for _, ref := range pkg.GetImagePullSecrets() {
+		names = append(names, ref.Name)
+	}
+	if len(names) == 0 {
+		namespace = r.managerNamespace
+		names = r.defaultImagePullSecrets
+	}
+
+	secrets := make([]corev1.Secret, 0, len(names))
+	for _, name := range names {
+		secret := corev1.Secret{}
+		if err := r.uncachedClient.Get(ctx, client.ObjectKey{
+			Name: name, Namespace: namespace,
+		}, &secret); err != nil {
+			return nil, fmt.Errorf("getting image pull secret %s/%s: %w", namespace, name, err)
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
 type unpackReconcilerConfig struct {
 	controllers.BackoffConfig
+
+	// Namespace Package Operator is running in.
+	// Image pull secrets of ClusterPackages and default image pull secrets are looked up here.
+	ManagerNamespace string
+	// Names of image pull secrets used for packages not referencing any.
+	DefaultImagePullSecrets []string
 }
 
-func (c *unpackReconcilerConfig) Option(opts ...unpackReconcilerOption) {
+func (c *unpackReconcilerConfig) Option(opts ...UnpackReconcilerOption) {
 	for _, opt := range opts {
 		opt.ConfigureUnpackReconciler(c)
 	}
@@ -149,6 +256,20 @@ func (c *unpackReconcilerConfig) Default() {
 	c.BackoffConfig.Default()
 }
 
-type unpackReconcilerOption interface {
+type UnpackReconcilerOption interface {
 	ConfigureUnpackReconciler(c *unpackReconcilerConfig)
 }
+
+// Configures where and which image pull secrets are looked up.
+type WithImagePullSecrets struct {
+	// Namespace Package Operator is running in.
+	ManagerNamespace string
+	// Names of Secrets in the manager namespace
+	// used for packages not referencing any image pull secrets.
+	Defaults []string
+}
+
+func (w WithImagePullSecrets) ConfigureUnpackReconciler(c *unpackReconcilerConfig) {
+	c.ManagerNamespace = w.ManagerNamespace
+	c.DefaultImagePullSecrets = w.Defaults
+}
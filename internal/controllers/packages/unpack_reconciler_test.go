@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/adapters"
@@ -16,6 +18,7 @@ import (
 	"package-operator.run/internal/controllers"
 	"package-operator.run/internal/packages"
 	"package-operator.run/internal/testutil"
+	"package-operator.run/internal/testutil/dynamiccachemocks"
 )
 
 func TestUnpackReconciler(t *testing.T) {
@@ -25,13 +28,13 @@ func TestUnpackReconciler(t *testing.T) {
 
 	ipm := &imagePullerMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(c, uc, ipm, pd, nil, nil)
+	ur := newUnpackReconciler(c, uc, &dynamiccachemocks.DynamicCacheMock{}, ipm, pd, nil, nil)
 
 	const image = "test123:latest"
 
 	rawPkg := &packages.RawPackage{}
 	ipm.
-		On("Pull", mock.Anything, mock.Anything).
+		On("Pull", mock.Anything, mock.Anything, mock.Anything).
 		Return(rawPkg, nil)
 	pd.
 		On("Deploy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -67,7 +70,7 @@ func TestUnpackReconciler_noop(t *testing.T) {
 
 	ipm := &imagePullerMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(c, uc, ipm, pd, nil, nil)
+	ur := newUnpackReconciler(c, uc, &dynamiccachemocks.DynamicCacheMock{}, ipm, pd, nil, nil)
 
 	const image = "test123:latest"
 
@@ -94,13 +97,13 @@ func TestUnpackReconciler_pullBackoff(t *testing.T) {
 
 	ipm := &imagePullerMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(c, uc, ipm, pd, nil, nil)
+	ur := newUnpackReconciler(c, uc, &dynamiccachemocks.DynamicCacheMock{}, ipm, pd, nil, nil)
 
 	const image = "test123:latest"
 
 	rawPkg := &packages.RawPackage{}
 	ipm.
-		On("Pull", mock.Anything, mock.Anything).
+		On("Pull", mock.Anything, mock.Anything, mock.Anything).
 		Return(rawPkg, errTest)
 
 	pkg := &adapters.GenericPackage{
@@ -121,14 +124,179 @@ func TestUnpackReconciler_pullBackoff(t *testing.T) {
 			corev1alpha1.PackageUnpacked))
 }
 
+func TestUnpackReconciler_signatureInvalid(t *testing.T) {
+	t.Parallel()
+	c := testutil.NewClient()
+	uc := testutil.NewClient()
+
+	ipm := &imagePullerMock{}
+	pd := &packageDeployerMock{}
+	ur := newUnpackReconciler(c, uc, &dynamiccachemocks.DynamicCacheMock{}, ipm, pd, nil, nil)
+
+	const image = "test123:latest"
+
+	ipm.
+		On("Pull", mock.Anything, mock.Anything, mock.Anything).
+		Return((*packages.RawPackage)(nil), &packages.SignatureVerificationError{
+			Image: image, Reason: "no signatures found",
+		})
+
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			Spec: corev1alpha1.PackageSpec{
+				Image: image,
+			},
+		},
+	}
+
+	ctx := context.Background()
+	res, err := ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	assert.Equal(t, controllers.DefaultInitialBackoff, res.RequeueAfter)
+
+	cond := meta.FindStatusCondition(*pkg.GetConditions(), corev1alpha1.PackageUnpacked)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "SignatureInvalid", cond.Reason)
+}
+
+func TestUnpackReconciler_upgradeApproval(t *testing.T) {
+	t.Parallel()
+	c := testutil.NewClient()
+	uc := testutil.NewClient()
+
+	ipm := &imagePullerMock{}
+	pd := &packageDeployerMock{}
+	ur := newUnpackReconciler(c, uc, &dynamiccachemocks.DynamicCacheMock{}, ipm, pd, nil, nil)
+
+	ipm.
+		On("Pull", mock.Anything, mock.Anything, mock.Anything).
+		Return(&packages.RawPackage{}, nil)
+	pd.
+		On("Deploy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	ur.SetEnvironment(&manifests.PackageEnvironment{})
+
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			Spec: corev1alpha1.PackageSpec{
+				Image: "test123:v2",
+				UpgradePolicy: &corev1alpha1.PackageUpgradePolicy{
+					Approval: corev1alpha1.PackageUpgradeApprovalManual,
+				},
+			},
+			Status: corev1alpha1.PackageStatus{
+				UnpackedHash: "v1",
+				Revision:     1,
+			},
+		},
+	}
+
+	ctx := context.Background()
+	res, err := ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	ipm.AssertNotCalled(t, "Pull", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, pkg.GetSpecHash(nil), pkg.Status.PendingHash)
+	assert.True(t, meta.IsStatusConditionTrue(pkg.Status.Conditions, corev1alpha1.PackageUpgradePending))
+
+	pkg.Annotations = map[string]string{
+		corev1alpha1.PackageApprovedHashAnnotation: pkg.Status.PendingHash,
+	}
+	res, err = ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	pd.AssertCalled(t, "Deploy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, pkg.GetSpecHash(nil), pkg.Status.UnpackedHash)
+	assert.Empty(t, pkg.Status.PendingHash)
+	assert.Nil(t, meta.FindStatusCondition(pkg.Status.Conditions, corev1alpha1.PackageUpgradePending))
+}
+
+func TestUnpackReconciler_imagePullSecrets(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		pkg               adapters.GenericPackageAccessor
+		expectedSecretKey client.ObjectKey
+	}{
+		"package secret": {
+			pkg: &adapters.GenericPackage{
+				Package: corev1alpha1.Package{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "tenant"},
+					Spec: corev1alpha1.PackageSpec{
+						Image:            "test123:latest",
+						ImagePullSecrets: []corev1alpha1.ImagePullSecretReference{{Name: "creds"}},
+					},
+				},
+			},
+			expectedSecretKey: client.ObjectKey{Name: "creds", Namespace: "tenant"},
+		},
+		"cluster package secret": {
+			pkg: &adapters.GenericClusterPackage{
+				ClusterPackage: corev1alpha1.ClusterPackage{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: corev1alpha1.PackageSpec{
+						Image:            "test123:latest",
+						ImagePullSecrets: []corev1alpha1.ImagePullSecretReference{{Name: "creds"}},
+					},
+				},
+			},
+			expectedSecretKey: client.ObjectKey{Name: "creds", Namespace: "pko"},
+		},
+		"default secret": {
+			pkg: &adapters.GenericPackage{
+				Package: corev1alpha1.Package{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "tenant"},
+					Spec: corev1alpha1.PackageSpec{
+						Image: "test123:latest",
+					},
+				},
+			},
+			expectedSecretKey: client.ObjectKey{Name: "default", Namespace: "pko"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			uc := testutil.NewClient()
+			uc.
+				On("Get", mock.Anything, tc.expectedSecretKey, mock.Anything, mock.Anything).
+				Return(nil)
+
+			ipm := &imagePullerMock{}
+			pd := &packageDeployerMock{}
+			ur := newUnpackReconciler(c, uc, &dynamiccachemocks.DynamicCacheMock{}, ipm, pd, nil, nil, WithImagePullSecrets{
+				ManagerNamespace: "pko",
+				Defaults:         []string{"default"},
+			})
+
+			ipm.
+				On("Pull", mock.Anything, mock.Anything, mock.Anything).
+				Return(&packages.RawPackage{}, nil)
+			pd.
+				On("Deploy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+			ur.SetEnvironment(&manifests.PackageEnvironment{})
+
+			_, err := ur.Reconcile(context.Background(), tc.pkg)
+			require.NoError(t, err)
+
+			uc.AssertCalled(t, "Get", mock.Anything, tc.expectedSecretKey, mock.Anything, mock.Anything)
+			ipm.AssertCalled(t, "Pull", mock.Anything, "test123:latest",
+				mock.MatchedBy(func(opts []packages.PullOption) bool { return len(opts) == 1 }))
+		})
+	}
+}
+
 type imagePullerMock struct {
 	mock.Mock
 }
 
 func (m *imagePullerMock) Pull(
-	ctx context.Context, image string,
+	ctx context.Context, image string, opts ...packages.PullOption,
 ) (*packages.RawPackage, error) {
-	args := m.Called(ctx, image)
+	args := m.Called(ctx, image, opts)
 	return args.Get(0).(*packages.RawPackage), args.Error(1)
 }
 
@@ -141,7 +309,8 @@ func (m *packageDeployerMock) Deploy(
 	apiPkg adapters.GenericPackageAccessor,
 	rawPkg *packages.RawPackage,
 	env manifests.PackageEnvironment,
+	opts ...packages.DeployOption,
 ) error {
-	args := m.Called(ctx, apiPkg, rawPkg, env)
+	args := m.Called(ctx, apiPkg, rawPkg, env, opts)
 	return args.Error(0)
 }
@@ -0,0 +1,113 @@
+package packages
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+)
+
+// UpgradePending condition reasons.
+const (
+	upgradeAwaitingApprovalReason         = "AwaitingApproval"
+	upgradeOutsideMaintenanceWindowReason = "OutsideMaintenanceWindow"
+	upgradeInvalidPolicyReason            = "InvalidUpgradePolicy"
+)
+
+// Checks whether the upgrade policy of the package allows
+// rolling out the change identified by specHash at the given time.
+// Changes that are held back are reported via .status.pendingHash and the UpgradePending condition.
+func checkUpgradePolicy(
+	pkg adapters.GenericPackageAccessor, specHash string, now time.Time,
+) (proceed bool, res ctrl.Result) {
+	policy := pkg.GetUpgradePolicy()
+	if policy == nil || isInitialInstall(pkg) {
+		return true, res
+	}
+
+	if policy.Approval == corev1alpha1.PackageUpgradeApprovalManual &&
+		pkg.ClientObject().GetAnnotations()[corev1alpha1.PackageApprovedHashAnnotation] != specHash {
+		setUpgradePending(pkg, specHash, upgradeAwaitingApprovalReason,
+			fmt.Sprintf("Change %s requires approval.", specHash))
+		return false, res
+	}
+
+	if len(policy.MaintenanceWindows) == 0 {
+		return true, res
+	}
+	open, nextOpen, err := maintenanceWindowOpen(policy.MaintenanceWindows, now)
+	if err != nil {
+		setUpgradePending(pkg, specHash, upgradeInvalidPolicyReason, err.Error())
+		return false, res
+	}
+	if open {
+		return true, res
+	}
+
+	msg := "No upcoming maintenance window."
+	if !nextOpen.IsZero() {
+		msg = fmt.Sprintf("Waiting for the next maintenance window at %s.", nextOpen.UTC().Format(time.RFC3339))
+		res.RequeueAfter = nextOpen.Sub(now)
+	}
+	setUpgradePending(pkg, specHash, upgradeOutsideMaintenanceWindowReason, msg)
+	return false, res
+}
+
+// The first installation of a package is never held back.
+func isInitialInstall(pkg adapters.GenericPackageAccessor) bool {
+	return len(pkg.GetUnpackedHash()) == 0 && pkg.GetStatusRevision() == 0
+}
+
+func setUpgradePending(pkg adapters.GenericPackageAccessor, specHash, reason, msg string) {
+	pkg.SetPendingHash(specHash)
+	meta.SetStatusCondition(
+		pkg.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.PackageUpgradePending,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            msg,
+			ObservedGeneration: pkg.ClientObject().GetGeneration(),
+		})
+}
+
+func clearUpgradePending(pkg adapters.GenericPackageAccessor) {
+	pkg.SetPendingHash("")
+	meta.RemoveStatusCondition(pkg.GetConditions(), corev1alpha1.PackageUpgradePending)
+}
+
+// Returns true, if any of the given maintenance windows is open at the given time.
+// Otherwise returns when the next window opens,
+// or the zero time if none of the windows opens within the schedule search limit.
+func maintenanceWindowOpen(
+	windows []corev1alpha1.PackageMaintenanceWindow, now time.Time,
+) (open bool, nextOpen time.Time, err error) {
+	for _, w := range windows {
+		schedule, err := parseCronSchedule(w.Schedule)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		loc := time.UTC
+		if len(w.TimeZone) > 0 {
+			if loc, err = time.LoadLocation(w.TimeZone); err != nil {
+				return false, time.Time{}, fmt.Errorf("invalid time zone %q: %w", w.TimeZone, err)
+			}
+		}
+
+		// First window start that has not ended yet.
+		start := schedule.next(now.In(loc).Add(-w.Duration.Duration))
+		switch {
+		case start.IsZero():
+			continue
+		case !start.After(now):
+			return true, time.Time{}, nil
+		case nextOpen.IsZero() || start.Before(nextOpen):
+			nextOpen = start
+		}
+	}
+	return false, nextOpen, nil
+}
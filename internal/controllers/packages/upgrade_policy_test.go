@@ -0,0 +1,162 @@
+package packages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+)
+
+func TestCheckUpgradePolicy(t *testing.T) {
+	t.Parallel()
+
+	// Saturday.
+	now := time.Date(2024, time.January, 13, 12, 0, 0, 0, time.UTC)
+	saturdayNight := corev1alpha1.PackageMaintenanceWindow{
+		Schedule: "0 2 * * SAT",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+	}
+	saturdayNoon := corev1alpha1.PackageMaintenanceWindow{
+		Schedule: "0 11 * * SAT",
+		Duration: metav1.Duration{Duration: 2 * time.Hour},
+	}
+
+	for name, tc := range map[string]struct {
+		policy               *corev1alpha1.PackageUpgradePolicy
+		initialInstall       bool
+		approvedHash         string
+		expectedProceed      bool
+		expectedRequeueAfter time.Duration
+		expectedReason       string
+	}{
+		"no policy": {
+			expectedProceed: true,
+		},
+		"automatic": {
+			policy: &corev1alpha1.PackageUpgradePolicy{
+				Approval: corev1alpha1.PackageUpgradeApprovalAutomatic,
+			},
+			expectedProceed: true,
+		},
+		"initial install": {
+			policy: &corev1alpha1.PackageUpgradePolicy{
+				Approval:           corev1alpha1.PackageUpgradeApprovalManual,
+				MaintenanceWindows: []corev1alpha1.PackageMaintenanceWindow{saturdayNight},
+			},
+			initialInstall:  true,
+			expectedProceed: true,
+		},
+		"awaiting approval": {
+			policy: &corev1alpha1.PackageUpgradePolicy{
+				Approval: corev1alpha1.PackageUpgradeApprovalManual,
+			},
+			approvedHash:   "old",
+			expectedReason: upgradeAwaitingApprovalReason,
+		},
+		"approved": {
+			policy: &corev1alpha1.PackageUpgradePolicy{
+				Approval: corev1alpha1.PackageUpgradeApprovalManual,
+			},
+			approvedHash:    "new",
+			expectedProceed: true,
+		},
+		"approved outside maintenance window": {
+			policy: &corev1alpha1.PackageUpgradePolicy{
+				Approval:           corev1alpha1.PackageUpgradeApprovalManual,
+				MaintenanceWindows: []corev1alpha1.PackageMaintenanceWindow{saturdayNight},
+			},
+			approvedHash:         "new",
+			expectedRequeueAfter: 7*24*time.Hour - 10*time.Hour,
+			expectedReason:       upgradeOutsideMaintenanceWindowReason,
+		},
+		"inside maintenance window": {
+			policy: &corev1alpha1.PackageUpgradePolicy{
+				MaintenanceWindows: []corev1alpha1.PackageMaintenanceWindow{saturdayNight, saturdayNoon},
+			},
+			expectedProceed: true,
+		},
+		"time zone": {
+			policy: &corev1alpha1.PackageUpgradePolicy{
+				MaintenanceWindows: []corev1alpha1.PackageMaintenanceWindow{
+					{
+						Schedule: "0 20 * * FRI",
+						Duration: metav1.Duration{Duration: time.Hour},
+						TimeZone: "America/Los_Angeles",
+					},
+				},
+			},
+			expectedProceed: false,
+			// Friday 20:00 PST is Saturday 04:00 UTC.
+			expectedRequeueAfter: 7*24*time.Hour - 8*time.Hour,
+			expectedReason:       upgradeOutsideMaintenanceWindowReason,
+		},
+		"invalid schedule": {
+			policy: &corev1alpha1.PackageUpgradePolicy{
+				MaintenanceWindows: []corev1alpha1.PackageMaintenanceWindow{
+					{Schedule: "banana", Duration: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			expectedReason: upgradeInvalidPolicyReason,
+		},
+		"invalid time zone": {
+			policy: &corev1alpha1.PackageUpgradePolicy{
+				MaintenanceWindows: []corev1alpha1.PackageMaintenanceWindow{
+					{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Banana/Town"},
+				},
+			},
+			expectedReason: upgradeInvalidPolicyReason,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := &adapters.GenericPackage{
+				Package: corev1alpha1.Package{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							corev1alpha1.PackageApprovedHashAnnotation: tc.approvedHash,
+						},
+					},
+					Spec: corev1alpha1.PackageSpec{UpgradePolicy: tc.policy},
+				},
+			}
+			if !tc.initialInstall {
+				pkg.Status.UnpackedHash = "old"
+				pkg.Status.Revision = 1
+			}
+
+			proceed, res := checkUpgradePolicy(pkg, "new", now)
+			assert.Equal(t, tc.expectedProceed, proceed)
+			assert.Equal(t, tc.expectedRequeueAfter, res.RequeueAfter)
+
+			cond := meta.FindStatusCondition(pkg.Status.Conditions, corev1alpha1.PackageUpgradePending)
+			if len(tc.expectedReason) == 0 {
+				assert.Nil(t, cond)
+				assert.Empty(t, pkg.Status.PendingHash)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionTrue, cond.Status)
+			assert.Equal(t, tc.expectedReason, cond.Reason)
+			assert.Equal(t, "new", pkg.Status.PendingHash)
+		})
+	}
+}
+
+func TestClearUpgradePending(t *testing.T) {
+	t.Parallel()
+
+	pkg := &adapters.GenericPackage{}
+	setUpgradePending(pkg, "new", upgradeAwaitingApprovalReason, "")
+	require.True(t, meta.IsStatusConditionTrue(pkg.Status.Conditions, corev1alpha1.PackageUpgradePending))
+
+	clearUpgradePending(pkg)
+	assert.Empty(t, pkg.Status.PendingHash)
+	assert.Nil(t, meta.FindStatusCondition(pkg.Status.Conditions, corev1alpha1.PackageUpgradePending))
+}
@@ -0,0 +1,122 @@
+package packages
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/crane"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/packages"
+)
+
+// Interval in which repositories of tracked packages are checked for new versions by default.
+const defaultTrackInterval = 10 * time.Minute
+
+// Resolves the version of packages tracked from a package repository.
+type versionTrackingReconciler struct {
+	resolver        versionResolver
+	imagePullSecret imagePullSecretGetter
+	clock           func() time.Time
+}
+
+type versionResolver interface {
+	Resolve(
+		ctx context.Context, repository, pkgName, versionRange string, opts ...crane.Option,
+	) (packages.ResolvedVersion, error)
+}
+
+type imagePullSecretGetter func(
+	ctx context.Context, pkg adapters.GenericPackageAccessor) ([]corev1.Secret, error)
+
+func (r *versionTrackingReconciler) Reconcile(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) (res ctrl.Result, err error) {
+	track := pkg.GetTrack()
+	if track == nil {
+		pkg.SetTrackStatus(nil)
+		meta.RemoveStatusCondition(pkg.GetConditions(), corev1alpha1.PackageVersionResolved)
+		return res, nil
+	}
+
+	interval := defaultTrackInterval
+	if track.Interval != nil && track.Interval.Duration > 0 {
+		interval = track.Interval.Duration
+	}
+	res.RequeueAfter = interval
+
+	now := r.clock()
+	cond := meta.FindStatusCondition(*pkg.GetConditions(), corev1alpha1.PackageVersionResolved)
+	if status := pkg.GetTrackStatus(); status != nil && status.LastCheckTime != nil &&
+		cond != nil && cond.Status == metav1.ConditionTrue &&
+		cond.ObservedGeneration == pkg.ClientObject().GetGeneration() {
+		// Don't hit the registry on every reconcile,
+		// unless the tracked package changed.
+		if next := status.LastCheckTime.Add(interval); now.Before(next) {
+			res.RequeueAfter = next.Sub(now)
+			return res, nil
+		}
+	}
+
+	opts, err := r.craneOptions(ctx, pkg)
+	if err != nil {
+		return res, err
+	}
+
+	resolved, err := r.resolver.Resolve(ctx, track.Repository, track.Package, track.Range, opts...)
+	if err != nil {
+		// Keep installing the last resolved version.
+		meta.SetStatusCondition(
+			pkg.GetConditions(), metav1.Condition{
+				Type:               corev1alpha1.PackageVersionResolved,
+				Status:             metav1.ConditionFalse,
+				Reason:             "ResolveFailed",
+				Message:            err.Error(),
+				ObservedGeneration: pkg.ClientObject().GetGeneration(),
+			})
+		return res, nil
+	}
+
+	pkg.SetTrackStatus(&corev1alpha1.PackageTrackStatus{
+		Version:       resolved.Version,
+		Image:         resolved.Image,
+		LastCheckTime: &metav1.Time{Time: now},
+	})
+	meta.SetStatusCondition(
+		pkg.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.PackageVersionResolved,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Resolved",
+			Message:            fmt.Sprintf("Resolved version %s.", resolved.Version),
+			ObservedGeneration: pkg.ClientObject().GetGeneration(),
+		})
+	return res, nil
+}
+
+// Repository images are pulled with the same credentials as the package image.
+func (r *versionTrackingReconciler) craneOptions(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) ([]crane.Option, error) {
+	secrets, err := r.imagePullSecret(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+
+	keychain, err := packages.NewSecretKeychain(secrets...)
+	if err != nil {
+		return nil, fmt.Errorf("loading image pull secrets: %w", err)
+	}
+	return []crane.Option{
+		crane.WithAuthFromKeychain(authn.NewMultiKeychain(keychain, authn.DefaultKeychain)),
+	}, nil
+}
@@ -0,0 +1,158 @@
+package packages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/packages"
+)
+
+func TestVersionTrackingReconciler(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	track := &corev1alpha1.PackageTrack{
+		Repository: "quay.io/repo:latest",
+		Package:    "pkg",
+		Range:      "~1.2",
+	}
+	resolvedCond := metav1.Condition{
+		Type:               corev1alpha1.PackageVersionResolved,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: 1,
+	}
+
+	for name, tc := range map[string]struct {
+		track                *corev1alpha1.PackageTrack
+		status               corev1alpha1.PackageStatus
+		resolveErr           error
+		expectResolve        bool
+		expectedImage        string
+		expectedCondition    metav1.ConditionStatus
+		expectedRequeueAfter time.Duration
+	}{
+		"not tracked": {
+			status: corev1alpha1.PackageStatus{
+				Track:      &corev1alpha1.PackageTrackStatus{Image: "old"},
+				Conditions: []metav1.Condition{resolvedCond},
+			},
+		},
+		"resolve": {
+			track:                track,
+			expectResolve:        true,
+			expectedImage:        "quay.io/pkg@sha256:2",
+			expectedCondition:    metav1.ConditionTrue,
+			expectedRequeueAfter: defaultTrackInterval,
+		},
+		"recently checked": {
+			track: track,
+			status: corev1alpha1.PackageStatus{
+				Track: &corev1alpha1.PackageTrackStatus{
+					Image:         "quay.io/pkg@sha256:1",
+					LastCheckTime: &metav1.Time{Time: now.Add(-4 * time.Minute)},
+				},
+				Conditions: []metav1.Condition{resolvedCond},
+			},
+			expectedImage:        "quay.io/pkg@sha256:1",
+			expectedCondition:    metav1.ConditionTrue,
+			expectedRequeueAfter: 6 * time.Minute,
+		},
+		"check interval passed": {
+			track: track,
+			status: corev1alpha1.PackageStatus{
+				Track: &corev1alpha1.PackageTrackStatus{
+					Image:         "quay.io/pkg@sha256:1",
+					LastCheckTime: &metav1.Time{Time: now.Add(-11 * time.Minute)},
+				},
+				Conditions: []metav1.Condition{resolvedCond},
+			},
+			expectResolve:        true,
+			expectedImage:        "quay.io/pkg@sha256:2",
+			expectedCondition:    metav1.ConditionTrue,
+			expectedRequeueAfter: defaultTrackInterval,
+		},
+		"resolve failed": {
+			track: track,
+			status: corev1alpha1.PackageStatus{
+				Track: &corev1alpha1.PackageTrackStatus{
+					Image:         "quay.io/pkg@sha256:1",
+					LastCheckTime: &metav1.Time{Time: now.Add(-time.Hour)},
+				},
+			},
+			resolveErr:           errTest,
+			expectResolve:        true,
+			expectedImage:        "quay.io/pkg@sha256:1",
+			expectedCondition:    metav1.ConditionFalse,
+			expectedRequeueAfter: defaultTrackInterval,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resolver := &versionResolverMock{}
+			resolver.
+				On("Resolve", mock.Anything, track.Repository, track.Package, track.Range, mock.Anything).
+				Return(packages.ResolvedVersion{Version: "1.2.1", Image: "quay.io/pkg@sha256:2"}, tc.resolveErr)
+
+			r := &versionTrackingReconciler{
+				resolver: resolver,
+				imagePullSecret: func(context.Context, adapters.GenericPackageAccessor) ([]corev1.Secret, error) {
+					return nil, nil
+				},
+				clock: func() time.Time { return now },
+			}
+
+			pkg := &adapters.GenericPackage{
+				Package: corev1alpha1.Package{
+					ObjectMeta: metav1.ObjectMeta{Generation: 1},
+					Spec:       corev1alpha1.PackageSpec{Track: tc.track},
+					Status:     tc.status,
+				},
+			}
+
+			res, err := r.Reconcile(context.Background(), pkg)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRequeueAfter, res.RequeueAfter)
+			assert.Equal(t, tc.expectedImage, pkg.GetImage())
+
+			if tc.expectResolve {
+				resolver.AssertCalled(t, "Resolve",
+					mock.Anything, track.Repository, track.Package, track.Range, mock.Anything)
+			} else {
+				resolver.AssertNotCalled(t, "Resolve",
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			cond := meta.FindStatusCondition(pkg.Status.Conditions, corev1alpha1.PackageVersionResolved)
+			if len(tc.expectedCondition) == 0 {
+				assert.Nil(t, cond)
+				assert.Nil(t, pkg.Status.Track)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, tc.expectedCondition, cond.Status)
+		})
+	}
+}
+
+type versionResolverMock struct {
+	mock.Mock
+}
+
+func (m *versionResolverMock) Resolve(
+	ctx context.Context, repository, pkgName, versionRange string, opts ...crane.Option,
+) (packages.ResolvedVersion, error) {
+	args := m.Called(ctx, repository, pkgName, versionRange, opts)
+	return args.Get(0).(packages.ResolvedVersion), args.Error(1)
+}
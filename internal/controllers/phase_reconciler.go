@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/csaupgrade"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -42,6 +45,7 @@ type PhaseReconciler struct {
 	adoptionChecker  adoptionChecker
 	patcher          patcher
 	preflightChecker preflightChecker
+	eventRecorder    record.EventRecorder
 }
 
 type ownerStrategy interface {
@@ -90,6 +94,7 @@ func NewPhaseReconciler(
 	uncachedClient client.Reader,
 	ownerStrategy ownerStrategy,
 	preflightChecker preflightChecker,
+	eventRecorder record.EventRecorder,
 ) *PhaseReconciler {
 	return &PhaseReconciler{
 		scheme:           scheme,
@@ -100,6 +105,7 @@ func NewPhaseReconciler(
 		adoptionChecker:  &defaultAdoptionChecker{ownerStrategy: ownerStrategy, scheme: scheme},
 		patcher:          &defaultPatcher{writer: writer},
 		preflightChecker: preflightChecker,
+		eventRecorder:    eventRecorder,
 	}
 }
 
@@ -121,17 +127,42 @@ type recordingProbe struct {
 	name     string
 	probe    probing.Prober
 	failures []string
+	details  []ProbeFailure
 }
 
 func (p *recordingProbe) Probe(obj *unstructured.Unstructured) {
-	ok, msg := p.probe.Probe(obj)
-	if ok {
+	failures := probing.ProbeDetailed(p.probe, obj)
+	if len(failures) == 0 {
 		return
 	}
-	p.recordForObj(obj, msg)
+
+	messages := make([]string, len(failures))
+	for i, f := range failures {
+		messages[i] = f.Message
+		p.recordDetail(obj, string(f.Type), f.Message)
+	}
+	p.recordForObj(obj, strings.Join(messages, ", "))
+}
+
+// ProbeAggregate runs aggregate probes against all probed objects at once.
+func (p *recordingProbe) ProbeAggregate(
+	objs []*unstructured.Unstructured, resolve probing.ReferenceResolver,
+) {
+	ap, ok := p.probe.(probing.AggregateProber)
+	if !ok {
+		return
+	}
+	if ok, msg := ap.ProbeAggregate(objs, resolve); !ok {
+		p.failures = append(p.failures, msg)
+		p.details = append(p.details, ProbeFailure{
+			Type:    string(probing.ProbeTypeAggregate),
+			Message: msg,
+		})
+	}
 }
 
 func (p *recordingProbe) RecordMissingObject(obj *unstructured.Unstructured) {
+	p.recordDetail(obj, corev1alpha1.ProbeResultTypeNotFound, "not found")
 	p.recordForObj(obj, "not found")
 }
 
@@ -142,6 +173,20 @@ func (p *recordingProbe) recordForObj(obj *unstructured.Unstructured, msg string
 	p.failures = append(p.failures, msg)
 }
 
+func (p *recordingProbe) recordDetail(obj *unstructured.Unstructured, probeType, msg string) {
+	gvk := obj.GroupVersionKind()
+	p.details = append(p.details, ProbeFailure{
+		Object: &corev1alpha1.ControlledObjectReference{
+			Kind:      gvk.Kind,
+			Group:     gvk.Group,
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+		},
+		Type:    probeType,
+		Message: msg,
+	})
+}
+
 func (p *recordingProbe) Result() ProbingResult {
 	if len(p.failures) == 0 {
 		return ProbingResult{}
@@ -150,12 +195,15 @@ func (p *recordingProbe) Result() ProbingResult {
 	return ProbingResult{
 		PhaseName:    p.name,
 		FailedProbes: p.failures,
+		Failures:     p.details,
 	}
 }
 
 type ProbingResult struct {
 	PhaseName    string
 	FailedProbes []string
+	// Failures of individual probes, if known.
+	Failures []ProbeFailure
 }
 
 func (e *ProbingResult) IsZero() bool {
@@ -201,6 +249,7 @@ func (r *PhaseReconciler) ReconcilePhase(
 
 	rec := newRecordingProbe(phase.Name, probe)
 
+	probedObjects := make([]*unstructured.Unstructured, 0, len(phase.Objects))
 	for i, phaseObject := range phase.Objects {
 		desiredObj := &desiredObjects[i]
 		actualObj, err := r.reconcilePhaseObject(ctx, owner, phaseObject, desiredObj, previous)
@@ -213,13 +262,44 @@ func (r *PhaseReconciler) ReconcilePhase(
 			return nil, res, fmt.Errorf("%s: %w", phaseObject, err)
 		}
 		actualObjects = append(actualObjects, actualObj)
+		probedObjects = append(probedObjects, actualObj)
 
 		rec.Probe(actualObj)
 	}
+	rec.ProbeAggregate(probedObjects, r.referenceResolver(ctx, owner.ClientObject()))
 
 	return actualObjects, rec.Result(), nil
 }
 
+// ErrCrossNamespaceReference is returned when a namespaced owner
+// references an object in another namespace from an aggregate probe.
+var ErrCrossNamespaceReference = errors.New("cross-namespace references are not allowed")
+
+// Returns a ReferenceResolver looking up objects referenced by aggregate probes.
+// References of namespaced owners default to, and are restricted to, the owners namespace.
+func (r *PhaseReconciler) referenceResolver(
+	ctx context.Context, owner client.Object,
+) probing.ReferenceResolver {
+	return func(ref probing.ObjectReference) (*unstructured.Unstructured, error) {
+		namespace := ref.Namespace
+		if ownerNamespace := owner.GetNamespace(); len(ownerNamespace) > 0 {
+			if len(namespace) > 0 && namespace != ownerNamespace {
+				return nil, fmt.Errorf("%w: %s", ErrCrossNamespaceReference, namespace)
+			}
+			namespace = ownerNamespace
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(ref.GroupVersionKind)
+		if err := r.uncachedClient.Get(
+			ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, obj,
+		); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+}
+
 func (r *PhaseReconciler) TeardownPhase(
 	ctx context.Context, owner PhaseObjectOwner,
 	phase corev1alpha1.ObjectSetTemplatePhase,
@@ -293,6 +373,25 @@ func (r *PhaseReconciler) teardownPhaseObject(
 		return true, nil
 	}
 
+	orphan, err := r.shouldOrphan(ctx, phaseObject.DeletionPolicy, currentObj)
+	if err != nil {
+		return false, err
+	}
+	if orphan {
+		log.Info("orphaning managed object",
+			"apiVersion", currentObj.GetAPIVersion(),
+			"kind", currentObj.GroupVersionKind().Kind,
+			"namespace", currentObj.GetNamespace(),
+			"name", currentObj.GetName(),
+			"deletionPolicy", phaseObject.DeletionPolicy)
+
+		r.ownerStrategy.RemoveOwner(owner.ClientObject(), currentObj)
+		if err := r.writer.Update(ctx, currentObj); err != nil {
+			return false, fmt.Errorf("removing owner reference: %w", err)
+		}
+		return true, nil
+	}
+
 	log.Info("deleting managed object",
 		"apiVersion", currentObj.GetAPIVersion(),
 		"kind", currentObj.GroupVersionKind().Kind,
@@ -310,6 +409,68 @@ func (r *PhaseReconciler) teardownPhaseObject(
 	return false, nil
 }
 
+// Checks the deletion policy to determine whether the object should be left on the cluster.
+func (r *PhaseReconciler) shouldOrphan(
+	ctx context.Context, policy corev1alpha1.ObjectDeletionPolicy,
+	obj *unstructured.Unstructured,
+) (bool, error) {
+	switch policy {
+	case corev1alpha1.ObjectDeletionPolicyOrphan:
+		return true, nil
+	case corev1alpha1.ObjectDeletionPolicyOrphanIfPopulated:
+		return r.isPopulated(ctx, obj)
+	}
+	return false, nil
+}
+
+// Checks whether deleting the object would also delete user data.
+func (r *PhaseReconciler) isPopulated(ctx context.Context, obj *unstructured.Unstructured) (bool, error) {
+	switch obj.GroupVersionKind().GroupKind() {
+	case apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition").GroupKind():
+		return r.hasCustomResources(ctx, obj)
+
+	case corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim").GroupKind():
+		phase, _, err := unstructured.NestedString(obj.Object, "status", "phase")
+		if err != nil {
+			return false, fmt.Errorf("reading PersistentVolumeClaim phase: %w", err)
+		}
+		return phase == string(corev1.ClaimBound), nil
+	}
+	// We can't tell for any other kind, so better keep it.
+	return true, nil
+}
+
+// Checks whether instances of the given CustomResourceDefinition exist.
+func (r *PhaseReconciler) hasCustomResources(ctx context.Context, obj *unstructured.Unstructured) (bool, error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd); err != nil {
+		return false, fmt.Errorf("converting CustomResourceDefinition: %w", err)
+	}
+
+	for _, version := range crd.Spec.Versions {
+		if !version.Storage {
+			continue
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   crd.Spec.Group,
+			Version: version.Name,
+			Kind:    crd.Spec.Names.ListKind,
+		})
+		err := r.uncachedClient.List(ctx, list, client.Limit(1))
+		if meta.IsNoMatchError(err) {
+			// API is not served, so there can't be any instances.
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("listing %s: %w", crd.Name, err)
+		}
+		return len(list.Items) > 0, nil
+	}
+	return false, nil
+}
+
 func (r *PhaseReconciler) reconcilePhaseObject(
 	ctx context.Context, owner PhaseObjectOwner,
 	phaseObject corev1alpha1.ObjectSetObject,
@@ -335,7 +496,9 @@ func (r *PhaseReconciler) reconcilePhaseObject(
 		return actualObj, nil
 	}
 
-	if actualObj, err = r.reconcileObject(ctx, owner, desiredObj, previous, phaseObject.CollisionProtection); err != nil {
+	if actualObj, err = r.reconcileObject(
+		ctx, owner, desiredObj, previous, phaseObject.CollisionProtection, phaseObject.Reconcile,
+	); err != nil {
 		return nil, err
 	}
 
@@ -515,6 +678,7 @@ func (r *PhaseReconciler) reconcileObject(
 	ctx context.Context, owner PhaseObjectOwner,
 	desiredObj *unstructured.Unstructured, previous []PreviousObjectSet,
 	collisionProtection corev1alpha1.CollisionProtection,
+	reconcileMode corev1alpha1.ObjectReconcileMode,
 ) (actualObj *unstructured.Unstructured, err error) {
 	objKey := client.ObjectKeyFromObject(desiredObj)
 	currentObj := desiredObj.DeepCopy()
@@ -574,6 +738,20 @@ func (r *PhaseReconciler) reconcileObject(
 
 	// Only issue updates when this instance is already controlled by this instance.
 	if r.ownerStrategy.IsController(owner.ClientObject(), updatedObj) {
+		if !needsAdoption {
+			if drift := DetectDrift(desiredObj, currentObj); !drift.IsZero() {
+				log := logr.FromContextOrDiscard(ctx)
+				log.Info("object drifted from desired state",
+					"ObjectKey", objKey,
+					"ObjectGVK", desiredObj.GetObjectKind().GroupVersionKind(),
+					"drift", drift.String())
+				r.recordDrift(owner, desiredObj, drift, reconcileMode)
+			}
+			if reconcileMode == corev1alpha1.ObjectReconcileModeObserveOnly {
+				return updatedObj, nil
+			}
+		}
+
 		if err := r.patcher.Patch(ctx, desiredObj, currentObj, updatedObj); err != nil {
 			return nil, err
 		}
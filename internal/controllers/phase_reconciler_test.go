@@ -23,7 +23,9 @@ import (
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/internal/constants"
 	"package-operator.run/internal/preflight"
+	internalprobing "package-operator.run/internal/probing"
 	"package-operator.run/internal/testutil"
+	"package-operator.run/pkg/probing"
 )
 
 var testScheme = runtime.NewScheme()
@@ -331,6 +333,142 @@ func TestPhaseReconciler_TeardownPhase(t *testing.T) {
 	})
 }
 
+func TestPhaseReconciler_TeardownPhase_deletionPolicy(t *testing.T) {
+	t.Parallel()
+
+	pvc := func(phase corev1.PersistentVolumeClaimPhase) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "PersistentVolumeClaim",
+			"metadata":   map[string]any{"name": "data", "namespace": "test"},
+			"status":     map[string]any{"phase": string(phase)},
+		}}
+	}
+	crd := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "bananas.example.com"},
+		"spec": map[string]any{
+			"group": "example.com",
+			"names": map[string]any{"kind": "Banana", "listKind": "BananaList", "plural": "bananas"},
+			"versions": []any{
+				map[string]any{"name": "v1", "served": true, "storage": true},
+			},
+		},
+	}}
+
+	for name, tc := range map[string]struct {
+		policy         corev1alpha1.ObjectDeletionPolicy
+		obj            unstructured.Unstructured
+		instances      int
+		expectedOrphan bool
+	}{
+		"delete": {
+			policy: corev1alpha1.ObjectDeletionPolicyDelete,
+			obj:    pvc(corev1.ClaimBound),
+		},
+		"orphan": {
+			policy:         corev1alpha1.ObjectDeletionPolicyOrphan,
+			obj:            pvc(corev1.ClaimPending),
+			expectedOrphan: true,
+		},
+		"bound PersistentVolumeClaim": {
+			policy:         corev1alpha1.ObjectDeletionPolicyOrphanIfPopulated,
+			obj:            pvc(corev1.ClaimBound),
+			expectedOrphan: true,
+		},
+		"pending PersistentVolumeClaim": {
+			policy: corev1alpha1.ObjectDeletionPolicyOrphanIfPopulated,
+			obj:    pvc(corev1.ClaimPending),
+		},
+		"CustomResourceDefinition with instances": {
+			policy:         corev1alpha1.ObjectDeletionPolicyOrphanIfPopulated,
+			obj:            crd,
+			instances:      1,
+			expectedOrphan: true,
+		},
+		"CustomResourceDefinition without instances": {
+			policy: corev1alpha1.ObjectDeletionPolicyOrphanIfPopulated,
+			obj:    crd,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testClient := testutil.NewClient()
+			dynamicCache := &dynamicCacheMock{}
+			uncachedClient := testutil.NewClient()
+			ownerStrategy := &ownerStrategyMock{}
+			preflightChecker := &preflightCheckerMock{}
+			r := &PhaseReconciler{
+				writer:           testClient,
+				dynamicCache:     dynamicCache,
+				uncachedClient:   uncachedClient,
+				ownerStrategy:    ownerStrategy,
+				preflightChecker: preflightChecker,
+			}
+
+			owner := &phaseObjectOwnerMock{}
+			ownerObj := &unstructured.Unstructured{}
+			owner.On("ClientObject").Return(ownerObj)
+			owner.On("GetRevision").Return(int64(5))
+
+			preflightChecker.
+				On("Check", mock.Anything, mock.Anything, mock.Anything).
+				Return([]preflight.Violation{}, nil)
+			ownerStrategy.
+				On("SetControllerReference", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+			ownerStrategy.
+				On("IsController", mock.Anything, mock.Anything).
+				Return(true)
+			ownerStrategy.On("RemoveOwner", mock.Anything, mock.Anything)
+			dynamicCache.
+				On("Watch", mock.Anything, ownerObj, mock.Anything).
+				Return(nil)
+			uncachedClient.
+				On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					out := args.Get(2).(*unstructured.Unstructured)
+					*out = *tc.obj.DeepCopy()
+				}).
+				Return(nil)
+			uncachedClient.
+				On("List", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					list := args.Get(1).(*unstructured.UnstructuredList)
+					assert.Equal(t, "BananaList", list.GetKind())
+					list.Items = make([]unstructured.Unstructured, tc.instances)
+				}).
+				Return(nil)
+			testClient.
+				On("Update", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+			testClient.
+				On("Delete", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			done, err := r.TeardownPhase(context.Background(), owner, corev1alpha1.ObjectSetTemplatePhase{
+				Objects: []corev1alpha1.ObjectSetObject{
+					{Object: tc.obj, DeletionPolicy: tc.policy},
+				},
+			})
+			require.NoError(t, err)
+
+			if tc.expectedOrphan {
+				assert.True(t, done)
+				ownerStrategy.AssertCalled(t, "RemoveOwner", ownerObj, mock.Anything)
+				testClient.AssertCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				testClient.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.False(t, done) // wait for delete confirm
+			ownerStrategy.AssertNotCalled(t, "RemoveOwner", mock.Anything, mock.Anything)
+			testClient.AssertCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestPhaseReconciler_reconcileObject_create(t *testing.T) {
 	t.Parallel()
 
@@ -356,7 +494,7 @@ func TestPhaseReconciler_reconcileObject_create(t *testing.T) {
 
 	ctx := context.Background()
 	desired := &unstructured.Unstructured{}
-	actual, err := r.reconcileObject(ctx, owner, desired, nil, corev1alpha1.CollisionProtectionPrevent)
+	actual, err := r.reconcileObject(ctx, owner, desired, nil, corev1alpha1.CollisionProtectionPrevent, "")
 	require.NoError(t, err)
 
 	assert.Same(t, desired, actual)
@@ -412,7 +550,7 @@ func TestPhaseReconciler_reconcileObject_update(t *testing.T) {
 	obj := &unstructured.Unstructured{}
 	// set owner refs so we don't run into the panic
 	obj.SetOwnerReferences([]metav1.OwnerReference{{}})
-	actual, err := r.reconcileObject(ctx, owner, obj, nil, corev1alpha1.CollisionProtectionPrevent)
+	actual, err := r.reconcileObject(ctx, owner, obj, nil, corev1alpha1.CollisionProtectionPrevent, "")
 	require.NoError(t, err)
 
 	assert.Equal(t, &unstructured.Unstructured{
@@ -1214,6 +1352,197 @@ func TestPhaseReconciler_ReconcilePhase_preflightError(t *testing.T) {
 	require.ErrorAs(t, err, &pErr)
 }
 
+func TestPhaseReconciler_referenceResolver(t *testing.T) {
+	t.Parallel()
+
+	ref := probing.ObjectReference{
+		Alias:            "db",
+		GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		Name:             "database",
+	}
+
+	for name, tc := range map[string]struct {
+		ownerNamespace string
+		refNamespace   string
+		expectedKey    client.ObjectKey
+		expectedErr    error
+	}{
+		"cluster owner": {
+			refNamespace: "other",
+			expectedKey:  client.ObjectKey{Namespace: "other", Name: "database"},
+		},
+		"namespaced owner defaults namespace": {
+			ownerNamespace: "test",
+			expectedKey:    client.ObjectKey{Namespace: "test", Name: "database"},
+		},
+		"namespaced owner same namespace": {
+			ownerNamespace: "test",
+			refNamespace:   "test",
+			expectedKey:    client.ObjectKey{Namespace: "test", Name: "database"},
+		},
+		"namespaced owner other namespace": {
+			ownerNamespace: "test",
+			refNamespace:   "other",
+			expectedErr:    ErrCrossNamespaceReference,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			clientMock := &testutil.CtrlClient{}
+			r := &PhaseReconciler{uncachedClient: clientMock}
+			clientMock.
+				On("Get", mock.Anything, tc.expectedKey, mock.Anything, mock.Anything).
+				Return(nil)
+
+			owner := &unstructured.Unstructured{}
+			owner.SetNamespace(tc.ownerNamespace)
+
+			objRef := ref
+			objRef.Namespace = tc.refNamespace
+			obj, err := r.referenceResolver(context.Background(), owner)(objRef)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				clientMock.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, ref.GroupVersionKind, obj.GroupVersionKind())
+		})
+	}
+}
+
+func TestRecordingProbe_ProbeAggregate(t *testing.T) {
+	t.Parallel()
+
+	aggregate, err := probing.NewAggregateCELProbe(`objects.size() > 1`, "not enough objects", nil)
+	require.NoError(t, err)
+
+	rec := newRecordingProbe("phase", probing.And{
+		&probing.AggregateSelector{AggregateProber: aggregate},
+	})
+	rec.ProbeAggregate([]*unstructured.Unstructured{{}}, nil)
+
+	assert.Equal(t, ProbingResult{
+		PhaseName:    "phase",
+		FailedProbes: []string{"not enough objects"},
+		Failures: []ProbeFailure{
+			{Type: "Aggregate", Message: "not enough objects"},
+		},
+	}, rec.Result())
+}
+
+func TestRecordingProbe_ProbeAggregate_phases(t *testing.T) {
+	t.Parallel()
+
+	probe, err := internalprobing.Parse(context.Background(), []corev1alpha1.ObjectSetProbe{{
+		Selector: corev1alpha1.ProbeSelector{
+			Kind: &corev1alpha1.PackageProbeKindSpec{Group: "apps", Kind: "Deployment"},
+		},
+		Aggregate: []corev1alpha1.ProbeAggregateSpec{{
+			Rule:    `objects.filter(o, o.status.availableReplicas > 0).size() >= 2`,
+			Message: "At least 2 Deployments must be available",
+		}},
+	}})
+	require.NoError(t, err)
+
+	deployment := func(name string, available int64) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("apps/v1")
+		obj.SetKind("Deployment")
+		obj.SetName(name)
+		require.NoError(t, unstructured.SetNestedField(obj.Object, available, "status", "availableReplicas"))
+		return obj
+	}
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetName("config")
+
+	// Every phase is probed on its own, the same way ReconcilePhase does.
+	for name, tc := range map[string]struct {
+		objects  []*unstructured.Unstructured
+		expected []string
+	}{
+		"no deployments": {
+			objects: []*unstructured.Unstructured{cm},
+		},
+		"available": {
+			objects: []*unstructured.Unstructured{deployment("a", 1), deployment("b", 1)},
+		},
+		"unavailable": {
+			objects:  []*unstructured.Unstructured{deployment("a", 1), deployment("b", 0), cm},
+			expected: []string{"At least 2 Deployments must be available"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := newRecordingProbe(name, probe)
+			rec.ProbeAggregate(tc.objects, nil)
+			assert.Equal(t, tc.expected, rec.Result().FailedProbes)
+		})
+	}
+}
+
+func TestRecordingProbe_Probe(t *testing.T) {
+	t.Parallel()
+
+	rec := newRecordingProbe("phase", &probing.ObservedGenerationProbe{
+		Prober: probing.And{
+			&probing.ConditionProbe{Type: "Available", Status: "True"},
+			&probing.FieldsEqualProbe{FieldA: ".spec.fieldA", FieldB: ".spec.fieldB"},
+		},
+	})
+
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{"fieldA": "a", "fieldB": "b"},
+	}}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetName("test")
+	obj.SetNamespace("test-ns")
+	rec.Probe(obj)
+
+	missing := &unstructured.Unstructured{}
+	missing.SetAPIVersion("v1")
+	missing.SetKind("ConfigMap")
+	missing.SetName("missing")
+	missing.SetNamespace("test-ns")
+	rec.RecordMissingObject(missing)
+
+	deployment := &corev1alpha1.ControlledObjectReference{
+		Group: "apps", Kind: "Deployment", Name: "test", Namespace: "test-ns",
+	}
+	assert.Equal(t, ProbingResult{
+		PhaseName: "phase",
+		FailedProbes: []string{
+			`apps Deployment test-ns/test: condition "Available" == "True": missing .status.conditions, ` +
+				`".spec.fieldA" == ".spec.fieldB": "a" != "b"`,
+			" ConfigMap test-ns/missing: not found",
+		},
+		Failures: []ProbeFailure{
+			{
+				Object:  deployment,
+				Type:    "Condition",
+				Message: `condition "Available" == "True": missing .status.conditions`,
+			},
+			{
+				Object:  deployment,
+				Type:    "FieldsEqual",
+				Message: `".spec.fieldA" == ".spec.fieldB": "a" != "b"`,
+			},
+			{
+				Object: &corev1alpha1.ControlledObjectReference{
+					Kind: "ConfigMap", Name: "missing", Namespace: "test-ns",
+				},
+				Type:    corev1alpha1.ProbeResultTypeNotFound,
+				Message: "not found",
+			},
+		},
+	}, rec.Result())
+}
+
 type preflightCheckerMock struct {
 	mock.Mock
 }
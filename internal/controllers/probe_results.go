@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// ProbeFailure describes a single failing probe.
+type ProbeFailure struct {
+	// Object the probe failed for, nil for aggregate probes.
+	Object *corev1alpha1.ControlledObjectReference
+	// Type of the failing probe.
+	Type string
+	// Message of the failing probe.
+	Message string
+	// Time the probe started failing, if known,
+	// e.g. when reported by the remote phase probing the object.
+	// Zero for probes evaluated locally.
+	LastTransitionTime metav1.Time
+}
+
+// Returns the probe results reporting the failures of the given ProbingResult.
+// Failures with a known transition time keep it.
+// Otherwise, the transition time of probes that were already failing before is retained,
+// probes failing for the first time transition at the given time.
+func UpdateProbeResults(
+	previous []corev1alpha1.ProbeResult, res ProbingResult, now metav1.Time,
+) []corev1alpha1.ProbeResult {
+	if len(res.Failures) == 0 {
+		return nil
+	}
+
+	results := make([]corev1alpha1.ProbeResult, len(res.Failures))
+	for i, f := range res.Failures {
+		results[i] = corev1alpha1.ProbeResult{
+			Phase:              res.PhaseName,
+			Object:             f.Object,
+			Type:               f.Type,
+			Message:            f.Message,
+			LastTransitionTime: now,
+		}
+		if !f.LastTransitionTime.IsZero() {
+			results[i].LastTransitionTime = f.LastTransitionTime
+		}
+	}
+	for i, prev := range matchProbeResults(previous, results) {
+		if prev >= 0 && res.Failures[i].LastTransitionTime.IsZero() {
+			results[i].LastTransitionTime = previous[prev].LastTransitionTime
+		}
+	}
+	return results
+}
+
+// Returns the previous probe results that are no longer failing.
+func RecoveredProbeResults(previous, current []corev1alpha1.ProbeResult) []corev1alpha1.ProbeResult {
+	matched := make([]bool, len(previous))
+	for _, prev := range matchProbeResults(previous, current) {
+		if prev >= 0 {
+			matched[prev] = true
+		}
+	}
+
+	var recovered []corev1alpha1.ProbeResult
+	for i, prev := range previous {
+		if !matched[i] {
+			recovered = append(recovered, prev)
+		}
+	}
+	return recovered
+}
+
+// Converts probe results back into failures, e.g. to report the failures of remote phases.
+func ProbeFailuresFromResults(results []corev1alpha1.ProbeResult) []ProbeFailure {
+	if len(results) == 0 {
+		return nil
+	}
+	failures := make([]ProbeFailure, len(results))
+	for i, r := range results {
+		failures[i] = ProbeFailure{
+			Object:             r.Object,
+			Type:               r.Type,
+			Message:            r.Message,
+			LastTransitionTime: r.LastTransitionTime,
+		}
+	}
+	return failures
+}
+
+// Returns the index of the previous result for every current result, or -1.
+// Results match, if they are of the same probe type for the same phase and object.
+// Multiple probes of the same type may fail for one object, so results with
+// the same message are matched first, before messages that changed,
+// e.g. because a condition moved to another status, are matched in order.
+func matchProbeResults(previous, current []corev1alpha1.ProbeResult) []int {
+	matches := make([]int, len(current))
+	used := make([]bool, len(previous))
+	for i := range current {
+		matches[i] = -1
+		for j := range previous {
+			if !used[j] && sameProbe(previous[j], current[i]) &&
+				previous[j].Message == current[i].Message {
+				matches[i], used[j] = j, true
+				break
+			}
+		}
+	}
+	for i := range current {
+		if matches[i] >= 0 {
+			continue
+		}
+		for j := range previous {
+			if !used[j] && sameProbe(previous[j], current[i]) {
+				matches[i], used[j] = j, true
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func sameProbe(a, b corev1alpha1.ProbeResult) bool {
+	if a.Phase != b.Phase || a.Type != b.Type {
+		return false
+	}
+	switch {
+	case a.Object == nil && b.Object == nil:
+		return true
+	case a.Object != nil && b.Object != nil:
+		return *a.Object == *b.Object
+	default:
+		return false
+	}
+}
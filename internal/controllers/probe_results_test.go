@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestUpdateProbeResults(t *testing.T) {
+	t.Parallel()
+
+	before := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(before.Add(time.Minute))
+	deployment := &corev1alpha1.ControlledObjectReference{
+		Group: "apps", Kind: "Deployment", Name: "test", Namespace: "test-ns",
+	}
+
+	previous := []corev1alpha1.ProbeResult{
+		{
+			Phase: "deploy", Object: deployment, Type: "Condition",
+			Message: "wrong status", LastTransitionTime: before,
+		},
+		{
+			Phase: "deploy", Type: "Aggregate",
+			Message: "not enough objects", LastTransitionTime: before,
+		},
+	}
+
+	results := UpdateProbeResults(previous, ProbingResult{
+		PhaseName:    "deploy",
+		FailedProbes: []string{"..."},
+		Failures: []ProbeFailure{
+			{Object: deployment, Type: "Condition", Message: "not reported"},
+			{Object: deployment, Type: "CEL", Message: "not ready"},
+		},
+	}, now)
+
+	assert.Equal(t, []corev1alpha1.ProbeResult{
+		{
+			Phase: "deploy", Object: deployment, Type: "Condition",
+			Message: "not reported", LastTransitionTime: before,
+		},
+		{
+			Phase: "deploy", Object: deployment, Type: "CEL",
+			Message: "not ready", LastTransitionTime: now,
+		},
+	}, results)
+
+	assert.Equal(t, []corev1alpha1.ProbeResult{previous[1]},
+		RecoveredProbeResults(previous, results))
+
+	assert.Nil(t, UpdateProbeResults(previous, ProbingResult{}, now))
+	assert.Equal(t, previous, RecoveredProbeResults(previous, nil))
+}
+
+func TestUpdateProbeResults_sameType(t *testing.T) {
+	t.Parallel()
+
+	before := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(before.Add(time.Minute))
+	deployment := &corev1alpha1.ControlledObjectReference{
+		Group: "apps", Kind: "Deployment", Name: "test", Namespace: "test-ns",
+	}
+
+	// Two CEL probes failing for the same object.
+	previous := []corev1alpha1.ProbeResult{
+		{
+			Phase: "deploy", Object: deployment, Type: "CEL",
+			Message: "not ready", LastTransitionTime: before,
+		},
+		{
+			Phase: "deploy", Object: deployment, Type: "CEL",
+			Message: "not scaled", LastTransitionTime: before,
+		},
+	}
+
+	results := UpdateProbeResults(previous, ProbingResult{
+		PhaseName: "deploy",
+		Failures: []ProbeFailure{
+			{Object: deployment, Type: "CEL", Message: "not scaled"},
+		},
+	}, now)
+
+	assert.Equal(t, []corev1alpha1.ProbeResult{previous[1]}, results)
+	assert.Equal(t, []corev1alpha1.ProbeResult{previous[0]},
+		RecoveredProbeResults(previous, results))
+}
+
+func TestProbeFailuresFromResults(t *testing.T) {
+	t.Parallel()
+
+	before := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(before.Add(time.Minute))
+	deployment := &corev1alpha1.ControlledObjectReference{
+		Group: "apps", Kind: "Deployment", Name: "test",
+	}
+	failures := ProbeFailuresFromResults([]corev1alpha1.ProbeResult{
+		{
+			Phase: "deploy", Object: deployment, Type: "Condition",
+			Message: "wrong status", LastTransitionTime: before,
+		},
+	})
+	assert.Equal(t, []ProbeFailure{
+		{Object: deployment, Type: "Condition", Message: "wrong status", LastTransitionTime: before},
+	}, failures)
+	assert.Nil(t, ProbeFailuresFromResults(nil))
+
+	// The transition time reported by the remote phase is kept,
+	// even if the failure was not recorded before.
+	assert.Equal(t, []corev1alpha1.ProbeResult{
+		{
+			Phase: "remote", Object: deployment, Type: "Condition",
+			Message: "wrong status", LastTransitionTime: before,
+		},
+	}, UpdateProbeResults(nil, ProbingResult{PhaseName: "remote", Failures: failures}, now))
+}
@@ -24,8 +24,15 @@ type Recorder struct {
 	packageLoadDuration *prometheus.GaugeVec
 	packageRevision     *prometheus.GaugeVec
 
-	objectSetCreated   *prometheus.GaugeVec
-	objectSetSucceeded *prometheus.GaugeVec
+	packageCacheRequests  *prometheus.CounterVec
+	packageCacheEvictions prometheus.Counter
+	packageCacheEntries   prometheus.Gauge
+	packageCacheSize      prometheus.Gauge
+
+	objectSetCreated       *prometheus.GaugeVec
+	objectSetSucceeded     *prometheus.GaugeVec
+	objectSetFailingProbes *prometheus.GaugeVec
+	probeFailureDuration   *prometheus.HistogramVec
 }
 
 func NewRecorder() *Recorder {
@@ -67,6 +74,29 @@ func NewRecorder() *Recorder {
 		}, []string{"pko_name", "pko_namespace"},
 	)
 
+	// Package image cache
+	packageCacheRequests := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "package_operator_package_cache_requests_total",
+			Help: "Package image cache lookups by result.",
+		}, []string{"result"},
+	)
+	packageCacheEvictions := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "package_operator_package_cache_evictions_total",
+			Help: "Number of package images evicted from the cache.",
+		})
+	packageCacheEntries := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "package_operator_package_cache_entries",
+			Help: "Number of package images in the cache.",
+		})
+	packageCacheSize := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "package_operator_package_cache_size_bytes",
+			Help: "Total size of all package images in the cache.",
+		})
+
 	// Revisions
 	objectSetCreated := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -80,6 +110,22 @@ func NewRecorder() *Recorder {
 			Help: "ObjectSet Unix success timestamp.",
 		}, []string{"pko_name", "pko_namespace", "pko_package_instance"},
 	)
+	objectSetFailingProbes := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "package_operator_object_set_failing_probes",
+			Help: "Number of availability probes currently failing for an ObjectSet.",
+		}, []string{"pko_name", "pko_namespace", "pko_package_instance"},
+	)
+
+	// Probes
+	probeFailureDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "package_operator_probe_failure_duration_seconds",
+			Help: "Time availability probes were failing for, until they passed again.",
+			// 5s to ~27h.
+			Buckets: prometheus.ExponentialBuckets(5, 3, 10),
+		}, []string{"probe_type"},
+	)
 
 	return &Recorder{
 		dynamicCacheInformers: dynamicCacheInformers,
@@ -90,8 +136,15 @@ func NewRecorder() *Recorder {
 		packageLoadDuration: packageLoadDuration,
 		packageRevision:     packageRevision,
 
-		objectSetCreated:   objectSetCreated,
-		objectSetSucceeded: objectSetSucceeded,
+		packageCacheRequests:  packageCacheRequests,
+		packageCacheEvictions: packageCacheEvictions,
+		packageCacheEntries:   packageCacheEntries,
+		packageCacheSize:      packageCacheSize,
+
+		objectSetCreated:       objectSetCreated,
+		objectSetSucceeded:     objectSetSucceeded,
+		objectSetFailingProbes: objectSetFailingProbes,
+		probeFailureDuration:   probeFailureDuration,
 	}
 }
 
@@ -100,8 +153,10 @@ func (r *Recorder) Register() {
 	metrics.Registry.MustRegister(
 		r.dynamicCacheInformers, r.dynamicCacheObjects,
 		r.packageAvailability, r.packageCreated, r.packageLoadDuration, r.packageRevision,
+		r.packageCacheRequests, r.packageCacheEvictions, r.packageCacheEntries, r.packageCacheSize,
 
-		r.objectSetCreated, r.objectSetSucceeded,
+		r.objectSetCreated, r.objectSetSucceeded, r.objectSetFailingProbes,
+		r.probeFailureDuration,
 	)
 }
 
@@ -153,6 +208,27 @@ func (r *Recorder) RecordPackageLoadMetric(pkg GenericPackage, d time.Duration)
 		Set(float64(d.Milliseconds()) / 1000)
 }
 
+// Records a package image cache hit.
+func (r *Recorder) RecordPackageCacheHit() {
+	r.packageCacheRequests.WithLabelValues("hit").Inc()
+}
+
+// Records a package image cache miss.
+func (r *Recorder) RecordPackageCacheMiss() {
+	r.packageCacheRequests.WithLabelValues("miss").Inc()
+}
+
+// Records the eviction of a package image from the cache.
+func (r *Recorder) RecordPackageCacheEviction() {
+	r.packageCacheEvictions.Inc()
+}
+
+// Records the number of entries and total size of the package image cache.
+func (r *Recorder) RecordPackageCacheSize(entries int, bytes int64) {
+	r.packageCacheEntries.Set(float64(entries))
+	r.packageCacheSize.Set(float64(bytes))
+}
+
 type GenericObjectSet interface {
 	ClientObject() client.Object
 	GetConditions() *[]metav1.Condition
@@ -160,12 +236,7 @@ type GenericObjectSet interface {
 
 func (r *Recorder) RecordObjectSetMetrics(objectSet GenericObjectSet) {
 	obj := objectSet.ClientObject()
-
-	// Package instance name -> name of the Package Object.
-	var instance string
-	if l := obj.GetLabels(); l != nil && l[manifestsv1alpha1.PackageInstanceLabel] != "" {
-		instance = l[manifestsv1alpha1.PackageInstanceLabel]
-	}
+	instance := packageInstance(obj)
 
 	if !obj.GetDeletionTimestamp().IsZero() ||
 		meta.IsStatusConditionTrue(*objectSet.GetConditions(), corev1alpha1.ObjectSetArchived) {
@@ -188,6 +259,41 @@ func (r *Recorder) RecordObjectSetMetrics(objectSet GenericObjectSet) {
 	}
 }
 
+type ProbedObjectSet interface {
+	GenericObjectSet
+	GetProbeResults() []corev1alpha1.ProbeResult
+}
+
+// Records the number of availability probes failing for the ObjectSet
+// and how long the given recovered probes were failing until the given time.
+func (r *Recorder) RecordObjectSetProbeResults(
+	objectSet ProbedObjectSet, recovered []corev1alpha1.ProbeResult, now time.Time,
+) {
+	for _, result := range recovered {
+		r.probeFailureDuration.
+			WithLabelValues(result.Type).
+			Observe(now.Sub(result.LastTransitionTime.Time).Seconds())
+	}
+
+	obj := objectSet.ClientObject()
+	instance := packageInstance(obj)
+	if !obj.GetDeletionTimestamp().IsZero() {
+		r.objectSetFailingProbes.DeleteLabelValues(obj.GetName(), obj.GetNamespace(), instance)
+		return
+	}
+	r.objectSetFailingProbes.
+		WithLabelValues(obj.GetName(), obj.GetNamespace(), instance).
+		Set(float64(len(objectSet.GetProbeResults())))
+}
+
+// Package instance name -> name of the Package Object.
+func packageInstance(obj client.Object) string {
+	if l := obj.GetLabels(); l != nil && l[manifestsv1alpha1.PackageInstanceLabel] != "" {
+		return l[manifestsv1alpha1.PackageInstanceLabel]
+	}
+	return ""
+}
+
 // Records the number of active Informers for the cache.
 func (r *Recorder) RecordDynamicCacheInformers(total int) {
 	r.dynamicCacheInformers.Set(float64(total))
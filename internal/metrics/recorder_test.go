@@ -29,6 +29,11 @@ func (m *genericObjectSetMock) GetConditions() *[]metav1.Condition {
 	return args.Get(0).(*[]metav1.Condition)
 }
 
+func (m *genericObjectSetMock) GetProbeResults() []corev1alpha1.ProbeResult {
+	args := m.Called()
+	return args.Get(0).([]corev1alpha1.ProbeResult)
+}
+
 func (m *genericObjectSetMock) GetRevision() int64 {
 	args := m.Called()
 	return args.Get(0).(int64)
@@ -176,6 +181,25 @@ func TestRecorder_RecordPackageLoadMetric(t *testing.T) {
 	)
 }
 
+func TestRecorder_RecordPackageCacheMetrics(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewRecorder()
+	recorder.RecordPackageCacheHit()
+	recorder.RecordPackageCacheHit()
+	recorder.RecordPackageCacheMiss()
+	recorder.RecordPackageCacheEviction()
+	recorder.RecordPackageCacheSize(3, 1024)
+
+	assert.InDelta(t, float64(2),
+		testutil.ToFloat64(recorder.packageCacheRequests.WithLabelValues("hit")), 0.01)
+	assert.InDelta(t, float64(1),
+		testutil.ToFloat64(recorder.packageCacheRequests.WithLabelValues("miss")), 0.01)
+	assert.InDelta(t, float64(1), testutil.ToFloat64(recorder.packageCacheEvictions), 0.01)
+	assert.InDelta(t, float64(3), testutil.ToFloat64(recorder.packageCacheEntries), 0.01)
+	assert.InDelta(t, float64(1024), testutil.ToFloat64(recorder.packageCacheSize), 0.01)
+}
+
 func TestRecorder_RecordObjectSetMetrics(t *testing.T) {
 	t.Parallel()
 	successTimestamp := time.Date(2022, 5, 27, 15, 37, 19, 0, time.UTC)
@@ -235,3 +259,29 @@ func TestRecorder_RecordObjectSetMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestRecorder_RecordObjectSetProbeResults(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2022, 5, 27, 15, 37, 19, 0, time.UTC)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetName("test")
+	obj.SetNamespace("test-ns")
+
+	osMock := &genericObjectSetMock{}
+	osMock.On("ClientObject").Return(obj)
+	osMock.On("GetProbeResults").Return([]corev1alpha1.ProbeResult{{}, {}})
+
+	recorder := NewRecorder()
+	recorder.RecordObjectSetProbeResults(osMock, []corev1alpha1.ProbeResult{
+		{Type: "Condition", LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))},
+	}, now)
+
+	assert.InDelta(t, float64(2), testutil.ToFloat64(recorder.objectSetFailingProbes), 0.01)
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.probeFailureDuration))
+
+	// Remove metrics of deleted ObjectSets.
+	obj.SetDeletionTimestamp(&metav1.Time{Time: now})
+	recorder.RecordObjectSetProbeResults(osMock, nil, now)
+	assert.Equal(t, 0, testutil.CollectAndCount(recorder.objectSetFailingProbes))
+}
@@ -7,9 +7,18 @@ import (
 // PackageDeployer loads package contents from file, wraps it into an ObjectDeployment and deploys it.
 type PackageDeployer = packagedeploy.PackageDeployer
 
+type (
+	// DeployOption configures a single package deployment.
+	DeployOption = packagedeploy.DeployOption
+	// Adds configuration resolved from the ConfigMaps and Secrets referenced by the package.
+	WithConfigFrom = packagedeploy.WithConfigFrom
+)
+
 var (
 	// Returns a new namespace-scoped loader for the Package API.
 	NewPackageDeployer = packagedeploy.NewPackageDeployer
 	// Returns a new cluster-scoped loader for the ClusterPackage API.
 	NewClusterPackageDeployer = packagedeploy.NewClusterPackageDeployer
+	// Replaces the tag/digest part of the given image reference with the given digest.
+	ImageWithDigest = packagedeploy.ImageWithDigest
 )
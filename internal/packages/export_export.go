@@ -5,8 +5,33 @@ import "package-operator.run/internal/packages/internal/packageexport"
 var (
 	// Exports the package as OCI (Open Container Image).
 	ToOCI = packageexport.ToOCI
+	// Exports the package as OCI artifact.
+	ToOCIArtifact = packageexport.ToOCIArtifact
+	// Exports the package as multi-platform OCI image index.
+	ToOCIIndex = packageexport.ToOCIIndex
 	// Exports the given package to an OCI tar under the given name and tags.
 	ToOCIFile = packageexport.ToOCIFile
 	// Exports the given package by pushing it to an OCI registry.
 	ToPushedOCI = packageexport.ToPushedOCI
+
+	// ErrUnsupportedFormat is returned when a package can't be exported in the requested format.
+	ErrUnsupportedFormat = packageexport.ErrUnsupportedFormat
+)
+
+type (
+	// Exporter exports packages as OCI images in the configured format.
+	Exporter = packageexport.Exporter
+	// Format of exported package images.
+	ExportFormat = packageexport.Format
+	// Exported package image, either an image or an image index.
+	ExportedImage = packageexport.Image
+)
+
+const (
+	// Exports packages as linux/amd64 container image.
+	ExportFormatImage = packageexport.FormatImage
+	// Exports packages as OCI artifact with package-operator specific media types.
+	ExportFormatArtifact = packageexport.FormatArtifact
+	// Exports packages as multi-platform image index.
+	ExportFormatIndex = packageexport.FormatIndex
 )
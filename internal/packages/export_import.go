@@ -7,17 +7,79 @@ var (
 	FromFolder = packageimport.FromFolder
 	// Import a RawPackage from the given FileSystem.
 	FromFS = packageimport.FromFS
+	// Imports a RawPackage from the given path, which may either be
+	// a package source folder, an OCI image layout directory or an image tar file.
+	FromPath = packageimport.FromPath
+	// Imports a RawPackage from the OCI image layout directory at the given path.
+	FromOCILayout = packageimport.FromOCILayout
+	// Imports a RawPackage from the image tar file at the given path.
+	FromOCITarball = packageimport.FromOCITarball
+	// Detects how the package at the given path is stored.
+	DetectPathType = packageimport.DetectPathType
 
 	// Imports a RawPackage from the given OCI image.
 	FromOCI = packageimport.FromOCI
+	// Imports a RawPackage from the given OCI image index.
+	FromOCIIndex = packageimport.FromOCIIndex
 	// Imports a RawPackage from a container image registry.
 	FromRegistry = packageimport.FromRegistry
 
 	// Creates a new registry instance to de-duplicate parallel container image pulls.
 	NewRegistry = packageimport.NewRegistry
+	// Creates a new DiskCache in the given directory,
+	// picking up entries left over from previous runs.
+	NewDiskCache = packageimport.NewDiskCache
+	// Builds a keychain from Secrets of type kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg.
+	NewSecretKeychain = packageimport.NewSecretKeychain
+
+	// ErrUnsupportedSecretType is returned for Secrets that hold no registry credentials.
+	ErrUnsupportedSecretType = packageimport.ErrUnsupportedSecretType
+	// ErrNoImageInIndex is returned when importing from an image index without images.
+	ErrNoImageInIndex = packageimport.ErrNoImageInIndex
+	// ErrAmbiguousOCILayout is returned when the package image in an OCI image layout can't be determined.
+	ErrAmbiguousOCILayout = packageimport.ErrAmbiguousOCILayout
+)
+
+// Default size limit of a DiskCache: 512Mi.
+const DefaultDiskCacheMaxSize = packageimport.DefaultDiskCacheMaxSize
+
+const (
+	// Package source folder.
+	PathTypeFolder = packageimport.PathTypeFolder
+	// OCI image layout directory, containing an oci-layout file.
+	PathTypeOCILayout = packageimport.PathTypeOCILayout
+	// Image tar file, as written by `kubectl package build --output`.
+	PathTypeOCITarball = packageimport.PathTypeOCITarball
 )
 
 type (
+	// PathType describes how a package is stored at a filesystem path.
+	PathType = packageimport.PathType
 	// Registry de-duplicates multiple parallel container image pulls.
 	Registry = packageimport.Registry
+	// RegistryOption configures a Registry.
+	RegistryOption = packageimport.RegistryOption
+	// Verifies images before they are pulled, e.g. by checking their signatures.
+	WithImageVerifier = packageimport.WithImageVerifier
+	// ImageVerifier checks an image before it is pulled.
+	ImageVerifier = packageimport.ImageVerifier
+	// PullOption configures a single Registry pull.
+	PullOption = packageimport.PullOption
+	// Pulls images using the registry credentials in the given Secrets.
+	WithPullSecrets = packageimport.WithPullSecrets
+	// Caches pulled packages by image digest.
+	WithPackageCache = packageimport.WithPackageCache
+	// PackageCache stores RawPackages by image digest.
+	PackageCache = packageimport.PackageCache
+
+	// DiskCache is a content-addressed on-disk cache of package image contents.
+	DiskCache = packageimport.DiskCache
+	// DiskCacheOption configures a DiskCache.
+	DiskCacheOption = packageimport.DiskCacheOption
+	// DiskCacheRecorder records metrics of a DiskCache.
+	DiskCacheRecorder = packageimport.DiskCacheRecorder
+	// Maximum size of all cache entries in bytes.
+	WithDiskCacheMaxSize = packageimport.WithMaxSize
+	// Records cache metrics.
+	WithDiskCacheRecorder = packageimport.WithDiskCacheRecorder
 )
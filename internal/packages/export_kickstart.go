@@ -5,6 +5,8 @@ import "package-operator.run/internal/packages/internal/packagekickstart"
 type KickstartResult = packagekickstart.KickstartResult
 
 var (
-	Kickstart            = packagekickstart.Kickstart
-	ImportOLMBundleImage = packagekickstart.ImportOLMBundleImage
+	Kickstart              = packagekickstart.Kickstart
+	KickstartFromHelmChart = packagekickstart.KickstartFromHelmChart
+	ImportOLMBundleImage   = packagekickstart.ImportOLMBundleImage
+	ErrInvalidHelmChart    = packagekickstart.ErrInvalidHelmChart
 )
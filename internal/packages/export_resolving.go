@@ -2,4 +2,14 @@ package packages
 
 import "package-operator.run/internal/packages/internal/packageresolving"
 
-type BuildResolver = packageresolving.BuildResolver
+type (
+	// BuildResolver resolves dependencies when building a package.
+	BuildResolver = packageresolving.BuildResolver
+	// TrackResolver resolves the latest version of a package tracked from a package repository.
+	TrackResolver = packageresolving.TrackResolver
+	// ResolvedVersion is the version of a tracked package resolved from a package repository.
+	ResolvedVersion = packageresolving.ResolvedVersion
+)
+
+// ErrTrackedPackageNotFound is returned when a tracked package is not part of the repository.
+var ErrTrackedPackageNotFound = packageresolving.ErrTrackedPackageNotFound
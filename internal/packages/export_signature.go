@@ -0,0 +1,29 @@
+package packages
+
+import "package-operator.run/internal/packages/internal/packagesignature"
+
+var (
+	// Signs the image with the given digest and pushes the signature next to the image.
+	SignImage = packagesignature.Sign
+	// Returns the tag that signatures of the image with the given digest are stored under.
+	SignatureTag = packagesignature.SignatureTag
+	// Creates a new Verifier trusting the given public keys.
+	NewSignatureVerifier = packagesignature.NewVerifier
+	// Loads PEM encoded public keys from the given file paths.
+	LoadSignaturePublicKeys = packagesignature.LoadPublicKeys
+	// Loads an unencrypted PEM encoded private key from the given file path.
+	LoadSignaturePrivateKey = packagesignature.LoadPrivateKey
+
+	// ErrInvalidSignatureKey is returned when a key could not be parsed.
+	ErrInvalidSignatureKey = packagesignature.ErrInvalidKey
+	// ErrEncryptedSignatureKey is returned for password protected cosign keys.
+	ErrEncryptedSignatureKey = packagesignature.ErrEncryptedKey
+)
+
+type (
+	// SignatureVerifier checks image signatures against a set of trusted public keys.
+	SignatureVerifier = packagesignature.Verifier
+	// SignatureVerificationError is returned when an image
+	// is not signed by any of the trusted keys.
+	SignatureVerificationError = packagesignature.SignatureVerificationError
+)
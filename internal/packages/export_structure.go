@@ -9,6 +9,8 @@ var (
 	NewStructuralLoader = packagestructure.NewStructuralLoader
 	// Converts the internal version of an PackageManifestLock into it's v1alpha1 representation.
 	ToV1Alpha1ManifestLock = packagestructure.ToV1Alpha1ManifestLock
+	// Converts the internal version of an RepositoryEntry into it's v1alpha1 representation.
+	ToV1Alpha1RepositoryEntry = packagestructure.ToV1Alpha1RepositoryEntry
 )
 
 // StructuralLoader parses the raw package structure to produce something usable.
@@ -7,6 +7,8 @@ const (
 	PackageManifestFilename = packagetypes.PackageManifestFilename
 	// Package manifest lock filename without file-extension.
 	PackageManifestLockFilename = packagetypes.PackageManifestLockFilename
+	// Annotation marking the package image in OCI image layouts containing multiple images.
+	OCILayoutPackageAnnotation = packagetypes.OCILayoutPackageAnnotation
 )
 
 type (
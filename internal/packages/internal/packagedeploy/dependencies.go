@@ -0,0 +1,162 @@
+package packagedeploy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+var (
+	// ErrDependencyNotLocked is returned when a dependency to install is missing from the PackageManifestLock.
+	ErrDependencyNotLocked = errors.New("dependency not found in PackageManifestLock")
+	// ErrDependencyNamespace is returned when a Package tries to install a dependency into another namespace.
+	ErrDependencyNamespace = errors.New("dependencies of Packages can only be installed into the Package namespace")
+)
+
+// Returns (Cluster)Package objects for all locked dependencies that should be installed.
+// The objects are added to the phase configured for the dependency,
+// so later phases are blocked until the dependency reports Available.
+func dependencyObjects(
+	apiPkg adapters.GenericPackageAccessor, pkg *packagetypes.Package, config map[string]any,
+) ([]unstructured.Unstructured, error) {
+	lockedDependencies := map[string]manifests.PackageManifestLockDependency{}
+	if pkg.ManifestLock != nil {
+		for _, dep := range pkg.ManifestLock.Spec.Dependencies {
+			lockedDependencies[dep.Name] = dep
+		}
+	}
+
+	var objects []unstructured.Unstructured
+	for _, dep := range pkg.Manifest.Spec.Dependencies {
+		if dep.Install == nil || dep.Image == nil {
+			continue
+		}
+
+		locked, ok := lockedDependencies[dep.Image.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrDependencyNotLocked, dep.Image.Name)
+		}
+		image, err := ImageWithDigest(locked.Image, locked.Digest)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err := dependencyObject(apiPkg, dep.Image.Name, image, *dep.Install, config)
+		if err != nil {
+			return nil, fmt.Errorf("dependency %s: %w", dep.Image.Name, err)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+func dependencyObject(
+	apiPkg adapters.GenericPackageAccessor, name, image string,
+	install manifests.PackageManifestDependencyInstall, config map[string]any,
+) (unstructured.Unstructured, error) {
+	parent := apiPkg.ClientObject()
+	spec := corev1alpha1.PackageSpec{Image: image}
+	if len(install.ConfigKey) > 0 {
+		if depConfig, ok := config[install.ConfigKey]; ok {
+			b, err := json.Marshal(depConfig)
+			if err != nil {
+				return unstructured.Unstructured{}, fmt.Errorf("marshalling config: %w", err)
+			}
+			spec.Config = &runtime.RawExtension{Raw: b}
+		}
+	}
+
+	// Image pull secrets are looked up in the namespace of the package,
+	// so they are only passed on to dependencies installed into the same namespace,
+	// which is always the case for Packages and for ClusterPackages installing ClusterPackages.
+	if len(parent.GetNamespace()) > 0 || len(install.Namespace) == 0 {
+		spec.ImagePullSecrets = apiPkg.GetImagePullSecrets()
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Name: parent.GetName() + "-" + name,
+		Labels: map[string]string{
+			manifests.PackageDependencyOfLabel: parent.GetName(),
+		},
+		Annotations: map[string]string{
+			manifests.PackagePhaseAnnotation: install.Phase,
+		},
+	}
+
+	var obj client.Object
+	switch {
+	case len(parent.GetNamespace()) > 0:
+		// Packages can only manage objects within their own namespace.
+		if len(install.Namespace) > 0 && install.Namespace != parent.GetNamespace() {
+			return unstructured.Unstructured{}, ErrDependencyNamespace
+		}
+		objectMeta.Namespace = parent.GetNamespace()
+		obj = &corev1alpha1.Package{
+			TypeMeta:   metav1.TypeMeta{APIVersion: corev1alpha1.GroupVersion.String(), Kind: "Package"},
+			ObjectMeta: objectMeta,
+			Spec:       spec,
+		}
+
+	case len(install.Namespace) > 0:
+		objectMeta.Namespace = install.Namespace
+		obj = &corev1alpha1.Package{
+			TypeMeta:   metav1.TypeMeta{APIVersion: corev1alpha1.GroupVersion.String(), Kind: "Package"},
+			ObjectMeta: objectMeta,
+			Spec:       spec,
+		}
+
+	default:
+		obj = &corev1alpha1.ClusterPackage{
+			TypeMeta:   metav1.TypeMeta{APIVersion: corev1alpha1.GroupVersion.String(), Kind: "ClusterPackage"},
+			ObjectMeta: objectMeta,
+			Spec:       spec,
+		}
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return unstructured.Unstructured{}, err
+	}
+	// Drop empty status from conversion.
+	delete(content, "status")
+	return unstructured.Unstructured{Object: content}, nil
+}
+
+// Availability probes for dependency (Cluster)Packages installed by the given package.
+func dependencyProbes(apiPkg adapters.GenericPackageAccessor) []corev1alpha1.ObjectSetProbe {
+	probes := make([]corev1alpha1.ObjectSetProbe, 0, 2)
+	for _, kind := range []string{"Package", "ClusterPackage"} {
+		probes = append(probes, corev1alpha1.ObjectSetProbe{
+			Selector: corev1alpha1.ProbeSelector{
+				Kind: &corev1alpha1.PackageProbeKindSpec{
+					Group: corev1alpha1.GroupVersion.Group,
+					Kind:  kind,
+				},
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						manifests.PackageDependencyOfLabel: apiPkg.ClientObject().GetName(),
+					},
+				},
+			},
+			Probes: []corev1alpha1.Probe{
+				{
+					Condition: &corev1alpha1.ProbeConditionSpec{
+						Type:   corev1alpha1.PackageAvailable,
+						Status: string(metav1.ConditionTrue),
+					},
+				},
+			},
+		})
+	}
+	return probes
+}
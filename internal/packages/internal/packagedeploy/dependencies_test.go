@@ -0,0 +1,178 @@
+package packagedeploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/adapters"
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+func TestDependencyObjects(t *testing.T) {
+	t.Parallel()
+
+	newPkg := func(install *manifests.PackageManifestDependencyInstall) *packagetypes.Package {
+		return &packagetypes.Package{
+			Manifest: &manifests.PackageManifest{
+				Spec: manifests.PackageManifestSpec{
+					Dependencies: []manifests.PackageManifestDependency{
+						{
+							Image:   &manifests.PackageManifestDependencyImage{Name: "dep"},
+							Install: install,
+						},
+						{
+							// not installed.
+							Image: &manifests.PackageManifestDependencyImage{Name: "other"},
+						},
+					},
+				},
+			},
+			ManifestLock: &manifests.PackageManifestLock{
+				Spec: manifests.PackageManifestLockSpec{
+					Dependencies: []manifests.PackageManifestLockDependency{
+						{Name: "dep", Image: "quay.io/package-operator/dep:v1", Digest: testDgst},
+					},
+				},
+			},
+		}
+	}
+	namespacedPkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: "parent", Namespace: "parent-ns"},
+			Spec: corev1alpha1.PackageSpec{
+				ImagePullSecrets: []corev1alpha1.ImagePullSecretReference{{Name: "pull-secret"}},
+			},
+		},
+	}
+	clusterPkg := &adapters.GenericClusterPackage{
+		ClusterPackage: corev1alpha1.ClusterPackage{
+			ObjectMeta: metav1.ObjectMeta{Name: "parent"},
+			Spec: corev1alpha1.PackageSpec{
+				ImagePullSecrets: []corev1alpha1.ImagePullSecretReference{{Name: "pull-secret"}},
+			},
+		},
+	}
+	pullSecrets := []any{map[string]any{"name": "pull-secret"}}
+
+	for name, tc := range map[string]struct {
+		apiPkg            adapters.GenericPackageAccessor
+		install           *manifests.PackageManifestDependencyInstall
+		config            map[string]any
+		expectedKind      string
+		expectedNamespace string
+		expectedConfig    map[string]any
+		// Image pull secrets passed on to the dependency.
+		expectedPullSecrets []any
+		expectedErr         error
+	}{
+		"not installed": {
+			apiPkg: namespacedPkg,
+		},
+		"package": {
+			apiPkg:              namespacedPkg,
+			install:             &manifests.PackageManifestDependencyInstall{Phase: "deps"},
+			expectedKind:        "Package",
+			expectedNamespace:   "parent-ns",
+			expectedPullSecrets: pullSecrets,
+		},
+		"package with config": {
+			apiPkg: namespacedPkg,
+			install: &manifests.PackageManifestDependencyInstall{
+				Phase:     "deps",
+				ConfigKey: "dep",
+			},
+			config: map[string]any{
+				"dep":   map[string]any{"replicas": int64(2)},
+				"other": "banana",
+			},
+			expectedKind:        "Package",
+			expectedNamespace:   "parent-ns",
+			expectedConfig:      map[string]any{"replicas": int64(2)},
+			expectedPullSecrets: pullSecrets,
+		},
+		"package into other namespace": {
+			apiPkg: namespacedPkg,
+			install: &manifests.PackageManifestDependencyInstall{
+				Phase:     "deps",
+				Namespace: "other",
+			},
+			expectedErr: ErrDependencyNamespace,
+		},
+		"cluster package": {
+			apiPkg:              clusterPkg,
+			install:             &manifests.PackageManifestDependencyInstall{Phase: "deps"},
+			expectedKind:        "ClusterPackage",
+			expectedPullSecrets: pullSecrets,
+		},
+		"cluster package into namespace": {
+			apiPkg: clusterPkg,
+			install: &manifests.PackageManifestDependencyInstall{
+				Phase:     "deps",
+				Namespace: "other",
+			},
+			expectedKind:      "Package",
+			expectedNamespace: "other",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			objs, err := dependencyObjects(tc.apiPkg, newPkg(tc.install), tc.config)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			if len(tc.expectedKind) == 0 {
+				assert.Empty(t, objs)
+				return
+			}
+			require.Len(t, objs, 1)
+
+			obj := objs[0]
+			assert.Equal(t, tc.expectedKind, obj.GetKind())
+			assert.Equal(t, "parent-dep", obj.GetName())
+			assert.Equal(t, tc.expectedNamespace, obj.GetNamespace())
+			assert.Equal(t, "parent", obj.GetLabels()[manifests.PackageDependencyOfLabel])
+			assert.Equal(t, "deps", obj.GetAnnotations()[manifests.PackagePhaseAnnotation])
+
+			image, _, err := unstructured.NestedString(obj.Object, "spec", "image")
+			require.NoError(t, err)
+			assert.Equal(t, "quay.io/package-operator/dep@"+testDgst, image)
+
+			config, _, err := unstructured.NestedMap(obj.Object, "spec", "config")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, config)
+
+			secrets, _, err := unstructured.NestedSlice(obj.Object, "spec", "imagePullSecrets")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPullSecrets, secrets)
+		})
+	}
+}
+
+func TestDependencyObjects_NotLocked(t *testing.T) {
+	t.Parallel()
+
+	pkg := &packagetypes.Package{
+		Manifest: &manifests.PackageManifest{
+			Spec: manifests.PackageManifestSpec{
+				Dependencies: []manifests.PackageManifestDependency{
+					{
+						Image:   &manifests.PackageManifestDependencyImage{Name: "dep"},
+						Install: &manifests.PackageManifestDependencyInstall{Phase: "deps"},
+					},
+				},
+			},
+		},
+	}
+	_, err := dependencyObjects(&adapters.GenericPackage{}, pkg, nil)
+	require.ErrorIs(t, err, ErrDependencyNotLocked)
+}
@@ -29,6 +29,7 @@ import (
 	"package-operator.run/internal/packages/internal/packagestructure"
 	"package-operator.run/internal/packages/internal/packagetypes"
 	"package-operator.run/internal/packages/internal/packagevalidation"
+	"package-operator.run/internal/utils"
 )
 
 var ErrNonExisting = errors.New("unable to validate non existing package")
@@ -120,12 +121,39 @@ func ImageWithDigest(reference string, digest string) (string, error) {
 	return ref.Context().Digest(digest).String(), nil
 }
 
+type DeployConfig struct {
+	// Configuration loaded from outside the package object,
+	// inline package configuration takes precedence.
+	ConfigFrom map[string]any
+}
+
+func (c *DeployConfig) Option(opts ...DeployOption) {
+	for _, opt := range opts {
+		opt.ConfigureDeploy(c)
+	}
+}
+
+type DeployOption interface {
+	ConfigureDeploy(*DeployConfig)
+}
+
+// Adds configuration resolved from the ConfigMaps and Secrets referenced by the package.
+type WithConfigFrom map[string]any
+
+func (w WithConfigFrom) ConfigureDeploy(c *DeployConfig) {
+	c.ConfigFrom = w
+}
+
 func (l *PackageDeployer) Deploy(
 	ctx context.Context,
 	apiPkg adapters.GenericPackageAccessor,
 	rawPkg *packagetypes.RawPackage,
 	env manifests.PackageEnvironment,
+	opts ...DeployOption,
 ) error {
+	var cfg DeployConfig
+	cfg.Option(opts...)
+
 	pkg, err := l.structuralLoader.LoadComponent(ctx, rawPkg, apiPkg.GetComponent())
 	if err != nil {
 		setInvalidConditionBasedOnLoadError(apiPkg, err)
@@ -140,12 +168,13 @@ func (l *PackageDeployer) Deploy(
 
 	// prepare package render/template context
 	tmplCtx := apiPkg.TemplateContext()
-	configuration := map[string]any{}
+	inlineConfiguration := map[string]any{}
 	if tmplCtx.Config != nil {
-		if err := json.Unmarshal(tmplCtx.Config.Raw, &configuration); err != nil {
+		if err := json.Unmarshal(tmplCtx.Config.Raw, &inlineConfiguration); err != nil {
 			return fmt.Errorf("unmarshal config: %w", err)
 		}
 	}
+	configuration := utils.MergeConfig(cfg.ConfigFrom, inlineConfiguration)
 	validationErrors, err := packagemanifestvalidation.AdmitPackageConfiguration(
 		ctx, configuration, pkg.Manifest, field.NewPath("spec", "config"))
 	if err != nil {
@@ -180,6 +209,18 @@ func (l *PackageDeployer) Deploy(
 		return nil
 	}
 
+	// add dependencies to install
+	dependencies, err := dependencyObjects(apiPkg, pkg, configuration)
+	if err != nil {
+		setInvalidConditionBasedOnLoadError(apiPkg, err)
+		return nil
+	}
+	if len(dependencies) > 0 {
+		pkgInstance.Objects = append(pkgInstance.Objects, dependencies...)
+		pkgInstance.Manifest.Spec.AvailabilityProbes = append(
+			pkgInstance.Manifest.Spec.AvailabilityProbes, dependencyProbes(apiPkg)...)
+	}
+
 	desiredDeploy, err := l.desiredObjectDeployment(ctx, apiPkg, pkgInstance)
 	if err != nil {
 		return fmt.Errorf("creating desired ObjectDeployment: %w", err)
@@ -223,6 +264,7 @@ func (l *PackageDeployer) desiredObjectDeployment(
 
 	deploy.SetTemplateSpec(packagerender.RenderObjectSetTemplateSpec(pkgInstance))
 	deploy.SetSelector(labels)
+	deploy.SetRolloutStrategy(pkg.GetRolloutStrategy())
 
 	if err := controllerutil.SetControllerReference(
 		pkg.ClientObject(), deploy.ClientObject(), l.scheme); err != nil {
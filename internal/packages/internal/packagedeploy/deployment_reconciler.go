@@ -112,6 +112,7 @@ func (r *DeploymentReconciler) Reconcile(
 		actualDeploy.ClientObject().SetLabels(labels)
 
 		actualDeploy.SetTemplateSpec(templateSpec)
+		actualDeploy.SetRolloutStrategy(desiredDeploy.GetRolloutStrategy())
 
 		err := r.client.Update(ctx, actualDeploy.ClientObject())
 		if err == nil {
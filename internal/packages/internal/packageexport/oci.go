@@ -2,63 +2,83 @@ package packageexport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
 	containerregistrypkgv1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 
 	"package-operator.run/internal/packages/internal/packagetypes"
 )
 
-// Exports the package as OCI (Open Container Image).
-func ToOCI(pkg *packagetypes.RawPackage) (containerregistrypkgv1.Image, error) {
-	// Hardcoded to linux/amd64 or kubernetes will refuse to pull the image on
-	// our target architecture. We will drop this after refactoring our in-cluster
-	// package loading process to make it architecture agnostic.
-	configFile := &containerregistrypkgv1.ConfigFile{
-		Architecture: "amd64",
-		OS:           "linux",
-		Config:       containerregistrypkgv1.Config{},
-		RootFS:       containerregistrypkgv1.RootFS{Type: "layers"},
-	}
-	image, err := mutate.ConfigFile(empty.Image, configFile)
-	if err != nil {
-		return nil, err
-	}
+// Format of exported package images.
+type Format string
 
-	subFiles := map[string][]byte{}
-	for k, v := range pkg.Files {
-		subFiles[addOCIPathPrefix(k)] = v
-	}
+const (
+	// Exports packages as linux/amd64 container image.
+	FormatImage Format = "image"
+	// Exports packages as OCI artifact with package-operator specific media types.
+	FormatArtifact Format = "artifact"
+	// Exports packages as multi-platform image index.
+	FormatIndex Format = "index"
+)
 
-	layer, err := crane.Layer(subFiles)
-	if err != nil {
-		return nil, err
-	}
+// ErrUnsupportedFormat is returned when a package can't be exported in the requested format.
+var ErrUnsupportedFormat = errors.New("unsupported export format")
 
-	image, err = mutate.AppendLayers(image, layer)
-	if err != nil {
-		return nil, fmt.Errorf("create image from layer: %w", err)
-	}
+// Platform of package images, when no other platform is requested.
+var defaultPlatform = containerregistrypkgv1.Platform{OS: "linux", Architecture: "amd64"}
 
-	image, err = mutate.Canonical(image)
-	if err != nil {
-		return nil, err
-	}
+// Image is an exported package image,
+// either a containerregistrypkgv1.Image or a containerregistrypkgv1.ImageIndex.
+type Image interface {
+	Digest() (containerregistrypkgv1.Hash, error)
+	MediaType() (types.MediaType, error)
+	RawManifest() ([]byte, error)
+}
 
-	return image, nil
+// Exporter exports packages as OCI images in the configured format.
+// The zero value exports linux/amd64 container images.
+type Exporter struct {
+	// Format of exported images, defaults to FormatImage.
+	Format Format
+	// Platforms listed in image indexes, defaults to linux/amd64.
+	// Only used with FormatIndex.
+	Platforms []containerregistrypkgv1.Platform
+}
+
+// Exports the package as OCI image in the configured format.
+func (e Exporter) ToOCI(pkg *packagetypes.RawPackage) (Image, error) {
+	switch e.Format {
+	case FormatImage, "":
+		return ToOCI(pkg)
+	case FormatArtifact:
+		return ToOCIArtifact(pkg)
+	case FormatIndex:
+		return ToOCIIndex(pkg, e.Platforms...)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, e.Format)
+	}
 }
 
 // Exports the given package to an OCI tar under the given name and tags.
-func ToOCIFile(dst string, tags []string, pkg *packagetypes.RawPackage) error {
-	image, err := ToOCI(pkg)
+// Image indexes can't be stored in tar files.
+func (e Exporter) ToOCIFile(dst string, tags []string, pkg *packagetypes.RawPackage) error {
+	exported, err := e.ToOCI(pkg)
 	if err != nil {
 		return err
 	}
+	image, ok := exported.(containerregistrypkgv1.Image)
+	if !ok {
+		return fmt.Errorf("%w: image indexes can't be written to tar files", ErrUnsupportedFormat)
+	}
 
 	m := map[string]containerregistrypkgv1.Image{}
 	for _, tag := range tags {
@@ -72,8 +92,10 @@ func ToOCIFile(dst string, tags []string, pkg *packagetypes.RawPackage) error {
 }
 
 // Exports the given package by pushing it to an OCI registry.
-func ToPushedOCI(ctx context.Context, references []string, pkg *packagetypes.RawPackage, opts ...crane.Option) error {
-	image, err := ToOCI(pkg)
+func (e Exporter) ToPushedOCI(
+	ctx context.Context, references []string, pkg *packagetypes.RawPackage, opts ...crane.Option,
+) error {
+	exported, err := e.ToOCI(pkg)
 	if err != nil {
 		return err
 	}
@@ -82,8 +104,7 @@ func ToPushedOCI(ctx context.Context, references []string, pkg *packagetypes.Raw
 	verboseLogger := logr.FromContextOrDiscard(ctx).V(1)
 	for _, ref := range references {
 		verboseLogger.Info("pushing image", "reference", ref)
-		err := crane.Push(image, ref, opts...)
-		if err != nil {
+		if err := push(exported, ref, opts...); err != nil {
 			return fmt.Errorf("push: %w", err)
 		}
 	}
@@ -91,6 +112,143 @@ func ToPushedOCI(ctx context.Context, references []string, pkg *packagetypes.Raw
 	return nil
 }
 
+func push(exported Image, ref string, opts ...crane.Option) error {
+	index, ok := exported.(containerregistrypkgv1.ImageIndex)
+	if !ok {
+		return crane.Push(exported.(containerregistrypkgv1.Image), ref, opts...)
+	}
+
+	o := crane.GetOptions(opts...)
+	tag, err := name.ParseReference(ref, o.Name...)
+	if err != nil {
+		return fmt.Errorf("parsing reference %q: %w", ref, err)
+	}
+	return remote.WriteIndex(tag, index, o.Remote...)
+}
+
+// Exports the package as OCI (Open Container Image).
+func ToOCI(pkg *packagetypes.RawPackage) (containerregistrypkgv1.Image, error) {
+	// Hardcoded to linux/amd64 or kubernetes will refuse to pull the image on
+	// our target architecture. Use ToOCIIndex or ToOCIArtifact for
+	// architecture agnostic package images.
+	return toPlatformImage(pkg, defaultPlatform)
+}
+
+// Exports the package as OCI artifact.
+// The artifact is platform independent and uses package-operator specific
+// config and layer media types, so it is not mistaken for a container image.
+func ToOCIArtifact(pkg *packagetypes.RawPackage) (containerregistrypkgv1.Image, error) {
+	configFile := &containerregistrypkgv1.ConfigFile{
+		RootFS: containerregistrypkgv1.RootFS{Type: "layers"},
+	}
+	image, err := mutate.ConfigFile(empty.Image, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	layer, err := packageLayer(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Not canonicalized, as that would reset the layer media type.
+	// The image is reproducible nonetheless, because no timestamps are set.
+	image, err = mutate.Append(image, mutate.Addendum{
+		Layer:     layer,
+		MediaType: packagetypes.PackageArtifactLayerMediaType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create image from layer: %w", err)
+	}
+
+	image = mutate.MediaType(image, types.OCIManifestSchema1)
+	image = mutate.ConfigMediaType(image, packagetypes.PackageArtifactConfigMediaType)
+
+	return image, nil
+}
+
+// Exports the package as multi-platform OCI image index,
+// containing one image for each of the given platforms.
+// Defaults to linux/amd64, if no platforms are given.
+func ToOCIIndex(
+	pkg *packagetypes.RawPackage, platforms ...containerregistrypkgv1.Platform,
+) (containerregistrypkgv1.ImageIndex, error) {
+	if len(platforms) == 0 {
+		platforms = []containerregistrypkgv1.Platform{defaultPlatform}
+	}
+
+	index := mutate.IndexMediaType(empty.Index, types.OCIImageIndex)
+	for _, platform := range platforms {
+		image, err := toPlatformImage(pkg, platform)
+		if err != nil {
+			return nil, err
+		}
+
+		index = mutate.AppendManifests(index, mutate.IndexAddendum{
+			Add: image,
+			Descriptor: containerregistrypkgv1.Descriptor{
+				Platform: &platform,
+			},
+		})
+	}
+
+	return index, nil
+}
+
+func toPlatformImage(
+	pkg *packagetypes.RawPackage, platform containerregistrypkgv1.Platform,
+) (containerregistrypkgv1.Image, error) {
+	configFile := &containerregistrypkgv1.ConfigFile{
+		Architecture: platform.Architecture,
+		OS:           platform.OS,
+		OSVersion:    platform.OSVersion,
+		Variant:      platform.Variant,
+		Config:       containerregistrypkgv1.Config{},
+		RootFS:       containerregistrypkgv1.RootFS{Type: "layers"},
+	}
+	image, err := mutate.ConfigFile(empty.Image, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	layer, err := packageLayer(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err = mutate.AppendLayers(image, layer)
+	if err != nil {
+		return nil, fmt.Errorf("create image from layer: %w", err)
+	}
+
+	image, err = mutate.Canonical(image)
+	if err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// Creates a layer containing all package files below the OCIPathPrefix.
+func packageLayer(pkg *packagetypes.RawPackage) (containerregistrypkgv1.Layer, error) {
+	subFiles := map[string][]byte{}
+	for k, v := range pkg.Files {
+		subFiles[addOCIPathPrefix(k)] = v
+	}
+
+	return crane.Layer(subFiles)
+}
+
+// Exports the given package to an OCI tar under the given name and tags.
+func ToOCIFile(dst string, tags []string, pkg *packagetypes.RawPackage) error {
+	return Exporter{}.ToOCIFile(dst, tags, pkg)
+}
+
+// Exports the given package by pushing it to an OCI registry.
+func ToPushedOCI(ctx context.Context, references []string, pkg *packagetypes.RawPackage, opts ...crane.Option) error {
+	return Exporter{}.ToPushedOCI(ctx, references, pkg, opts...)
+}
+
 func addOCIPathPrefix(path string) string {
 	return filepath.Join(packagetypes.OCIPathPrefix, path)
 }
@@ -3,12 +3,17 @@ package packageexport
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-containerregistry/pkg/crane"
+	containerregistrypkgv1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"package-operator.run/internal/packages/internal/packageimport"
 	"package-operator.run/internal/packages/internal/packagetypes"
 	"package-operator.run/internal/testutil"
 )
@@ -70,3 +75,133 @@ func TestToPushedOCI(t *testing.T) { //nolint:paralleltest
 	_, err = crane.Pull(ref, reg.CraneOpt)
 	require.NoError(t, err)
 }
+
+func TestToOCIArtifact(t *testing.T) {
+	t.Parallel()
+
+	rawPkg := &packagetypes.RawPackage{
+		Files: packagetypes.Files{"manifest.yaml": {5, 6}},
+	}
+
+	image, err := ToOCIArtifact(rawPkg)
+	require.NoError(t, err)
+
+	manifest, err := image.Manifest()
+	require.NoError(t, err)
+	assert.Equal(t, types.OCIManifestSchema1, manifest.MediaType)
+	assert.Equal(t, types.MediaType(packagetypes.PackageArtifactConfigMediaType), manifest.Config.MediaType)
+	require.Len(t, manifest.Layers, 1)
+	assert.Equal(t, types.MediaType(packagetypes.PackageArtifactLayerMediaType), manifest.Layers[0].MediaType)
+
+	// Exports must be reproducible to sign pushed images.
+	again, err := ToOCIArtifact(rawPkg)
+	require.NoError(t, err)
+	digest, err := image.Digest()
+	require.NoError(t, err)
+	againDigest, err := again.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, digest, againDigest)
+
+	imported, err := packageimport.FromOCI(context.Background(), image)
+	require.NoError(t, err)
+	assert.Equal(t, rawPkg.Files, imported.Files)
+}
+
+func TestToOCIIndex(t *testing.T) {
+	t.Parallel()
+
+	rawPkg := &packagetypes.RawPackage{
+		Files: packagetypes.Files{"manifest.yaml": {5, 6}},
+	}
+
+	for name, tc := range map[string]struct {
+		platforms []containerregistrypkgv1.Platform
+		expected  []containerregistrypkgv1.Platform
+	}{
+		"default": {
+			expected: []containerregistrypkgv1.Platform{{OS: "linux", Architecture: "amd64"}},
+		},
+		"multi-platform": {
+			platforms: []containerregistrypkgv1.Platform{
+				{OS: "linux", Architecture: "amd64"},
+				{OS: "linux", Architecture: "arm64", Variant: "v8"},
+			},
+			expected: []containerregistrypkgv1.Platform{
+				{OS: "linux", Architecture: "amd64"},
+				{OS: "linux", Architecture: "arm64", Variant: "v8"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			index, err := ToOCIIndex(rawPkg, tc.platforms...)
+			require.NoError(t, err)
+
+			indexManifest, err := index.IndexManifest()
+			require.NoError(t, err)
+			assert.Equal(t, types.OCIImageIndex, indexManifest.MediaType)
+
+			platforms := make([]containerregistrypkgv1.Platform, 0, len(indexManifest.Manifests))
+			for _, desc := range indexManifest.Manifests {
+				require.NotNil(t, desc.Platform)
+				platforms = append(platforms, *desc.Platform)
+
+				image, err := index.Image(desc.Digest)
+				require.NoError(t, err)
+				config, err := image.ConfigFile()
+				require.NoError(t, err)
+				assert.Equal(t, desc.Platform.Architecture, config.Architecture)
+				assert.Equal(t, desc.Platform.Variant, config.Variant)
+			}
+			assert.Equal(t, tc.expected, platforms)
+
+			imported, err := packageimport.FromOCIIndex(context.Background(), index)
+			require.NoError(t, err)
+			assert.Equal(t, rawPkg.Files, imported.Files)
+		})
+	}
+}
+
+func TestExporter_ToOCIFile_Index(t *testing.T) {
+	t.Parallel()
+
+	e := Exporter{Format: FormatIndex}
+	err := e.ToOCIFile(filepath.Join(t.TempDir(), "pkg.tar"), []string{"chickens:oldest"}, &packagetypes.RawPackage{})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestExporter_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := Exporter{Format: "banana"}.ToOCI(&packagetypes.RawPackage{})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestExporter_ToPushedOCI(t *testing.T) { //nolint:paralleltest
+	ctx := context.Background()
+
+	reg := testutil.NewInMemoryRegistry()
+
+	rawPkg := &packagetypes.RawPackage{
+		Files: packagetypes.Files{"manifest.yaml": {5, 6}},
+	}
+
+	for format, expectedMediaType := range map[Format]types.MediaType{
+		FormatArtifact: types.OCIManifestSchema1,
+		FormatIndex:    types.OCIImageIndex,
+	} {
+		ref := "chickens:" + string(format)
+
+		e := Exporter{Format: format}
+		require.NoError(t, e.ToPushedOCI(ctx, []string{ref}, rawPkg, reg.CraneOpt))
+
+		desc, err := crane.Get(ref, reg.CraneOpt)
+		require.NoError(t, err)
+		assert.Equal(t, expectedMediaType, desc.MediaType)
+
+		imported, err := packageimport.FromRegistry(ctx, ref, reg.CraneOpt)
+		require.NoError(t, err)
+		assert.Equal(t, rawPkg.Files, imported.Files)
+	}
+}
@@ -0,0 +1,381 @@
+package packageimport
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	containerregistrypkgv1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+const (
+	diskCacheFileSuffix = ".tar"
+	// Default size limit of a DiskCache: 512Mi.
+	DefaultDiskCacheMaxSize int64 = 512 * 1024 * 1024
+)
+
+// PackageCache stores RawPackages by image digest.
+type PackageCache interface {
+	// Returns the package stored for the given image digest.
+	Get(digest string) (*packagetypes.RawPackage, bool)
+	// Stores the package under the given image digest.
+	Put(digest string, rawPkg *packagetypes.RawPackage) error
+}
+
+// DiskCacheRecorder records metrics of a DiskCache.
+type DiskCacheRecorder interface {
+	RecordPackageCacheHit()
+	RecordPackageCacheMiss()
+	RecordPackageCacheEviction()
+	RecordPackageCacheSize(entries int, bytes int64)
+}
+
+// DiskCache is a content-addressed on-disk cache of package image contents.
+// Entries are keyed by image digest and the least recently used entries are
+// evicted when the total size exceeds the configured limit.
+// Entries survive restarts, as long as the directory is persisted.
+type DiskCache struct {
+	dir      string
+	maxSize  int64
+	recorder DiskCacheRecorder
+
+	lock    sync.Mutex
+	entries map[string]*diskCacheEntry
+	size    int64
+}
+
+type diskCacheEntry struct {
+	size     int64
+	lastUsed time.Time
+}
+
+// Creates a new DiskCache in the given directory,
+// picking up entries left over from previous runs.
+func NewDiskCache(dir string, opts ...DiskCacheOption) (*DiskCache, error) {
+	var cfg DiskCacheConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	c := &DiskCache{
+		dir:      dir,
+		maxSize:  cfg.MaxSize,
+		recorder: cfg.Recorder,
+		entries:  map[string]*diskCacheEntry{},
+	}
+	if err := c.load(); err != nil {
+		return nil, fmt.Errorf("loading package cache: %w", err)
+	}
+	return c, nil
+}
+
+type DiskCacheConfig struct {
+	// Maximum size of all cache entries in bytes.
+	MaxSize int64
+	// Optional recorder for cache metrics.
+	Recorder DiskCacheRecorder
+}
+
+func (c *DiskCacheConfig) Option(opts ...DiskCacheOption) {
+	for _, opt := range opts {
+		opt.ConfigureDiskCache(c)
+	}
+}
+
+func (c *DiskCacheConfig) Default() {
+	if c.MaxSize <= 0 {
+		c.MaxSize = DefaultDiskCacheMaxSize
+	}
+}
+
+type DiskCacheOption interface {
+	ConfigureDiskCache(*DiskCacheConfig)
+}
+
+// Maximum size of all cache entries in bytes.
+type WithMaxSize int64
+
+func (w WithMaxSize) ConfigureDiskCache(c *DiskCacheConfig) {
+	c.MaxSize = int64(w)
+}
+
+// Records cache metrics.
+type WithDiskCacheRecorder struct {
+	Recorder DiskCacheRecorder
+}
+
+func (w WithDiskCacheRecorder) ConfigureDiskCache(c *DiskCacheConfig) {
+	c.Recorder = w.Recorder
+}
+
+// Returns the package stored for the given image digest.
+func (c *DiskCache) Get(digest string) (*packagetypes.RawPackage, bool) {
+	key, err := diskCacheKey(digest)
+	if err != nil {
+		return nil, false
+	}
+
+	c.lock.Lock()
+	_, ok := c.entries[key]
+	c.lock.Unlock()
+	if !ok {
+		c.recordMiss()
+		return nil, false
+	}
+
+	rawPkg, err := c.read(key)
+	if err != nil {
+		// Corrupted or concurrently evicted, drop the entry.
+		c.remove(key)
+		c.recordMiss()
+		return nil, false
+	}
+
+	now := time.Now()
+	c.lock.Lock()
+	if entry, ok := c.entries[key]; ok {
+		entry.lastUsed = now
+	}
+	c.lock.Unlock()
+	// Persist the access time for LRU ordering across restarts.
+	_ = os.Chtimes(c.path(key), now, now)
+
+	c.recordHit()
+	return rawPkg, true
+}
+
+// Stores the package under the given image digest
+// and evicts least recently used entries to stay within the size limit.
+func (c *DiskCache) Put(digest string, rawPkg *packagetypes.RawPackage) error {
+	key, err := diskCacheKey(digest)
+	if err != nil {
+		return err
+	}
+
+	data, err := encodeRawPackage(rawPkg)
+	if err != nil {
+		return fmt.Errorf("encoding package: %w", err)
+	}
+	size := int64(len(data))
+	if size > c.maxSize {
+		// Would evict everything else and still not fit.
+		return nil
+	}
+
+	if err := c.write(key, data); err != nil {
+		return fmt.Errorf("writing package to cache: %w", err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		c.size -= entry.size
+	}
+	c.entries[key] = &diskCacheEntry{size: size, lastUsed: time.Now()}
+	c.size += size
+	c.evict(key)
+	c.recordSize()
+
+	return nil
+}
+
+// Evicts least recently used entries until the cache fits into its size limit.
+// The entry with the given key is kept. Must be called with the lock held.
+func (c *DiskCache) evict(keep string) {
+	if c.size <= c.maxSize {
+		return
+	}
+
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		if key != keep {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].lastUsed.Before(c.entries[keys[j]].lastUsed)
+	})
+
+	for _, key := range keys {
+		if c.size <= c.maxSize {
+			return
+		}
+		c.size -= c.entries[key].size
+		delete(c.entries, key)
+		_ = os.Remove(c.path(key))
+		if c.recorder != nil {
+			c.recorder.RecordPackageCacheEviction()
+		}
+	}
+}
+
+func (c *DiskCache) remove(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		c.size -= entry.size
+		delete(c.entries, key)
+	}
+	_ = os.Remove(c.path(key))
+	c.recordSize()
+}
+
+// Indexes existing entries and cleans up incomplete writes.
+func (c *DiskCache) load() error {
+	if err := os.MkdirAll(c.dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			continue
+		}
+		name := dirEntry.Name()
+		if !strings.HasSuffix(name, diskCacheFileSuffix) {
+			// Leftovers of interrupted writes.
+			_ = os.Remove(filepath.Join(c.dir, name))
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return err
+		}
+		key := strings.TrimSuffix(name, diskCacheFileSuffix)
+		c.entries[key] = &diskCacheEntry{size: info.Size(), lastUsed: info.ModTime()}
+		c.size += info.Size()
+	}
+	c.evict("")
+	c.recordSize()
+
+	return nil
+}
+
+func (c *DiskCache) read(key string) (*packagetypes.RawPackage, error) {
+	f, err := os.Open(c.path(key))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return decodeRawPackage(f)
+}
+
+// Writes to a temporary file first, so readers never observe partial entries.
+func (c *DiskCache) write(key string, data []byte) error {
+	f, err := os.CreateTemp(c.dir, key+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.path(key))
+}
+
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.dir, key+diskCacheFileSuffix)
+}
+
+func (c *DiskCache) recordHit() {
+	if c.recorder != nil {
+		c.recorder.RecordPackageCacheHit()
+	}
+}
+
+func (c *DiskCache) recordMiss() {
+	if c.recorder != nil {
+		c.recorder.RecordPackageCacheMiss()
+	}
+}
+
+// Must be called with the lock held.
+func (c *DiskCache) recordSize() {
+	if c.recorder != nil {
+		c.recorder.RecordPackageCacheSize(len(c.entries), c.size)
+	}
+}
+
+// Converts a digest like "sha256:abc" into a file name safe key like "sha256-abc".
+func diskCacheKey(digest string) (string, error) {
+	hash, err := containerregistrypkgv1.NewHash(digest)
+	if err != nil {
+		return "", fmt.Errorf("invalid digest %q: %w", digest, err)
+	}
+	return hash.Algorithm + "-" + hash.Hex, nil
+}
+
+func encodeRawPackage(rawPkg *packagetypes.RawPackage) ([]byte, error) {
+	paths := make([]string, 0, len(rawPkg.Files))
+	for path := range rawPkg.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for _, path := range paths {
+		data := rawPkg.Files[path]
+		if err := w.WriteHeader(&tar.Header{
+			Name: path,
+			Mode: 0o600,
+			Size: int64(len(data)),
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeRawPackage(r io.Reader) (*packagetypes.RawPackage, error) {
+	files := packagetypes.Files{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[hdr.Name] = data
+	}
+	return &packagetypes.RawPackage{Files: files}, nil
+}
@@ -0,0 +1,141 @@
+package packageimport
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+func testDigest(n string) string {
+	return "sha256:" + strings.Repeat("0", 64-len(n)) + n
+}
+
+func TestDiskCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir)
+	require.NoError(t, err)
+
+	rawPkg := &packagetypes.RawPackage{Files: packagetypes.Files{
+		"manifest.yaml":    []byte("test"),
+		"deploy/test.yaml": []byte("test2"),
+	}}
+
+	_, ok := c.Get(testDigest("1"))
+	assert.False(t, ok)
+
+	require.NoError(t, c.Put(testDigest("1"), rawPkg))
+
+	cached, ok := c.Get(testDigest("1"))
+	require.True(t, ok)
+	assert.Equal(t, rawPkg, cached)
+
+	// Entries survive restarts.
+	c, err = NewDiskCache(dir)
+	require.NoError(t, err)
+	cached, ok = c.Get(testDigest("1"))
+	require.True(t, ok)
+	assert.Equal(t, rawPkg, cached)
+}
+
+func TestDiskCache_Eviction(t *testing.T) {
+	t.Parallel()
+
+	rawPkg := &packagetypes.RawPackage{Files: packagetypes.Files{
+		"manifest.yaml": []byte("test"),
+	}}
+	data, err := encodeRawPackage(rawPkg)
+	require.NoError(t, err)
+
+	rm := &diskCacheRecorderMock{}
+	rm.On("RecordPackageCacheHit")
+	rm.On("RecordPackageCacheMiss")
+	rm.On("RecordPackageCacheEviction")
+	rm.On("RecordPackageCacheSize", mock.Anything, mock.Anything)
+
+	// Fits two entries.
+	c, err := NewDiskCache(t.TempDir(),
+		WithMaxSize(2*len(data)),
+		WithDiskCacheRecorder{Recorder: rm},
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Put(testDigest("1"), rawPkg))
+	require.NoError(t, c.Put(testDigest("2"), rawPkg))
+	// Use 1, so 2 is the least recently used entry.
+	_, ok := c.Get(testDigest("1"))
+	require.True(t, ok)
+	require.NoError(t, c.Put(testDigest("3"), rawPkg))
+
+	_, ok = c.Get(testDigest("1"))
+	assert.True(t, ok)
+	_, ok = c.Get(testDigest("2"))
+	assert.False(t, ok)
+	_, ok = c.Get(testDigest("3"))
+	assert.True(t, ok)
+
+	rm.AssertNumberOfCalls(t, "RecordPackageCacheEviction", 1)
+	rm.AssertNumberOfCalls(t, "RecordPackageCacheHit", 3)
+	rm.AssertNumberOfCalls(t, "RecordPackageCacheMiss", 1)
+	rm.AssertCalled(t, "RecordPackageCacheSize", 2, int64(2*len(data)))
+}
+
+func TestDiskCache_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "sha256-"+strings.Repeat("0", 63)+"1"+diskCacheFileSuffix),
+		[]byte("banana"), os.ModePerm))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "sha256-123-456.tmp"), []byte("banana"), os.ModePerm))
+
+	c, err := NewDiskCache(dir)
+	require.NoError(t, err)
+
+	_, ok := c.Get(testDigest("1"))
+	assert.False(t, ok)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestDiskCache_InvalidDigest(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewDiskCache(t.TempDir())
+	require.NoError(t, err)
+
+	require.Error(t, c.Put("../../etc/passwd", &packagetypes.RawPackage{}))
+	_, ok := c.Get("../../etc/passwd")
+	assert.False(t, ok)
+}
+
+type diskCacheRecorderMock struct {
+	mock.Mock
+}
+
+func (m *diskCacheRecorderMock) RecordPackageCacheHit() {
+	m.Called()
+}
+
+func (m *diskCacheRecorderMock) RecordPackageCacheMiss() {
+	m.Called()
+}
+
+func (m *diskCacheRecorderMock) RecordPackageCacheEviction() {
+	m.Called()
+}
+
+func (m *diskCacheRecorderMock) RecordPackageCacheSize(entries int, bytes int64) {
+	m.Called(entries, bytes)
+}
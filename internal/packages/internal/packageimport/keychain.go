@@ -0,0 +1,121 @@
+package packageimport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Builds a keychain from Secrets of type kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg.
+// Registries without matching credentials resolve to anonymous access.
+func NewSecretKeychain(secrets ...corev1.Secret) (authn.Keychain, error) {
+	kc := &secretKeychain{}
+	for _, secret := range secrets {
+		auths, err := dockerAuthsFromSecret(secret)
+		if err != nil {
+			return nil, fmt.Errorf("secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+
+		for key, auth := range auths {
+			registry, path := splitDockerAuthKey(key)
+			kc.entries = append(kc.entries, secretKeychainEntry{
+				registry: registry,
+				path:     path,
+				auth:     auth,
+			})
+		}
+	}
+
+	// Most specific path wins, keep secret order otherwise.
+	sort.SliceStable(kc.entries, func(i, j int) bool {
+		return len(kc.entries[i].path) > len(kc.entries[j].path)
+	})
+	return kc, nil
+}
+
+type secretKeychain struct {
+	entries []secretKeychainEntry
+}
+
+type secretKeychainEntry struct {
+	registry string
+	// optional repository path prefix.
+	path string
+	auth authn.AuthConfig
+}
+
+func (kc *secretKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	registry := normalizeRegistry(target.RegistryStr())
+
+	var repository string
+	if repo, ok := target.(name.Repository); ok {
+		repository = repo.RepositoryStr()
+	}
+
+	for _, entry := range kc.entries {
+		if entry.registry != registry {
+			continue
+		}
+		if entry.path != "" &&
+			repository != entry.path && !strings.HasPrefix(repository, entry.path+"/") {
+			continue
+		}
+		return authn.FromConfig(entry.auth), nil
+	}
+	return authn.Anonymous, nil
+}
+
+// Returns the registry auths by registry key stored in the given Secret.
+func dockerAuthsFromSecret(secret corev1.Secret) (map[string]authn.AuthConfig, error) {
+	switch secret.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		var config struct {
+			Auths map[string]authn.AuthConfig `json:"auths"`
+		}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", corev1.DockerConfigJsonKey, err)
+		}
+		return config.Auths, nil
+
+	case corev1.SecretTypeDockercfg:
+		var auths map[string]authn.AuthConfig
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &auths); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", corev1.DockerConfigKey, err)
+		}
+		return auths, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedSecretType, secret.Type)
+}
+
+// ErrUnsupportedSecretType is returned for Secrets that hold no registry credentials.
+var ErrUnsupportedSecretType = errors.New(
+	"unsupported secret type, expected kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg")
+
+// Splits docker config keys like "https://quay.io/org" into registry and repository path.
+func splitDockerAuthKey(key string) (registry, path string) {
+	key = strings.TrimPrefix(key, "https://")
+	key = strings.TrimPrefix(key, "http://")
+
+	registry, path, _ = strings.Cut(key, "/")
+	// Docker Hub keys commonly carry the API version, e.g. "https://index.docker.io/v1/".
+	path = strings.TrimSuffix(path, "/")
+	if path == "v1" || path == "v2" {
+		path = ""
+	}
+	return normalizeRegistry(registry), path
+}
+
+func normalizeRegistry(registry string) string {
+	switch registry {
+	case name.DefaultRegistry, "docker.io", "registry-1.docker.io":
+		return name.DefaultRegistry
+	}
+	return registry
+}
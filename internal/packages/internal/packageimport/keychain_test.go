@@ -0,0 +1,65 @@
+package packageimport
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretKeychain(t *testing.T) {
+	t.Parallel()
+
+	kc, err := NewSecretKeychain(
+		corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "quay", Namespace: "test"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{` +
+					`"quay.io":{"username":"quay","password":"quay"},` +
+					`"quay.io/private":{"username":"private","password":"private"}}}`),
+			},
+		},
+		corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hub", Namespace: "test"},
+			Type:       corev1.SecretTypeDockercfg,
+			Data: map[string][]byte{
+				corev1.DockerConfigKey: []byte(`{"https://index.docker.io/v1/":{"username":"hub","password":"hub"}}`),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	for ref, expectedUser := range map[string]string{
+		"quay.io/test/pkg":      "quay",
+		"quay.io/private/pkg":   "private",
+		"quay.io/privateer/pkg": "quay",
+		"docker.io/library/pkg": "hub",
+		"ghcr.io/test/pkg":      "",
+	} {
+		repo, err := name.NewRepository(ref)
+		require.NoError(t, err)
+
+		auth, err := kc.Resolve(repo)
+		require.NoError(t, err)
+
+		if expectedUser == "" {
+			assert.Equal(t, authn.Anonymous, auth, ref)
+			continue
+		}
+		cfg, err := auth.Authorization()
+		require.NoError(t, err)
+		assert.Equal(t, expectedUser, cfg.Username, ref)
+	}
+}
+
+func TestSecretKeychain_UnsupportedType(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSecretKeychain(corev1.Secret{Type: corev1.SecretTypeOpaque})
+	require.ErrorIs(t, err, ErrUnsupportedSecretType)
+}
@@ -16,7 +16,12 @@ import (
 	"package-operator.run/internal/packages/internal/packagetypes"
 )
 
+// ErrNoImageInIndex is returned when importing from an image index without images.
+var ErrNoImageInIndex = errors.New("image index contains no images")
+
 // Imports a RawPackage from the given OCI image.
+// Package images exported as OCI artifact are imported the same way,
+// because the package files are stored in a single tar layer either way.
 func FromOCI(ctx context.Context, image containerregistrypkgv1.Image) (
 	rawPkg *packagetypes.RawPackage, err error,
 ) {
@@ -67,6 +72,39 @@ func FromOCI(ctx context.Context, image containerregistrypkgv1.Image) (
 	}, nil
 }
 
+// Imports a RawPackage from the given OCI image index.
+// Package images of all platforms contain the same files,
+// so the package is imported from the first image in the index,
+// regardless of the platform it was built for.
+func FromOCIIndex(ctx context.Context, index containerregistrypkgv1.ImageIndex) (
+	*packagetypes.RawPackage, error,
+) {
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading index manifest: %w", err)
+	}
+
+	for _, desc := range indexManifest.Manifests {
+		switch {
+		case desc.MediaType.IsImage():
+			image, err := index.Image(desc.Digest)
+			if err != nil {
+				return nil, fmt.Errorf("reading image %s from index: %w", desc.Digest, err)
+			}
+			return FromOCI(ctx, image)
+
+		case desc.MediaType.IsIndex():
+			child, err := index.ImageIndex(desc.Digest)
+			if err != nil {
+				return nil, fmt.Errorf("reading index %s from index: %w", desc.Digest, err)
+			}
+			return FromOCIIndex(ctx, child)
+		}
+	}
+
+	return nil, ErrNoImageInIndex
+}
+
 func stripOCIPathPrefix(path string) (string, error) {
 	strippedPath, err := filepath.Rel(packagetypes.OCIPathPrefix, path)
 	if err != nil {
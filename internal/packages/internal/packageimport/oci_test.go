@@ -6,6 +6,9 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/testr"
+	containerregistrypkgv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -44,3 +47,37 @@ func TestFromOCI_EmptyImage(t *testing.T) {
 	_, err := FromOCI(ctx, image)
 	require.EqualError(t, err, packagetypes.ErrEmptyPackage.Error())
 }
+
+func TestFromOCIIndex(t *testing.T) {
+	t.Parallel()
+
+	image := testutil.BuildImage(t, map[string][]byte{
+		packagetypes.OCIPathPrefix + "/file.yaml": []byte(`test: test`),
+	})
+	index := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{Add: image})
+
+	for name, idx := range map[string]containerregistrypkgv1.ImageIndex{
+		"index":  index,
+		"nested": mutate.AppendManifests(empty.Index, mutate.IndexAddendum{Add: index}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := logr.NewContext(context.Background(), testr.New(t))
+			rawPkg, err := FromOCIIndex(ctx, idx)
+			require.NoError(t, err)
+
+			assert.Equal(t, packagetypes.Files{
+				"file.yaml": []byte(`test: test`),
+			}, rawPkg.Files)
+		})
+	}
+}
+
+func TestFromOCIIndex_Empty(t *testing.T) {
+	t.Parallel()
+
+	ctx := logr.NewContext(context.Background(), testr.New(t))
+	_, err := FromOCIIndex(ctx, empty.Index)
+	require.ErrorIs(t, err, ErrNoImageInIndex)
+}
@@ -0,0 +1,145 @@
+package packageimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	containerregistrypkgv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+
+	"package-operator.run/internal/packages/internal/packagetypes"
+)
+
+// PathType describes how a package is stored at a filesystem path.
+type PathType string
+
+const (
+	// Package source folder.
+	PathTypeFolder PathType = "Folder"
+	// OCI image layout directory, containing an oci-layout file.
+	PathTypeOCILayout PathType = "OCILayout"
+	// Image tar file, as written by `kubectl package build --output`.
+	PathTypeOCITarball PathType = "OCITarball"
+)
+
+// Name of the file marking a directory as OCI image layout.
+const ociLayoutFile = "oci-layout"
+
+// Detects how the package at the given path is stored.
+func DetectPathType(path string) (PathType, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return PathTypeOCITarball, nil
+	}
+
+	_, err = os.Stat(filepath.Join(path, ociLayoutFile))
+	switch {
+	case err == nil:
+		return PathTypeOCILayout, nil
+	case errors.Is(err, os.ErrNotExist):
+		return PathTypeFolder, nil
+	default:
+		return "", err
+	}
+}
+
+// Imports a RawPackage from the given path, which may either be
+// a package source folder, an OCI image layout directory or an image tar file.
+func FromPath(ctx context.Context, path string) (*packagetypes.RawPackage, error) {
+	pathType, err := DetectPathType(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch pathType {
+	case PathTypeOCILayout:
+		return FromOCILayout(ctx, path)
+	case PathTypeOCITarball:
+		return FromOCITarball(ctx, path)
+	default:
+		return FromFolder(ctx, path)
+	}
+}
+
+// ErrAmbiguousOCILayout is returned when an OCI image layout contains multiple images,
+// but not exactly one of them is annotated as package image.
+var ErrAmbiguousOCILayout = errors.New("can't determine package image in OCI layout")
+
+// Imports a RawPackage from the OCI image layout directory at the given path.
+// Layouts containing multiple images, like the ones written by `kubectl package mirror`,
+// need to mark the package image with the OCILayoutPackageAnnotation.
+func FromOCILayout(ctx context.Context, path string) (*packagetypes.RawPackage, error) {
+	index, err := layout.ImageIndexFromPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading OCI layout: %w", err)
+	}
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading OCI layout: %w", err)
+	}
+
+	desc, err := packageDescriptor(indexManifest.Manifests)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case desc.MediaType.IsImage():
+		image, err := index.Image(desc.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("reading image %s from OCI layout: %w", desc.Digest, err)
+		}
+		return FromOCI(ctx, image)
+
+	case desc.MediaType.IsIndex():
+		child, err := index.ImageIndex(desc.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("reading index %s from OCI layout: %w", desc.Digest, err)
+		}
+		return FromOCIIndex(ctx, child)
+	}
+	return nil, ErrNoImageInIndex
+}
+
+// Returns the descriptor annotated as package image,
+// or the only descriptor if the layout contains a single image.
+func packageDescriptor(descs []containerregistrypkgv1.Descriptor) (containerregistrypkgv1.Descriptor, error) {
+	var annotated []containerregistrypkgv1.Descriptor
+	for _, desc := range descs {
+		if _, ok := desc.Annotations[packagetypes.OCILayoutPackageAnnotation]; ok {
+			annotated = append(annotated, desc)
+		}
+	}
+
+	switch {
+	case len(annotated) == 1:
+		return annotated[0], nil
+	case len(annotated) > 1:
+		return containerregistrypkgv1.Descriptor{}, fmt.Errorf(
+			"%w: %d images are annotated with %s",
+			ErrAmbiguousOCILayout, len(annotated), packagetypes.OCILayoutPackageAnnotation)
+	case len(descs) == 0:
+		return containerregistrypkgv1.Descriptor{}, ErrNoImageInIndex
+	case len(descs) > 1:
+		return containerregistrypkgv1.Descriptor{}, fmt.Errorf(
+			"%w: %d images, none is annotated with %s",
+			ErrAmbiguousOCILayout, len(descs), packagetypes.OCILayoutPackageAnnotation)
+	}
+	return descs[0], nil
+}
+
+// Imports a RawPackage from the image tar file at the given path.
+// The file must contain a single image, which may be tagged multiple times.
+func FromOCITarball(ctx context.Context, path string) (*packagetypes.RawPackage, error) {
+	image, err := tarball.ImageFromPath(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading image tar: %w", err)
+	}
+	return FromOCI(ctx, image)
+}
@@ -0,0 +1,132 @@
+package packageimport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/packages/internal/packagetypes"
+	"package-operator.run/internal/testutil"
+)
+
+// Writes the same package as source folder, OCI layout and image tar.
+func writePackagePaths(t *testing.T) (folder, ociLayout, ociTarball string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	folder = filepath.Join(dir, "folder")
+	require.NoError(t, os.Mkdir(folder, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "file.yaml"), []byte(`test: test`), 0o600))
+
+	image := testutil.BuildImage(t, map[string][]byte{
+		packagetypes.OCIPathPrefix + "/file.yaml": []byte(`test: test`),
+	})
+
+	ociLayout = filepath.Join(dir, "layout")
+	p, err := layout.Write(ociLayout, empty.Index)
+	require.NoError(t, err)
+	require.NoError(t, p.AppendImage(image))
+
+	ociTarball = filepath.Join(dir, "pkg.tar")
+	tag, err := name.NewTag("quay.io/pkg:v1")
+	require.NoError(t, err)
+	require.NoError(t, tarball.WriteToFile(ociTarball, tag, image))
+
+	return folder, ociLayout, ociTarball
+}
+
+func TestDetectPathType(t *testing.T) {
+	t.Parallel()
+
+	folder, ociLayout, ociTarball := writePackagePaths(t)
+
+	for path, expected := range map[string]PathType{
+		folder:     PathTypeFolder,
+		ociLayout:  PathTypeOCILayout,
+		ociTarball: PathTypeOCITarball,
+	} {
+		pathType, err := DetectPathType(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, pathType, path)
+	}
+
+	_, err := DetectPathType(filepath.Join(folder, "dne"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFromPath(t *testing.T) {
+	t.Parallel()
+
+	folder, ociLayout, ociTarball := writePackagePaths(t)
+
+	for source, path := range map[string]string{
+		"folder":      folder,
+		"OCI layout":  ociLayout,
+		"OCI tarball": ociTarball,
+	} {
+		t.Run(source, func(t *testing.T) {
+			t.Parallel()
+
+			rawPkg, err := FromPath(context.Background(), path)
+			require.NoError(t, err)
+			assert.Equal(t, packagetypes.Files{
+				"file.yaml": []byte(`test: test`),
+			}, rawPkg.Files)
+		})
+	}
+}
+
+func TestFromOCILayout_multipleImages(t *testing.T) {
+	t.Parallel()
+
+	pkgImage := testutil.BuildImage(t, map[string][]byte{
+		packagetypes.OCIPathPrefix + "/file.yaml": []byte(`test: test`),
+	})
+	otherImage := testutil.BuildImage(t, map[string][]byte{"bin": {1, 2}})
+
+	for name, tc := range map[string]struct {
+		pkgAnnotations   map[string]string
+		otherAnnotations map[string]string
+		expectedErr      error
+	}{
+		"package annotated": {
+			pkgAnnotations: map[string]string{packagetypes.OCILayoutPackageAnnotation: "quay.io/pkg:v1"},
+		},
+		"none annotated": {
+			expectedErr: ErrAmbiguousOCILayout,
+		},
+		"all annotated": {
+			pkgAnnotations:   map[string]string{packagetypes.OCILayoutPackageAnnotation: "quay.io/pkg:v1"},
+			otherAnnotations: map[string]string{packagetypes.OCILayoutPackageAnnotation: "quay.io/other:v1"},
+			expectedErr:      ErrAmbiguousOCILayout,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "layout")
+			p, err := layout.Write(path, empty.Index)
+			require.NoError(t, err)
+			require.NoError(t, p.AppendImage(otherImage, layout.WithAnnotations(tc.otherAnnotations)))
+			require.NoError(t, p.AppendImage(pkgImage, layout.WithAnnotations(tc.pkgAnnotations)))
+
+			rawPkg, err := FromOCILayout(context.Background(), path)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, packagetypes.Files{
+				"file.yaml": []byte(`test: test`),
+			}, rawPkg.Files)
+		})
+	}
+}
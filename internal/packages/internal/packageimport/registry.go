@@ -2,20 +2,39 @@ package packageimport
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"sync"
 
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
 
 	"package-operator.run/internal/packages/internal/packagetypes"
 	"package-operator.run/internal/utils"
 )
 
 // Imports a RawPackage from a container image registry.
+// The reference may point to a package image, OCI artifact or image index.
 func FromRegistry(ctx context.Context, ref string, opts ...crane.Option) (
 	*packagetypes.RawPackage, error,
 ) {
-	img, err := crane.Pull(ref, opts...)
+	desc, err := crane.Get(ref, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if desc.MediaType.IsIndex() {
+		index, err := desc.ImageIndex()
+		if err != nil {
+			return nil, err
+		}
+		return FromOCIIndex(ctx, index)
+	}
+
+	img, err := desc.Image()
 	if err != nil {
 		return nil, err
 	}
@@ -25,10 +44,13 @@ func FromRegistry(ctx context.Context, ref string, opts ...crane.Option) (
 // Registry de-duplicates multiple parallel container image pulls.
 type Registry struct {
 	registryHostOverrides map[string]string
+	imageVerifier         ImageVerifier
+	cache                 PackageCache
 
-	pullImage    pullImageFn
-	inFlight     map[string][]chan<- response
-	inFlightLock sync.Mutex
+	pullImage     pullImageFn
+	resolveDigest resolveDigestFn
+	inFlight      map[string][]chan<- response
+	inFlightLock  sync.Mutex
 }
 
 type response struct {
@@ -39,26 +61,126 @@ type response struct {
 type pullImageFn func(
 	ctx context.Context, ref string, opts ...crane.Option) (*packagetypes.RawPackage, error)
 
+type resolveDigestFn func(
+	ctx context.Context, ref string, opts ...crane.Option) (name.Digest, error)
+
+// ImageVerifier checks an image before it is pulled.
+type ImageVerifier interface {
+	// Verifies the image and returns the digest reference to pull.
+	Verify(ctx context.Context, ref string, opts ...crane.Option) (name.Digest, error)
+}
+
 // Creates a new registry instance to de-duplicate parallel container image pulls.
-func NewRegistry(registryHostOverrides map[string]string) *Registry {
+func NewRegistry(registryHostOverrides map[string]string, opts ...RegistryOption) *Registry {
+	var cfg RegistryConfig
+
+	cfg.Option(opts...)
+
 	return &Registry{
 		registryHostOverrides: registryHostOverrides,
+		imageVerifier:         cfg.ImageVerifier,
+		cache:                 cfg.Cache,
 		pullImage:             FromRegistry,
+		resolveDigest:         resolveDigest,
 		inFlight:              make(map[string][]chan<- response),
 	}
 }
 
-func (r *Registry) Pull(ctx context.Context, image string) (*packagetypes.RawPackage, error) {
+type RegistryConfig struct {
+	// Verifies images before they are pulled, when set.
+	ImageVerifier ImageVerifier
+	// Caches pulled packages by image digest, when set.
+	Cache PackageCache
+}
+
+func (c *RegistryConfig) Option(opts ...RegistryOption) {
+	for _, opt := range opts {
+		opt.ConfigureRegistry(c)
+	}
+}
+
+type RegistryOption interface {
+	ConfigureRegistry(*RegistryConfig)
+}
+
+// Verifies images before they are pulled, e.g. by checking their signatures.
+type WithImageVerifier struct {
+	Verifier ImageVerifier
+}
+
+func (w WithImageVerifier) ConfigureRegistry(c *RegistryConfig) {
+	c.ImageVerifier = w.Verifier
+}
+
+// Caches pulled packages by image digest.
+type WithPackageCache struct {
+	Cache PackageCache
+}
+
+func (w WithPackageCache) ConfigureRegistry(c *RegistryConfig) {
+	c.Cache = w.Cache
+}
+
+type PullConfig struct {
+	// Secrets holding registry credentials.
+	PullSecrets []corev1.Secret
+}
+
+func (c *PullConfig) Option(opts ...PullOption) {
+	for _, opt := range opts {
+		opt.ConfigurePull(c)
+	}
+}
+
+type PullOption interface {
+	ConfigurePull(*PullConfig)
+}
+
+// Pulls images using the registry credentials in the given Secrets.
+// Falls back to the default keychain for registries without credentials in the Secrets.
+type WithPullSecrets []corev1.Secret
+
+func (w WithPullSecrets) ConfigurePull(c *PullConfig) {
+	c.PullSecrets = append(c.PullSecrets, w...)
+}
+
+func (r *Registry) Pull(
+	ctx context.Context, image string, opts ...PullOption,
+) (*packagetypes.RawPackage, error) {
+	var cfg PullConfig
+
+	cfg.Option(opts...)
+
 	image, err := r.applyOverride(image)
 	if err != nil {
 		return nil, err
 	}
 
-	res := <-r.handleRequest(ctx, image)
+	var craneOpts []crane.Option
+	if len(cfg.PullSecrets) > 0 {
+		keychain, err := NewSecretKeychain(cfg.PullSecrets...)
+		if err != nil {
+			return nil, fmt.Errorf("loading image pull secrets: %w", err)
+		}
+		craneOpts = append(craneOpts, crane.WithAuthFromKeychain(
+			authn.NewMultiKeychain(keychain, authn.DefaultKeychain)))
+	}
+
+	res := <-r.handleRequest(ctx, pullRequestKey(image, cfg.PullSecrets), image, craneOpts)
 
 	return res.RawPackage, res.Err
 }
 
+// Pulls are only de-duplicated when using the same credentials,
+// so callers can not piggyback on pulls they are not authorized for.
+func pullRequestKey(image string, pullSecrets []corev1.Secret) string {
+	key := image
+	for _, secret := range pullSecrets {
+		key += fmt.Sprintf("|%s/%s@%s", secret.Namespace, secret.Name, secret.ResourceVersion)
+	}
+	return key
+}
+
 func (r *Registry) applyOverride(image string) (string, error) {
 	for original, override := range r.registryHostOverrides {
 		if strings.HasPrefix(image, original) {
@@ -75,14 +197,16 @@ func (r *Registry) applyOverride(image string) (string, error) {
 // on the in flight pull requests, more specifically, a check if an image pull
 // is in flight after a pull attempt has started, but before the first receiver
 // is registered.
-func (r *Registry) handleRequest(ctx context.Context, image string) <-chan response {
+func (r *Registry) handleRequest(
+	ctx context.Context, key, image string, craneOpts []crane.Option,
+) <-chan response {
 	r.inFlightLock.Lock()
 	defer r.inFlightLock.Unlock()
 
-	if _, inFlight := r.inFlight[image]; !inFlight {
+	if _, inFlight := r.inFlight[key]; !inFlight {
 		go func(ctx context.Context, image string) {
-			rawPkg, err := r.pullImage(ctx, image)
-			r.handleResponse(image, response{
+			rawPkg, err := r.pull(ctx, image, craneOpts...)
+			r.handleResponse(key, response{
 				RawPackage: rawPkg,
 				Err:        err,
 			})
@@ -93,11 +217,70 @@ func (r *Registry) handleRequest(ctx context.Context, image string) <-chan respo
 	// is never blocked by a receiver.
 	recv := make(chan response, 1)
 
-	r.inFlight[image] = append(r.inFlight[image], recv)
+	r.inFlight[key] = append(r.inFlight[key], recv)
 
 	return recv
 }
 
+// pull verifies the image, if a verifier is configured, and pulls it by the
+// verified digest, so the image can not be swapped out between verification and pull.
+// When a cache is configured, images already cached under their digest are not pulled again.
+func (r *Registry) pull(
+	ctx context.Context, image string, opts ...crane.Option,
+) (*packagetypes.RawPackage, error) {
+	if r.imageVerifier == nil && r.cache == nil {
+		return r.pullImage(ctx, image, opts...)
+	}
+
+	var (
+		digest name.Digest
+		err    error
+	)
+	if r.imageVerifier != nil {
+		digest, err = r.imageVerifier.Verify(ctx, image, opts...)
+	} else {
+		digest, err = r.resolveDigest(ctx, image, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if r.cache == nil {
+		return r.pullImage(ctx, digest.String(), opts...)
+	}
+
+	if rawPkg, ok := r.cache.Get(digest.DigestStr()); ok {
+		return rawPkg, nil
+	}
+
+	rawPkg, err := r.pullImage(ctx, digest.String(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.cache.Put(digest.DigestStr(), rawPkg); err != nil {
+		// The package was pulled successfully, caching is best effort.
+		logr.FromContextOrDiscard(ctx).Error(err, "caching package", "image", digest.String())
+	}
+	return rawPkg, nil
+}
+
+// Resolves the given image reference to a digest reference.
+// The registry is always contacted, even for digest references,
+// to ensure the caller has access to the image before it is served from cache.
+func resolveDigest(ctx context.Context, ref string, opts ...crane.Option) (name.Digest, error) {
+	parsed, err := name.ParseReference(ref)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("parsing image reference: %w", err)
+	}
+
+	opts = append(opts, crane.WithContext(ctx))
+	rawDigest, err := crane.Digest(ref, opts...)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("resolving image digest: %w", err)
+	}
+	return parsed.Context().Digest(rawDigest), nil
+}
+
 // handleResponse broadcasts a response to all receivers listening
 // for a given image's pull request and then deletes the image's
 // entry allowing new requests to trigger a fresh pull. These
@@ -105,11 +288,11 @@ func (r *Registry) handleRequest(ctx context.Context, image string) <-chan respo
 // writes, more specifically, the registration of a new receiver
 // after broadcast has occurred, but before the image entry is
 // deleted.
-func (r *Registry) handleResponse(image string, res response) {
+func (r *Registry) handleResponse(key string, res response) {
 	r.inFlightLock.Lock()
 	defer r.inFlightLock.Unlock()
 
-	for _, recv := range r.inFlight[image] {
+	for _, recv := range r.inFlight[key] {
 		var rawPkg *packagetypes.RawPackage
 		if res.RawPackage != nil {
 			// DeepCopy to ensure clients can work concurrently on the returned files map.
@@ -121,5 +304,5 @@ func (r *Registry) handleResponse(image string, res response) {
 		}
 	}
 
-	delete(r.inFlight, image)
+	delete(r.inFlight, key)
 }
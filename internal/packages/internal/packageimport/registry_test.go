@@ -2,14 +2,19 @@ package packageimport
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"package-operator.run/internal/packages/internal/packagetypes"
 )
@@ -106,6 +111,141 @@ func TestRegistry_DelayedRequests(t *testing.T) {
 	}
 }
 
+func TestRegistry_VerifiedPull(t *testing.T) {
+	t.Parallel()
+
+	digest, err := name.NewDigest(
+		"quay.io/test123@sha256:" +
+			"0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	ivm := &imageVerifierMock{}
+	ivm.
+		On("Verify", mock.Anything, "quay.io/test123:latest", mock.Anything).
+		Return(digest, nil)
+
+	ipm := &imagePullerMock{}
+	pkg := &packagetypes.RawPackage{Files: packagetypes.Files{"test": []byte{}}}
+	ipm.
+		On("Pull", mock.Anything, mock.Anything, mock.Anything).
+		Return(pkg, nil)
+
+	r := NewRegistry(nil, WithImageVerifier{Verifier: ivm})
+	r.pullImage = ipm.Pull
+
+	_, err = r.Pull(context.Background(), "quay.io/test123:latest")
+	require.NoError(t, err)
+
+	// image is pulled by the verified digest.
+	ipm.AssertCalled(t, "Pull", mock.Anything, digest.String(), mock.Anything)
+}
+
+func TestRegistry_VerificationFailed(t *testing.T) {
+	t.Parallel()
+
+	verifyErr := errors.New("not signed")
+	ivm := &imageVerifierMock{}
+	ivm.
+		On("Verify", mock.Anything, mock.Anything, mock.Anything).
+		Return(name.Digest{}, verifyErr)
+
+	ipm := &imagePullerMock{}
+
+	r := NewRegistry(nil, WithImageVerifier{Verifier: ivm})
+	r.pullImage = ipm.Pull
+
+	_, err := r.Pull(context.Background(), "quay.io/test123:latest")
+	require.ErrorIs(t, err, verifyErr)
+	ipm.AssertNotCalled(t, "Pull", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRegistry_CachedPull(t *testing.T) {
+	t.Parallel()
+
+	digest, err := name.NewDigest(
+		"quay.io/test123@sha256:" +
+			"0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	cache, err := NewDiskCache(t.TempDir())
+	require.NoError(t, err)
+
+	ipm := &imagePullerMock{}
+	pkg := &packagetypes.RawPackage{Files: packagetypes.Files{"test": []byte("test")}}
+	ipm.
+		On("Pull", mock.Anything, mock.Anything, mock.Anything).
+		Return(pkg, nil)
+
+	r := NewRegistry(nil, WithPackageCache{Cache: cache})
+	r.pullImage = ipm.Pull
+	r.resolveDigest = func(context.Context, string, ...crane.Option) (name.Digest, error) {
+		return digest, nil
+	}
+
+	ctx := context.Background()
+	for range 2 {
+		rawPkg, err := r.Pull(ctx, "quay.io/test123:latest")
+		require.NoError(t, err)
+		assert.Equal(t, pkg, rawPkg)
+	}
+
+	// Second pull is served from cache.
+	ipm.AssertNumberOfCalls(t, "Pull", 1)
+	ipm.AssertCalled(t, "Pull", mock.Anything, digest.String(), mock.Anything)
+}
+
+func TestRegistry_PullSecrets(t *testing.T) {
+	t.Parallel()
+
+	ipm := &imagePullerMock{}
+	pkg := &packagetypes.RawPackage{Files: packagetypes.Files{"test": []byte("test")}}
+	ipm.
+		On("Pull", mock.Anything, mock.Anything, mock.Anything).
+		Return(pkg, nil)
+
+	r := NewRegistry(nil)
+	r.pullImage = ipm.Pull
+
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"quay.io":{"username":"test","password":"test"}}}`),
+		},
+	}
+	_, err := r.Pull(context.Background(), "quay.io/test123:latest", WithPullSecrets{secret})
+	require.NoError(t, err)
+
+	ipm.AssertCalled(t, "Pull", mock.Anything, "quay.io/test123:latest",
+		mock.MatchedBy(func(opts []crane.Option) bool { return len(opts) == 1 }))
+
+	_, err = r.Pull(context.Background(), "quay.io/test123:latest",
+		WithPullSecrets{corev1.Secret{Type: corev1.SecretTypeOpaque}})
+	require.ErrorIs(t, err, ErrUnsupportedSecretType)
+}
+
+func TestPullRequestKey(t *testing.T) {
+	t.Parallel()
+
+	secrets := []corev1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns", ResourceVersion: "1"},
+	}}
+	assert.Equal(t, "quay.io/test:latest", pullRequestKey("quay.io/test:latest", nil))
+	assert.Equal(t, "quay.io/test:latest|ns/test@1", pullRequestKey("quay.io/test:latest", secrets))
+}
+
+type imageVerifierMock struct {
+	mock.Mock
+}
+
+func (m *imageVerifierMock) Verify(
+	ctx context.Context, ref string,
+	opts ...crane.Option,
+) (name.Digest, error) {
+	args := m.Called(ctx, ref, opts)
+	return args.Get(0).(name.Digest), args.Error(1)
+}
+
 type imagePullerMock struct {
 	mock.Mock
 }
@@ -0,0 +1,668 @@
+package packagekickstart
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"testing/fstest"
+	"text/template"
+
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"pkg.package-operator.run/cardboard/kubeutils/kubemanifests"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/internal/packages/internal/packagekickstart/presets"
+	"package-operator.run/internal/packages/internal/packagetypes"
+	"package-operator.run/internal/transform"
+)
+
+const (
+	helmChartFile      = "Chart.yaml"
+	helmValuesFile     = "values.yaml"
+	helmSchemaFile     = "values.schema.json"
+	helmTemplatesDir   = "templates"
+	helmCRDsDir        = "crds"
+	helmChartsDir      = "charts"
+	helmCIValuesDir    = "ci"
+	helmHookAnnotation = "helm.sh/hook"
+
+	// Folder receiving templates that could not be translated automatically.
+	// Files in this folder are not picked up by package-operator.
+	untranslatedFolder = "untranslated"
+	untranslatedSuffix = ".todo"
+	helpersFilename    = "_helpers.gotmpl"
+)
+
+// Phases wrapping the well known phases to run Helm install and upgrade hooks.
+const (
+	PhaseHelmPreInstall  presets.Phase = "pre-install"
+	PhaseHelmPostInstall presets.Phase = "post-install"
+)
+
+// ErrInvalidHelmChart is returned when a Helm chart could not be loaded.
+var ErrInvalidHelmChart = errors.New("invalid Helm chart")
+
+type helmChartMetadata struct {
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	AppVersion   string `json:"appVersion"`
+	Dependencies []struct {
+		Name string `json:"name"`
+	} `json:"dependencies"`
+}
+
+// KickstartFromHelmChart converts the Helm chart at chartPath
+// - either a chart directory or a .tgz archive - into a package.
+// Templates are translated to package-operator templates where feasible,
+// templates needing manual work are reported via the KickstartResult.
+func KickstartFromHelmChart(ctx context.Context, pkgName, chartPath string) (
+	*packagetypes.RawPackage, KickstartResult, error,
+) {
+	chartFS, err := loadHelmChart(chartPath)
+	if err != nil {
+		return nil, KickstartResult{}, err
+	}
+	return kickstartHelmChart(ctx, pkgName, chartFS)
+}
+
+func kickstartHelmChart(_ context.Context, pkgName string, chartFS fs.FS) (
+	*packagetypes.RawPackage, KickstartResult, error,
+) {
+	res := KickstartResult{}
+	rawPkg := &packagetypes.RawPackage{
+		Files: packagetypes.Files{},
+	}
+
+	chartBytes, err := fs.ReadFile(chartFS, helmChartFile)
+	if err != nil {
+		return nil, res, fmt.Errorf("%w: reading %s: %w", ErrInvalidHelmChart, helmChartFile, err)
+	}
+	chart := &helmChartMetadata{}
+	if err := yaml.Unmarshal(chartBytes, chart); err != nil {
+		return nil, res, fmt.Errorf("%w: parsing %s: %w", ErrInvalidHelmChart, helmChartFile, err)
+	}
+	for _, dep := range chart.Dependencies {
+		res.UntranslatedTemplates = append(res.UntranslatedTemplates,
+			path.Join(helmChartsDir, dep.Name))
+	}
+
+	values := map[string]any{}
+	if b, err := fs.ReadFile(chartFS, helmValuesFile); err == nil {
+		if err := yaml.Unmarshal(b, &values); err != nil {
+			return nil, res, fmt.Errorf("%w: parsing %s: %w", ErrInvalidHelmChart, helmValuesFile, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, res, fmt.Errorf("reading %s: %w", helmValuesFile, err)
+	}
+
+	scheme, err := helmConfigSchema(chartFS, values)
+	if err != nil {
+		return nil, res, err
+	}
+
+	var (
+		usedPhases = map[string]struct{}{}
+		usedGKs    = map[schema.GroupKind]struct{}{}
+	)
+
+	// CRDs are shipped verbatim.
+	crdObjects, err := loadHelmCRDs(chartFS)
+	if err != nil {
+		return nil, res, err
+	}
+	for _, obj := range crdObjects {
+		gk := obj.GroupVersionKind().GroupKind()
+		phase := presets.DeterminePhase(gk)
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[manifestsv1alpha1.PackagePhaseAnnotation] = phase
+		obj.SetAnnotations(annotations)
+
+		b, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, res, fmt.Errorf("marshalling YAML: %w", err)
+		}
+		addFileWithCollisionPrevention(rawPkg.Files, phase, objectIdentity{
+			ObjectKey: client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()},
+			GroupKind: gk,
+		}, b, "yaml")
+		usedPhases[phase] = struct{}{}
+		usedGKs[gk] = struct{}{}
+		res.ObjectCount++
+	}
+
+	// Translate templates.
+	t := &helmTranslator{chart: chart}
+	err = fs.WalkDir(chartFS, helmTemplatesDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		b, err := fs.ReadFile(chartFS, p)
+		if err != nil {
+			return err
+		}
+
+		base := path.Base(p)
+		switch {
+		case strings.HasPrefix(base, "_"):
+			// Named template definitions.
+			translated, ok := t.translate(string(b))
+			if !ok {
+				addUntranslated(rawPkg.Files, &res, p, translated)
+				return nil
+			}
+			rawPkg.Files[helpersFilename] = append(rawPkg.Files[helpersFilename], []byte(strings.TrimSpace(translated)+"\n")...)
+			return nil
+
+		case !packagetypes.IsYAMLFile(p):
+			// NOTES.txt and other non manifest files.
+			return nil
+		}
+
+		for _, doc := range t.translateFile(string(b)) {
+			if !doc.ok {
+				addUntranslated(rawPkg.Files, &res, p, doc.content)
+				continue
+			}
+			if len(doc.skippedHook) > 0 {
+				res.SkippedHooks = append(res.SkippedHooks,
+					fmt.Sprintf("%s (%s)", p, doc.skippedHook))
+				continue
+			}
+
+			name := strings.TrimSuffix(base, path.Ext(base))
+			kind := doc.gk.Kind
+			if len(kind) == 0 {
+				kind = "object"
+			}
+			addFileWithCollisionPrevention(rawPkg.Files, doc.phase, objectIdentity{
+				ObjectKey: client.ObjectKey{Name: name},
+				GroupKind: schema.GroupKind{Group: doc.gk.Group, Kind: kind},
+			}, []byte(doc.content+"\n"), "yaml.gotmpl")
+			usedPhases[doc.phase] = struct{}{}
+			if len(doc.gk.Kind) > 0 {
+				usedGKs[doc.gk] = struct{}{}
+			}
+			res.ObjectCount++
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, res, fmt.Errorf("translating templates: %w", err)
+	}
+
+	// Generate Manifest
+	var phases []manifestsv1alpha1.PackageManifestPhase
+	for _, phase := range helmOrderedPhases() {
+		if _, ok := usedPhases[string(phase)]; ok {
+			phases = append(phases, manifestsv1alpha1.PackageManifestPhase{Name: string(phase)})
+		}
+	}
+
+	manifest := &manifestsv1alpha1.PackageManifest{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageManifest",
+			APIVersion: "manifests.package-operator.run/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: pkgName,
+		},
+		Spec: manifestsv1alpha1.PackageManifestSpec{
+			Phases: phases,
+			// Helm releases are always namespaced.
+			Scopes: []manifestsv1alpha1.PackageManifestScope{
+				manifestsv1alpha1.PackageManifestScopeNamespaced,
+			},
+			AvailabilityProbes: probesForGKs(usedGKs, &res),
+		},
+	}
+	if len(scheme.Properties) > 0 {
+		manifest.Spec.Config = manifestsv1alpha1.PackageManifestSpecConfig{
+			OpenAPIV3Schema: scheme,
+		}
+	}
+	tests, err := helmTestCases(chartFS)
+	if err != nil {
+		return nil, res, err
+	}
+	manifest.Test = manifestsv1alpha1.PackageManifestTest{
+		Template: tests,
+	}
+
+	b, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, res, fmt.Errorf("marshalling PackageManifest YAML: %w", err)
+	}
+	rawPkg.Files[packagetypes.PackageManifestFilename+".yaml"] = b
+
+	sort.Strings(res.UntranslatedTemplates)
+	sort.Strings(res.SkippedHooks)
+	return rawPkg, res, nil
+}
+
+// Helm pre-install hooks run before and post-install hooks after all other phases.
+func helmOrderedPhases() []presets.Phase {
+	phases := make([]presets.Phase, 0, len(presets.OrderedPhases)+2)
+	phases = append(phases, PhaseHelmPreInstall)
+	phases = append(phases, presets.OrderedPhases...)
+	return append(phases, PhaseHelmPostInstall)
+}
+
+func addUntranslated(files packagetypes.Files, res *KickstartResult, p, content string) {
+	dst := path.Join(untranslatedFolder, p+untranslatedSuffix)
+	if existing, ok := files[dst]; ok {
+		content = string(existing) + "\n---\n" + content
+	} else {
+		res.UntranslatedTemplates = append(res.UntranslatedTemplates, p)
+	}
+	files[dst] = []byte(content)
+}
+
+func loadHelmCRDs(chartFS fs.FS) ([]unstructured.Unstructured, error) {
+	var objects []unstructured.Unstructured
+	err := fs.WalkDir(chartFS, helmCRDsDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !packagetypes.IsYAMLFile(p) {
+			return nil
+		}
+		b, err := fs.ReadFile(chartFS, p)
+		if err != nil {
+			return err
+		}
+		objs, err := kubemanifests.LoadKubernetesObjectsFromBytes(b)
+		if err != nil {
+			return fmt.Errorf("loading CRDs from %s: %w", p, err)
+		}
+		objects = append(objects, objs...)
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// Builds the config schema from values.schema.json if present,
+// or infers it from values.yaml. Values are added as defaults.
+func helmConfigSchema(chartFS fs.FS, values map[string]any) (*v1.JSONSchemaProps, error) {
+	b, err := fs.ReadFile(chartFS, helmSchemaFile)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		s := schemaFromValue(values)
+		// The root object has no default.
+		s.Default = nil
+		s.XPreserveUnknownFields = nil
+		return &s, nil
+
+	case err != nil:
+		return nil, fmt.Errorf("reading %s: %w", helmSchemaFile, err)
+	}
+
+	s := &v1.JSONSchemaProps{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("%w: parsing %s: %w", ErrInvalidHelmChart, helmSchemaFile, err)
+	}
+	// Meta fields are not allowed in OpenAPIV3Schema.
+	s.Schema = ""
+	s.ID = ""
+	if err := addSchemaDefaults(s, values); err != nil {
+		return nil, err
+	}
+	if s.Properties == nil {
+		s.Properties = map[string]v1.JSONSchemaProps{}
+	}
+	return s, nil
+}
+
+// Infers a schema from a value of values.yaml, using the value as default.
+func schemaFromValue(value any) v1.JSONSchemaProps {
+	var s v1.JSONSchemaProps
+	switch v := value.(type) {
+	case map[string]any:
+		s = v1.JSONSchemaProps{
+			Type:    "object",
+			Default: &v1.JSON{Raw: []byte("{}")},
+		}
+		if len(v) == 0 {
+			// Empty maps, like nodeSelector: {}, take arbitrary keys.
+			s.XPreserveUnknownFields = ptr.To(true)
+			return s
+		}
+		s.Properties = map[string]v1.JSONSchemaProps{}
+		for k, pv := range v {
+			s.Properties[k] = schemaFromValue(pv)
+		}
+		return s
+
+	case []any:
+		s = v1.JSONSchemaProps{Type: "array"}
+		items := v1.JSONSchemaProps{XPreserveUnknownFields: ptr.To(true)}
+		if len(v) > 0 {
+			items = schemaFromValue(v[0])
+			items.Default = nil
+		}
+		s.Items = &v1.JSONSchemaPropsOrArray{Schema: &items}
+
+	case string:
+		s = v1.JSONSchemaProps{Type: "string"}
+	case bool:
+		s = v1.JSONSchemaProps{Type: "boolean"}
+	case float64:
+		if v == float64(int64(v)) {
+			s = v1.JSONSchemaProps{Type: "integer"}
+		} else {
+			s = v1.JSONSchemaProps{Type: "number"}
+		}
+	case nil:
+		return v1.JSONSchemaProps{
+			Nullable:               true,
+			XPreserveUnknownFields: ptr.To(true),
+		}
+	default:
+		s = v1.JSONSchemaProps{XPreserveUnknownFields: ptr.To(true)}
+	}
+
+	if b, err := json.Marshal(value); err == nil {
+		s.Default = &v1.JSON{Raw: b}
+	}
+	return s
+}
+
+// Sets defaults from values.yaml on properties without a default.
+func addSchemaDefaults(s *v1.JSONSchemaProps, values map[string]any) error {
+	for k, v := range values {
+		prop, ok := s.Properties[k]
+		if !ok {
+			continue
+		}
+
+		if nested, isMap := v.(map[string]any); isMap && len(prop.Properties) > 0 {
+			if err := addSchemaDefaults(&prop, nested); err != nil {
+				return err
+			}
+			if prop.Default == nil {
+				prop.Default = &v1.JSON{Raw: []byte("{}")}
+			}
+		} else if prop.Default == nil && v != nil {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return fmt.Errorf("marshalling default of %s: %w", k, err)
+			}
+			prop.Default = &v1.JSON{Raw: b}
+		}
+		s.Properties[k] = prop
+	}
+	return nil
+}
+
+// Generates a test case for every values file in the ci folder
+// following the convention of the chart-testing tool.
+func helmTestCases(chartFS fs.FS) ([]manifestsv1alpha1.PackageManifestTestCaseTemplate, error) {
+	tests := []manifestsv1alpha1.PackageManifestTestCaseTemplate{
+		{Name: "defaults", Context: helmTestContext(nil)},
+	}
+
+	entries, err := fs.ReadDir(chartFS, helmCIValuesDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tests, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", helmCIValuesDir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !packagetypes.IsYAMLFile(entry.Name()) {
+			continue
+		}
+		b, err := fs.ReadFile(chartFS, path.Join(helmCIValuesDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		config, err := yaml.YAMLToJSON(b)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parsing %s: %w", ErrInvalidHelmChart, entry.Name(), err)
+		}
+
+		name := strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+		name = strings.TrimSuffix(name, "-values")
+		tests = append(tests, manifestsv1alpha1.PackageManifestTestCaseTemplate{
+			Name:    name,
+			Context: helmTestContext(&runtime.RawExtension{Raw: config}),
+		})
+	}
+	return tests, nil
+}
+
+func helmTestContext(config *runtime.RawExtension) manifestsv1alpha1.TemplateContext {
+	return manifestsv1alpha1.TemplateContext{
+		Package: manifestsv1alpha1.TemplateContextPackage{
+			TemplateContextObjectMeta: manifestsv1alpha1.TemplateContextObjectMeta{
+				Name:      "test",
+				Namespace: "test",
+			},
+		},
+		Config: config,
+	}
+}
+
+type helmTranslator struct {
+	chart *helmChartMetadata
+}
+
+type helmDocument struct {
+	content     string
+	ok          bool
+	phase       string
+	gk          schema.GroupKind
+	skippedHook string
+}
+
+var (
+	helmActionRegEx     = regexp.MustCompile(`(?s){{.*?}}`)
+	helmKindRegEx       = regexp.MustCompile(`(?m)^kind:\s*["']?([A-Za-z0-9]+)["']?\s*$`)
+	helmAPIVersionRegEx = regexp.MustCompile(`(?m)^apiVersion:\s*["']?([a-z0-9./-]+)["']?\s*$`)
+	helmHookRegEx       = regexp.MustCompile(`(?m)^\s*["']?` + regexp.QuoteMeta(helmHookAnnotation) +
+		`["']?:\s*["']?([a-z,\- ]+)["']?\s*$`)
+
+	// Helm built-in objects and functions with a direct equivalent.
+	helmReplacements = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{regexp.MustCompile(`\.Values\b`), ".config"},
+		{regexp.MustCompile(`\.Release\.Name\b`), ".package.metadata.name"},
+		{regexp.MustCompile(`\.Release\.Namespace\b`), ".package.metadata.namespace"},
+		{regexp.MustCompile(`(\$[A-Za-z0-9_]*)?\.Release\.Service\b`), `"package-operator"`},
+		{regexp.MustCompile(`\btoYaml\b`), "toYAML"},
+		{regexp.MustCompile(`\bfromYaml\b`), "fromYAML"},
+	}
+	// Chart metadata is static and replaced by literals.
+	helmChartRegEx = regexp.MustCompile(`(\$[A-Za-z0-9_]*)?\.Chart\.(Name|Version|AppVersion)\b`)
+	// Helm built-in objects without equivalent.
+	helmUnsupportedRegEx = regexp.MustCompile(
+		`\.(Files|Capabilities|Template|Subcharts|Release\.(IsInstall|IsUpgrade|Revision))\b`)
+)
+
+// Splits a template file into documents and translates each of them.
+// Files with control structures spanning multiple documents are kept in one piece.
+func (t *helmTranslator) translateFile(content string) []helmDocument {
+	parts := packagetypes.SplitYAMLDocuments([]byte(content))
+	docs := make([]helmDocument, 0, len(parts))
+	for _, part := range parts {
+		if len(part) == 0 {
+			continue
+		}
+		doc := t.translateDocument(string(part))
+		if !doc.ok && len(parts) > 1 {
+			// Maybe a range or if block spans multiple documents.
+			whole := t.translateDocument(content)
+			if whole.ok {
+				return []helmDocument{whole}
+			}
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func (t *helmTranslator) translateDocument(content string) helmDocument {
+	translated, ok := t.translate(content)
+	doc := helmDocument{
+		content: strings.TrimSpace(translated),
+		ok:      ok,
+		phase:   string(presets.PhaseOther),
+	}
+
+	if m := helmKindRegEx.FindStringSubmatch(content); m != nil {
+		doc.gk.Kind = m[1]
+		if av := helmAPIVersionRegEx.FindStringSubmatch(content); av != nil {
+			doc.gk.Group = schema.FromAPIVersionAndKind(av[1], m[1]).Group
+		}
+		doc.phase = presets.DeterminePhase(doc.gk)
+	}
+
+	if m := helmHookRegEx.FindStringSubmatch(content); m != nil {
+		phase, ok := helmHookPhase(m[1])
+		if !ok {
+			doc.skippedHook = strings.TrimSpace(m[1])
+			return doc
+		}
+		doc.phase = string(phase)
+	}
+	return doc
+}
+
+// Maps helm.sh/hook annotation values to a phase.
+// Returns false for hooks that have no equivalent, e.g. delete, rollback and test hooks.
+func helmHookPhase(hooks string) (presets.Phase, bool) {
+	for _, hook := range strings.Split(hooks, ",") {
+		switch strings.TrimSpace(hook) {
+		case "pre-install", "pre-upgrade":
+			return PhaseHelmPreInstall, true
+		case "post-install", "post-upgrade":
+			return PhaseHelmPostInstall, true
+		}
+	}
+	return "", false
+}
+
+// Rewrites Helm specific references within template actions.
+// Returns false if the template uses constructs not available in package templates.
+func (t *helmTranslator) translate(content string) (string, bool) {
+	supported := true
+	translated := helmActionRegEx.ReplaceAllStringFunc(content, func(action string) string {
+		for _, r := range helmReplacements {
+			action = r.re.ReplaceAllString(action, r.repl)
+		}
+		action = helmChartRegEx.ReplaceAllStringFunc(action, func(ref string) string {
+			switch helmChartRegEx.FindStringSubmatch(ref)[2] {
+			case "Name":
+				return fmt.Sprintf("%q", t.chart.Name)
+			case "Version":
+				return fmt.Sprintf("%q", t.chart.Version)
+			default:
+				return fmt.Sprintf("%q", t.chart.AppVersion)
+			}
+		})
+		if helmUnsupportedRegEx.MatchString(action) {
+			supported = false
+		}
+		return action
+	})
+	if !supported {
+		return translated, false
+	}
+
+	// Parsing fails for functions not available in package templates, e.g. tpl, lookup or required.
+	tmpl := template.New("")
+	if _, err := tmpl.Funcs(transform.SprigFuncs(tmpl)).Parse(translated); err != nil {
+		return translated, false
+	}
+	return translated, true
+}
+
+// Loads a Helm chart from a directory or a .tgz archive.
+func loadHelmChart(chartPath string) (chartFS fs.FS, err error) {
+	i, err := os.Stat(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("accessing Helm chart: %w", err)
+	}
+	if i.IsDir() {
+		return os.DirFS(chartPath), nil
+	}
+
+	f, err := os.Open(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening Helm chart: %w", err)
+	}
+	defer func() { err = errors.Join(err, f.Close()) }()
+	return loadHelmChartArchive(f)
+}
+
+// Reads a gzipped chart archive into memory.
+// Archives contain a single top level folder named after the chart, which is stripped.
+func loadHelmChartArchive(r io.Reader) (fs.FS, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHelmChart, err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+
+	rawFS := fstest.MapFS{}
+	for {
+		hdr, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading archive: %w", ErrInvalidHelmChart, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		p := path.Clean(hdr.Name)
+		if strings.HasPrefix(p, "../") {
+			continue
+		}
+		_, p, found := strings.Cut(p, "/")
+		if !found {
+			continue
+		}
+
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading %s: %w", ErrInvalidHelmChart, hdr.Name, err)
+		}
+		rawFS[p] = &fstest.MapFile{Data: data}
+	}
+
+	if len(rawFS) == 0 {
+		return nil, packagetypes.ErrEmptyPackage
+	}
+	return rawFS, nil
+}
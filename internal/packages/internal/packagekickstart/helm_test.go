@@ -0,0 +1,354 @@
+package packagekickstart
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/internal/packages/internal/packagekickstart/presets"
+)
+
+const testHelmChartPath = "testdata/helm/mychart"
+
+func TestKickstartFromHelmChart(t *testing.T) {
+	t.Parallel()
+
+	rawPkg, res, err := KickstartFromHelmChart(context.Background(), "my-pkg", testHelmChartPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, res.ObjectCount)
+	assert.Equal(t, []string{"templates/configmap.yaml"}, res.UntranslatedTemplates)
+	assert.Equal(t, []string{"templates/tests/test-connection.yaml (test)"}, res.SkippedHooks)
+
+	files := make([]string, 0, len(rawPkg.Files))
+	for path := range rawPkg.Files {
+		files = append(files, path)
+	}
+	assert.ElementsMatch(t, []string{
+		"manifest.yaml",
+		"_helpers.gotmpl",
+		"crds/bananas.fruits.customresourcedefinition.yaml",
+		"deploy/deployment.deployment.yaml.gotmpl",
+		"deploy/service.service.yaml.gotmpl",
+		"pre-install/migrate-job.job.yaml.gotmpl",
+		"untranslated/templates/configmap.yaml.todo",
+	}, files)
+
+	assert.Equal(t, `{{- define "mychart.fullname" -}}
+{{- printf "%s-%s" .package.metadata.name "mychart" | trunc 63 | trimSuffix "-" }}
+{{- end }}
+`, string(rawPkg.Files["_helpers.gotmpl"]))
+	assert.Contains(t, string(rawPkg.Files["deploy/deployment.deployment.yaml.gotmpl"]),
+		`image: "{{ .config.image.repository }}:{{ .config.image.tag | default "1.16.0" }}"`)
+	assert.Contains(t, string(rawPkg.Files["deploy/deployment.deployment.yaml.gotmpl"]),
+		`{{- toYAML . | nindent 8 }}`)
+
+	manifest := &manifestsv1alpha1.PackageManifest{}
+	require.NoError(t, yaml.Unmarshal(rawPkg.Files["manifest.yaml"], manifest))
+	assert.Equal(t, "my-pkg", manifest.Name)
+	assert.Equal(t, []manifestsv1alpha1.PackageManifestPhase{
+		{Name: string(PhaseHelmPreInstall)},
+		{Name: string(presets.PhaseCRDs)},
+		{Name: string(presets.PhaseDeploy)},
+	}, manifest.Spec.Phases)
+	assert.Equal(t, []manifestsv1alpha1.PackageManifestScope{
+		manifestsv1alpha1.PackageManifestScopeNamespaced,
+	}, manifest.Spec.Scopes)
+
+	if assert.NotNil(t, manifest.Spec.Config.OpenAPIV3Schema) {
+		replicas := manifest.Spec.Config.OpenAPIV3Schema.Properties["replicaCount"]
+		assert.Equal(t, "integer", replicas.Type)
+		assert.JSONEq(t, `1`, string(replicas.Default.Raw))
+	}
+
+	if assert.Len(t, manifest.Test.Template, 2) {
+		assert.Equal(t, "defaults", manifest.Test.Template[0].Name)
+		assert.Equal(t, "ha", manifest.Test.Template[1].Name)
+		assert.JSONEq(t, `{"replicaCount":3}`, string(manifest.Test.Template[1].Context.Config.Raw))
+	}
+}
+
+func TestKickstartFromHelmChart_Archive(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	err := fs.WalkDir(os.DirFS(testHelmChartPath), ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(filepath.Join(testHelmChartPath, path))
+		if err != nil {
+			return err
+		}
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:     "mychart/" + path,
+			Typeflag: tar.TypeReg,
+			Mode:     0o644,
+			Size:     int64(len(data)),
+		}); err != nil {
+			return err
+		}
+		_, err = tarWriter.Write(data)
+		return err
+	})
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+
+	chartFS, err := loadHelmChartArchive(&buf)
+	require.NoError(t, err)
+
+	_, res, err := kickstartHelmChart(context.Background(), "my-pkg", chartFS)
+	require.NoError(t, err)
+	assert.Equal(t, 4, res.ObjectCount)
+}
+
+func TestKickstartFromHelmChart_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := KickstartFromHelmChart(context.Background(), "my-pkg", "testdata/helm")
+	require.ErrorIs(t, err, ErrInvalidHelmChart)
+
+	_, err = loadHelmChartArchive(bytes.NewBufferString("banana"))
+	require.ErrorIs(t, err, ErrInvalidHelmChart)
+}
+
+func TestHelmTranslator_translate(t *testing.T) {
+	t.Parallel()
+
+	tr := &helmTranslator{chart: &helmChartMetadata{
+		Name:       "mychart",
+		Version:    "0.1.0",
+		AppVersion: "1.0.0",
+	}}
+
+	for name, tc := range map[string]struct {
+		template   string
+		expected   string
+		expectedOK bool
+	}{
+		"plain": {
+			template:   "name: test",
+			expected:   "name: test",
+			expectedOK: true,
+		},
+		"values": {
+			template:   "replicas: {{ .Values.replicas }}\nimage: {{ $.Values.image }}",
+			expected:   "replicas: {{ .config.replicas }}\nimage: {{ $.config.image }}",
+			expectedOK: true,
+		},
+		"release": {
+			template:   "{{ .Release.Name }}/{{ .Release.Namespace }}/{{ $.Release.Service }}",
+			expected:   `{{ .package.metadata.name }}/{{ .package.metadata.namespace }}/{{ "package-operator" }}`,
+			expectedOK: true,
+		},
+		"chart": {
+			template:   "{{ .Chart.Name }}-{{ $.Chart.Version }}-{{ .Chart.AppVersion }}",
+			expected:   `{{ "mychart" }}-{{ "0.1.0" }}-{{ "1.0.0" }}`,
+			expectedOK: true,
+		},
+		"functions": {
+			template:   "{{- toYaml .Values.resources | nindent 2 }}",
+			expected:   "{{- toYAML .config.resources | nindent 2 }}",
+			expectedOK: true,
+		},
+		"files": {
+			template: `{{ .Files.Get "banana" }}`,
+			expected: `{{ .Files.Get "banana" }}`,
+		},
+		"unknown function": {
+			template: `{{ required "replicas is required" .Values.replicas }}`,
+			expected: `{{ required "replicas is required" .config.replicas }}`,
+		},
+		"lookup": {
+			template: `{{ lookup "v1" "Secret" .Release.Namespace "banana" }}`,
+			expected: `{{ lookup "v1" "Secret" .package.metadata.namespace "banana" }}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			translated, ok := tr.translate(tc.template)
+			assert.Equal(t, tc.expected, translated)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestHelmTranslator_translateFile(t *testing.T) {
+	t.Parallel()
+
+	tr := &helmTranslator{chart: &helmChartMetadata{Name: "mychart"}}
+
+	// range spanning multiple documents.
+	docs := tr.translateFile(`{{- range .Values.names }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ . }}
+{{- end }}
+`)
+	if assert.Len(t, docs, 1) {
+		assert.True(t, docs[0].ok)
+		assert.Equal(t, string(presets.PhaseDeploy), docs[0].phase)
+	}
+
+	docs = tr.translateFile(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: test
+  annotations:
+    helm.sh/hook: post-install
+`)
+	if assert.Len(t, docs, 2) {
+		assert.Equal(t, "apps", docs[0].gk.Group)
+		assert.Equal(t, "Deployment", docs[0].gk.Kind)
+		assert.Equal(t, string(presets.PhaseDeploy), docs[0].phase)
+		assert.Equal(t, string(PhaseHelmPostInstall), docs[1].phase)
+	}
+}
+
+func TestHelmHookPhase(t *testing.T) {
+	t.Parallel()
+
+	for hooks, tc := range map[string]struct {
+		expectedPhase presets.Phase
+		expectedOK    bool
+	}{
+		"pre-install":              {expectedPhase: PhaseHelmPreInstall, expectedOK: true},
+		"pre-upgrade":              {expectedPhase: PhaseHelmPreInstall, expectedOK: true},
+		"post-install":             {expectedPhase: PhaseHelmPostInstall, expectedOK: true},
+		"pre-delete, post-install": {expectedPhase: PhaseHelmPostInstall, expectedOK: true},
+		"pre-delete":               {},
+		"test":                     {},
+	} {
+		t.Run(hooks, func(t *testing.T) {
+			t.Parallel()
+
+			phase, ok := helmHookPhase(hooks)
+			assert.Equal(t, tc.expectedPhase, phase)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestSchemaFromValue(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		value    any
+		expected v1.JSONSchemaProps
+	}{
+		"string": {
+			value:    "banana",
+			expected: v1.JSONSchemaProps{Type: "string", Default: &v1.JSON{Raw: []byte(`"banana"`)}},
+		},
+		"integer": {
+			value:    float64(3),
+			expected: v1.JSONSchemaProps{Type: "integer", Default: &v1.JSON{Raw: []byte(`3`)}},
+		},
+		"number": {
+			value:    1.5,
+			expected: v1.JSONSchemaProps{Type: "number", Default: &v1.JSON{Raw: []byte(`1.5`)}},
+		},
+		"boolean": {
+			value:    true,
+			expected: v1.JSONSchemaProps{Type: "boolean", Default: &v1.JSON{Raw: []byte(`true`)}},
+		},
+		"null": {
+			value:    nil,
+			expected: v1.JSONSchemaProps{Nullable: true, XPreserveUnknownFields: ptr.To(true)},
+		},
+		"empty object": {
+			value: map[string]any{},
+			expected: v1.JSONSchemaProps{
+				Type:                   "object",
+				Default:                &v1.JSON{Raw: []byte(`{}`)},
+				XPreserveUnknownFields: ptr.To(true),
+			},
+		},
+		"empty array": {
+			value: []any{},
+			expected: v1.JSONSchemaProps{
+				Type: "array",
+				Items: &v1.JSONSchemaPropsOrArray{Schema: &v1.JSONSchemaProps{
+					XPreserveUnknownFields: ptr.To(true),
+				}},
+				Default: &v1.JSON{Raw: []byte(`[]`)},
+			},
+		},
+		"object": {
+			value: map[string]any{"tag": "v1", "ports": []any{float64(80)}},
+			expected: v1.JSONSchemaProps{
+				Type:    "object",
+				Default: &v1.JSON{Raw: []byte(`{}`)},
+				Properties: map[string]v1.JSONSchemaProps{
+					"tag": {Type: "string", Default: &v1.JSON{Raw: []byte(`"v1"`)}},
+					"ports": {
+						Type:    "array",
+						Items:   &v1.JSONSchemaPropsOrArray{Schema: &v1.JSONSchemaProps{Type: "integer"}},
+						Default: &v1.JSON{Raw: []byte(`[80]`)},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, schemaFromValue(tc.value))
+		})
+	}
+}
+
+func TestHelmConfigSchema_ValuesSchema(t *testing.T) {
+	t.Parallel()
+
+	chartFS := fstest.MapFS{
+		helmSchemaFile: &fstest.MapFile{Data: []byte(`{
+  "$schema": "https://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "replicaCount": {"type": "integer", "minimum": 1},
+    "image": {
+      "type": "object",
+      "properties": {
+        "tag": {"type": "string", "default": "latest"}
+      }
+    }
+  }
+}`)},
+	}
+
+	s, err := helmConfigSchema(chartFS, map[string]any{
+		"replicaCount": float64(2),
+		"image":        map[string]any{"tag": "v1"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, s.Schema)
+	assert.JSONEq(t, `2`, string(s.Properties["replicaCount"].Default.Raw))
+	assert.JSONEq(t, `{}`, string(s.Properties["image"].Default.Raw))
+	// Existing defaults are kept.
+	assert.JSONEq(t, `"latest"`, string(s.Properties["image"].Properties["tag"].Default.Raw))
+}
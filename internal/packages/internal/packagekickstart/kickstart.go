@@ -25,6 +25,10 @@ import (
 type KickstartResult struct {
 	ObjectCount             int
 	GroupKindsWithoutProbes []schema.GroupKind
+	// Helm templates and subcharts that could not be translated and need manual work.
+	UntranslatedTemplates []string
+	// Helm hooks without equivalent, that have been dropped.
+	SkippedHooks []string
 }
 
 func Kickstart(
@@ -131,19 +135,6 @@ func Kickstart(
 		}
 	}
 
-	probes := []corev1alpha1.ObjectSetProbe{}
-	gksWithoutProbes := map[schema.GroupKind]struct{}{}
-	for gk := range usedGKs {
-		if presets.NoProbe(gk) {
-			continue
-		}
-		probe, ok := presets.DetermineProbe(gk)
-		if !ok {
-			gksWithoutProbes[gk] = struct{}{}
-			continue
-		}
-		probes = append(probes, probe)
-	}
 	manifest := &manifestsv1alpha1.PackageManifest{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "PackageManifest",
@@ -158,7 +149,7 @@ func Kickstart(
 				manifestsv1alpha1.PackageManifestScopeCluster,
 				manifestsv1alpha1.PackageManifestScopeNamespaced,
 			},
-			AvailabilityProbes: probes,
+			AvailabilityProbes: probesForGKs(usedGKs, &res),
 		},
 	}
 	if len(scheme.Properties) > 0 {
@@ -181,9 +172,6 @@ func Kickstart(
 	rawPkg.Files[packagetypes.PackageManifestFilename+".yaml"] = b
 
 	// Result stats
-	for gk := range gksWithoutProbes {
-		res.GroupKindsWithoutProbes = append(res.GroupKindsWithoutProbes, gk)
-	}
 	res.ObjectCount = objCount
 
 	return rawPkg, res, nil
@@ -328,3 +316,21 @@ func addMissingNamespaces(
 	}
 	return nil
 }
+
+// Determines availability probes for the given GroupKinds
+// and records GroupKinds without known probe in the result.
+func probesForGKs(usedGKs map[schema.GroupKind]struct{}, res *KickstartResult) []corev1alpha1.ObjectSetProbe {
+	probes := []corev1alpha1.ObjectSetProbe{}
+	for gk := range usedGKs {
+		if presets.NoProbe(gk) {
+			continue
+		}
+		probe, ok := presets.DetermineProbe(gk)
+		if !ok {
+			res.GroupKindsWithoutProbes = append(res.GroupKindsWithoutProbes, gk)
+			continue
+		}
+		probes = append(probes, probe)
+	}
+	return probes
+}
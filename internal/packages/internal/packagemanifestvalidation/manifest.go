@@ -11,6 +11,7 @@ import (
 	"k8s.io/utils/strings/slices"
 	"pkg.package-operator.run/semver"
 
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/internal/apis/manifests"
 )
 
@@ -41,6 +42,11 @@ func ValidatePackageManifest(ctx context.Context, obj *manifests.PackageManifest
 				field.Invalid(specPhases.Index(i).Child("name"), phase.Name, "must be unique"))
 		}
 		phaseNames[phase.Name] = struct{}{}
+
+		if len(phase.Hook) > 0 && !slices.Contains(supportedHooks, string(phase.Hook)) {
+			allErrs = append(allErrs,
+				field.NotSupported(specPhases.Index(i).Child("hook"), string(phase.Hook), supportedHooks))
+		}
 	}
 
 	specProbes := field.NewPath("spec").Child("availabilityProbes")
@@ -72,6 +78,10 @@ func ValidatePackageManifest(ctx context.Context, obj *manifests.PackageManifest
 		}
 	}
 
+	// Dependencies
+	allErrs = append(allErrs, validateDependencies(
+		field.NewPath("spec").Child("dependencies"), obj.Spec.Dependencies, phaseNames)...)
+
 	// Constraints
 	allErrs = append(allErrs, validateConstraints(
 		field.NewPath("spec").Child("constraints"), obj.Spec.Constraints)...)
@@ -114,6 +124,44 @@ func ValidatePackageManifest(ctx context.Context, obj *manifests.PackageManifest
 	return allErrs, nil
 }
 
+var supportedHooks = []string{
+	string(corev1alpha1.ObjectSetPhaseHookPreInstall),
+	string(corev1alpha1.ObjectSetPhaseHookPreUpgrade),
+	string(corev1alpha1.ObjectSetPhaseHookPostUpgrade),
+	string(corev1alpha1.ObjectSetPhaseHookPreDelete),
+	string(corev1alpha1.ObjectSetPhaseHookPostDelete),
+}
+
+func validateDependencies(
+	path *field.Path, dependencies []manifests.PackageManifestDependency, phaseNames map[string]struct{},
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, dependency := range dependencies {
+		if dependency.Install == nil {
+			continue
+		}
+		ipath := path.Index(i).Child("install")
+		if dependency.Image == nil {
+			allErrs = append(allErrs,
+				field.Required(path.Index(i).Child("image"), "required to install dependency"))
+		}
+		if len(dependency.Install.Phase) == 0 {
+			allErrs = append(allErrs,
+				field.Required(ipath.Child("phase"), ""))
+		} else if _, ok := phaseNames[dependency.Install.Phase]; !ok {
+			allErrs = append(allErrs,
+				field.Invalid(ipath.Child("phase"), dependency.Install.Phase, "must reference a phase of this package"))
+		}
+		if ns := dependency.Install.Namespace; len(ns) > 0 {
+			if el := validation.IsDNS1123Label(ns); len(el) > 0 {
+				allErrs = append(allErrs,
+					field.Invalid(ipath.Child("namespace"), ns, strings.Join(el, ", ")))
+			}
+		}
+	}
+	return allErrs
+}
+
 func validateConstraints(path *field.Path, constraints []manifests.PackageManifestConstraint) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, constraint := range constraints {
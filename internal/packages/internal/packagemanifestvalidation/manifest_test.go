@@ -48,6 +48,43 @@ func TestValidatePackageManifest(t *testing.T) {
 				`spec.constraints[0].platformVersion.range: Invalid value: "banana": improper constraint`,
 			},
 		},
+		{
+			name: "invalid dependency install",
+			packageManifest: &manifests.PackageManifest{
+				Spec: manifests.PackageManifestSpec{
+					Phases: []manifests.PackageManifestPhase{
+						{Name: "deps"},
+					},
+					Dependencies: []manifests.PackageManifestDependency{
+						{
+							Install: &manifests.PackageManifestDependencyInstall{
+								Phase:     "banana",
+								Namespace: "Not_Valid",
+							},
+						},
+						{
+							Image:   &manifests.PackageManifestDependencyImage{Name: "test"},
+							Install: &manifests.PackageManifestDependencyInstall{},
+						},
+						{
+							Image:   &manifests.PackageManifestDependencyImage{Name: "test"},
+							Install: &manifests.PackageManifestDependencyInstall{Phase: "deps"},
+						},
+					},
+				},
+			},
+			expectedErrors: []string{
+				"metadata.name: Required value",
+				"spec.scopes: Required value",
+				"spec.dependencies[0].image: Required value: required to install dependency",
+				`spec.dependencies[0].install.phase: Invalid value: "banana": must reference a phase of this package`,
+				`spec.dependencies[0].install.namespace: Invalid value: "Not_Valid": ` +
+					"a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', " +
+					"and must start and end with an alphanumeric character " +
+					"(e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')",
+				"spec.dependencies[1].install.phase: Required value",
+			},
+		},
 		{
 			name: "duplicated phase",
 			packageManifest: &manifests.PackageManifest{
@@ -64,6 +101,23 @@ func TestValidatePackageManifest(t *testing.T) {
 				"spec.phases[1].name: Invalid value: \"test\": must be unique",
 			},
 		},
+		{
+			name: "invalid phase hook",
+			packageManifest: &manifests.PackageManifest{
+				Spec: manifests.PackageManifestSpec{
+					Phases: []manifests.PackageManifestPhase{
+						{Name: "test"},
+						{Name: "cleanup", Hook: "banana"},
+					},
+				},
+			},
+			expectedErrors: []string{
+				"metadata.name: Required value",
+				"spec.scopes: Required value",
+				`spec.phases[1].hook: Unsupported value: "banana": supported values: ` +
+					`"PreInstall", "PreUpgrade", "PostUpgrade", "PreDelete", "PostDelete"`,
+			},
+		},
 		{
 			name: "openAPI invalid template context",
 			packageManifest: &manifests.PackageManifest{
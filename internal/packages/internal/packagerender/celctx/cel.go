@@ -12,6 +12,7 @@ import (
 
 	"package-operator.run/internal/apis/manifests"
 	"package-operator.run/internal/packages/internal/packagetypes"
+	"package-operator.run/pkg/celext"
 )
 
 var (
@@ -44,12 +45,24 @@ type CelCtx struct {
 
 // New pre-evaluates the given named conditions against tmplCtx
 // and exposes both tmplCtx + named condition results to cel programs.
+// Expressions have access to the shared Package Operator CEL library
+// and are type checked against the template context schema.
 func New(conditions []manifests.PackageManifestNamedCondition,
 	tmplCtx packagetypes.PackageRenderContext,
 ) (*CelCtx, error) {
-	return newCelCtx(conditions, tmplCtx, unpackContext, cel.NewEnv)
+	return newCelCtx(conditions, tmplCtx, unpackContext, newEnv)
 }
 
+func newEnv(opts ...cel.EnvOption) (*cel.Env, error) {
+	return cel.NewEnv(append(celext.EnvOptions(), opts...)...)
+}
+
+// Schemas of the template context variables.
+var tmplCtxSchemas = celext.SchemaOf(reflect.TypeOf(packagetypes.PackageRenderContext{})).Fields
+
+// Name prefix of the CEL types declared for template context variables.
+const celVariableContainer = "package_operator.template"
+
 func newCelCtx(conditions []manifests.PackageManifestNamedCondition,
 	tmplCtx packagetypes.PackageRenderContext,
 	unpack unpackContextFn,
@@ -72,6 +85,7 @@ func newCelCtx(conditions []manifests.PackageManifestNamedCondition,
 	}
 
 	conditionsMap := map[string]bool{}
+	condSchema := &celext.Schema{Kind: celext.SchemaObject, Fields: map[string]*celext.Schema{}}
 	for _, m := range conditions {
 		// make sure condition name is allowed
 		if !conditionNameRegexp.MatchString(m.Name) {
@@ -90,10 +104,11 @@ func newCelCtx(conditions []manifests.PackageManifestNamedCondition,
 
 		// store evaluation result in context
 		conditionsMap[m.Name] = result
+		condSchema.Fields[m.Name] = &celext.Schema{Kind: celext.SchemaScalar, Type: cel.BoolType}
 	}
 
 	ctxMap["cond"] = conditionsMap
-	opts = append(opts, cel.Variable("cond", cel.MapType(cel.StringType, cel.BoolType)))
+	opts = append(opts, celext.Variables(celVariableContainer, map[string]*celext.Schema{"cond": condSchema}))
 
 	// recreate CEL environment with condition declarations
 	env, err = newEnv(opts...)
@@ -155,11 +170,7 @@ func unpackContext(tmplCtx packagetypes.PackageRenderContext) (map[string]any, [
 		return nil, nil, fmt.Errorf("context serialization error: %w", err)
 	}
 
-	opts := make([]cel.EnvOption, 0, len(ctxMap))
-	for k := range ctxMap {
-		opts = append(opts, cel.Variable(k, cel.MapType(cel.StringType, cel.AnyType)))
-	}
-	return ctxMap, opts, nil
+	return ctxMap, []cel.EnvOption{celext.Variables(celVariableContainer, tmplCtxSchemas)}, nil
 }
 
 func structToMap[T any](p T) (map[string]any, error) {
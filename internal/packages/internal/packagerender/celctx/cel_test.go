@@ -264,6 +264,70 @@ func Test_celCtx_evaluate(t *testing.T) {
 			expected: true,
 			err:      "",
 		},
+		{
+			name:        "semver comparison",
+			expression:  `semver.atLeast(environment.kubernetes.version, "1.29")`,
+			envProgram:  defaultEnvProgram(),
+			programEval: defaultProgramEval(),
+			tmplCtx: packagetypes.PackageRenderContext{
+				Environment: manifests.PackageEnvironment{
+					Kubernetes: manifests.PackageEnvironmentKubernetes{Version: "v1.30.3"},
+				},
+			},
+			expected: true,
+			err:      "",
+		},
+		{
+			name:        "optional lookup",
+			expression:  `.config.?banana.?color.orValue("yellow") == "yellow"`,
+			envProgram:  defaultEnvProgram(),
+			programEval: defaultProgramEval(),
+			tmplCtx: packagetypes.PackageRenderContext{
+				Config: map[string]any{"banana": map[string]any{}},
+			},
+			expected: true,
+			err:      "",
+		},
+		{
+			// Rendering must be reproducible.
+			name:        "time helpers are not available",
+			expression:  `time.now() > timestamp("2024-01-01T00:00:00Z")`,
+			envProgram:  defaultEnvProgram(),
+			programEval: defaultProgramEval(),
+			tmplCtx:     packagetypes.PackageRenderContext{},
+			expected:    false,
+			err:         ErrExpressionCompilation.Error(),
+		},
+		{
+			name:        "undefined template context field",
+			expression:  `environment.kubernetes.versoin == "v1.30.3"`,
+			envProgram:  defaultEnvProgram(),
+			programEval: defaultProgramEval(),
+			tmplCtx:     packagetypes.PackageRenderContext{},
+			expected:    false,
+			err:         "undefined field 'versoin'",
+		},
+		{
+			name:        "mismatching type",
+			expression:  `environment.kubernetes.version > 3`,
+			envProgram:  defaultEnvProgram(),
+			programEval: defaultProgramEval(),
+			tmplCtx:     packagetypes.PackageRenderContext{},
+			expected:    false,
+			err:         ErrExpressionCompilation.Error(),
+		},
+		{
+			name:        "undefined condition",
+			expression:  "cond.isBar",
+			envProgram:  defaultEnvProgram(),
+			programEval: defaultProgramEval(),
+			conditions: []manifests.PackageManifestNamedCondition{
+				{Name: "isFoo", Expression: "true"},
+			},
+			tmplCtx:  packagetypes.PackageRenderContext{},
+			expected: false,
+			err:      ErrExpressionCompilation.Error(),
+		},
 		{
 			name:        "fail program construction",
 			expression:  "false",
@@ -32,6 +32,7 @@ func newPhaseCollector(phases ...manifests.PackageManifestPhase) phaseCollector
 			Phase: corev1alpha1.ObjectSetTemplatePhase{
 				Name:  phase.Name,
 				Class: phase.Class,
+				Hook:  phase.Hook,
 			},
 		}
 	}
@@ -51,10 +52,14 @@ func (c phaseCollector) AddObjects(objs ...unstructured.Unstructured) {
 		annotations := object.GetAnnotations()
 		phaseAnnotation := annotations[manifestsv1alpha1.PackagePhaseAnnotation]
 		collisionProtectionAnnotation := annotations[manifestsv1alpha1.PackageCollisionProtectionAnnotation]
+		reconcileAnnotation := annotations[manifestsv1alpha1.PackageReconcileAnnotation]
+		deletionPolicyAnnotation := annotations[manifestsv1alpha1.PackageDeletionPolicyAnnotation]
 		delete(annotations, manifestsv1alpha1.PackagePhaseAnnotation)
 		delete(annotations, manifestsv1alpha1.PackageConditionMapAnnotation)
 		delete(annotations, manifestsv1alpha1.PackageCollisionProtectionAnnotation)
 		delete(annotations, manifestsv1alpha1.PackageCELConditionAnnotation)
+		delete(annotations, manifestsv1alpha1.PackageReconcileAnnotation)
+		delete(annotations, manifestsv1alpha1.PackageDeletionPolicyAnnotation)
 		if len(annotations) == 0 {
 			// This is important!
 			// When submitted to the API server empty maps will be dropped.
@@ -76,6 +81,8 @@ func (c phaseCollector) AddObjects(objs ...unstructured.Unstructured) {
 			Object:              object,
 			ConditionMappings:   conditionMapping,
 			CollisionProtection: corev1alpha1.CollisionProtection(collisionProtectionAnnotation),
+			Reconcile:           corev1alpha1.ObjectReconcileMode(reconcileAnnotation),
+			DeletionPolicy:      corev1alpha1.ObjectDeletionPolicy(deletionPolicyAnnotation),
 		}
 
 		c.addObjects(phaseAnnotation, objSetObj)
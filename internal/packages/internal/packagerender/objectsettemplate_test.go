@@ -8,8 +8,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/internal/apis/manifests"
 	"package-operator.run/internal/packages/internal/packageimport"
 	"package-operator.run/internal/packages/internal/packagestructure"
 	"package-operator.run/internal/packages/internal/packagetypes"
@@ -46,6 +48,56 @@ func TestTemplateSpecFromPackage(t *testing.T) {
 	}, objectsToKindNameString(spec.Phases[0].Objects))
 }
 
+func TestRenderObjectSetTemplateSpec_hooks(t *testing.T) {
+	t.Parallel()
+
+	newObj := func(name string, annotations map[string]string) unstructured.Unstructured {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetName(name)
+		obj.SetAnnotations(annotations)
+		return obj
+	}
+
+	pkgInstance := &packagetypes.PackageInstance{
+		Manifest: &manifests.PackageManifest{
+			Spec: manifests.PackageManifestSpec{
+				Phases: []manifests.PackageManifestPhase{
+					{Name: "migrate", Hook: v1alpha1.ObjectSetPhaseHookPreUpgrade},
+					{Name: "deploy"},
+					{Name: "cleanup", Class: "hosted-cluster", Hook: v1alpha1.ObjectSetPhaseHookPostUpgrade},
+				},
+			},
+		},
+		Objects: []unstructured.Unstructured{
+			newObj("migrate", map[string]string{
+				manifests.PackagePhaseAnnotation: "migrate",
+			}),
+			newObj("app", map[string]string{
+				manifests.PackagePhaseAnnotation: "deploy",
+			}),
+			newObj("cleanup", map[string]string{
+				manifests.PackagePhaseAnnotation: "cleanup",
+			}),
+		},
+	}
+
+	spec := RenderObjectSetTemplateSpec(pkgInstance)
+	require.Len(t, spec.Phases, 3)
+
+	assert.Equal(t, "migrate", spec.Phases[0].Name)
+	assert.Equal(t, v1alpha1.ObjectSetPhaseHookPreUpgrade, spec.Phases[0].Hook)
+	assert.Equal(t, []string{"/v1, Kind=ConfigMap /migrate"}, objectsToKindNameString(spec.Phases[0].Objects))
+
+	assert.Equal(t, "deploy", spec.Phases[1].Name)
+	assert.Empty(t, spec.Phases[1].Hook)
+
+	assert.Equal(t, "cleanup", spec.Phases[2].Name)
+	assert.Equal(t, v1alpha1.ObjectSetPhaseHookPostUpgrade, spec.Phases[2].Hook)
+	assert.Equal(t, "hosted-cluster", spec.Phases[2].Class)
+}
+
 func objectsToKindNameString(objects []v1alpha1.ObjectSetObject) []string {
 	out := make([]string, len(objects))
 	for i, obj := range objects {
@@ -13,7 +13,7 @@ import (
 // RepoLoader is an interface for somthing that takes a set PackageManifestRepositories and loads
 // thoses into a MultiRepositoryIndex.
 type RepoLoader func(
-	context.Context, []manifests.PackageManifestRepository,
+	context.Context, []manifests.PackageManifestRepository, ...crane.Option,
 ) (*packagerepository.MultiRepositoryIndex, error)
 
 // defaultRepoLoaderIfNil passes through r if it is not nil, elsewise it returns [loadRepo].
@@ -27,8 +27,10 @@ func defaultRepoLoaderIfNil(r RepoLoader) RepoLoader {
 
 // loadRepo pulls the given PackageManifestRepositories into a MultiRepositoryIndex.
 func loadRepo(
-	ctx context.Context, repos []manifests.PackageManifestRepository,
+	ctx context.Context, repos []manifests.PackageManifestRepository, opts ...crane.Option,
 ) (*packagerepository.MultiRepositoryIndex, error) {
+	opts = append(opts, crane.WithContext(ctx))
+
 	idx := packagerepository.NewMultiRepositoryIndex()
 	for _, r := range repos {
 		if r.File != "" {
@@ -38,7 +40,7 @@ func loadRepo(
 		}
 
 		if r.Image != "" {
-			image, err := crane.Pull(r.Image)
+			image, err := crane.Pull(r.Image, opts...)
 			if err != nil {
 				return idx, fmt.Errorf("pull repository image: %w", err)
 			}
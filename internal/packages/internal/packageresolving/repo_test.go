@@ -8,6 +8,7 @@ import (
 
 	"package-operator.run/internal/packages/internal/packagerepository"
 
+	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stretchr/testify/require"
@@ -99,7 +100,7 @@ func TestDefaultRepoLoader(t *testing.T) {
 	assert.Equal(t, reflect.ValueOf(RepoLoader(loadRepo)).Pointer(), reflect.ValueOf(rl).Pointer())
 
 	customLoader := func(
-		context.Context, []manifests.PackageManifestRepository,
+		context.Context, []manifests.PackageManifestRepository, ...crane.Option,
 	) (*packagerepository.MultiRepositoryIndex, error) {
 		return packagerepository.NewMultiRepositoryIndex(), nil
 	}
@@ -7,6 +7,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/google/go-containerregistry/pkg/crane"
 	"pkg.package-operator.run/semver"
 
 	"package-operator.run/internal/apis/manifests"
@@ -21,6 +22,9 @@ type BuildResolver struct {
 	// Loader pulls package repositories.
 	// Intended for mock testing, nil value tells the resolver to use the default loader.
 	Loader RepoLoader
+	// CraneOptions are passed on to the loader when pulling repository images,
+	// e.g. to authenticate using image pull secrets.
+	CraneOptions []crane.Option
 	// inst is the generated solver installation.
 	inst solver.Installation[struct{}, buildSD, buildCD]
 }
@@ -57,7 +61,7 @@ func (r *BuildResolver) AddManifest(
 	}
 
 	// Fetch all the repositories.
-	idx, err := mgr(ctx, pkg.Spec.Repositories)
+	idx, err := mgr(ctx, pkg.Spec.Repositories, r.CraneOptions...)
 	if err != nil {
 		return nil, err
 	}
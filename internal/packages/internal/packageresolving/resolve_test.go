@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -16,7 +17,9 @@ func TestResolveNothing(t *testing.T) {
 	t.Parallel()
 	idx := packages.NewMultiRepositoryIndex()
 	r := packageresolving.BuildResolver{
-		Loader: func(_ context.Context, _ []manifests.PackageManifestRepository) (*packages.MultiRepositoryIndex, error) {
+		Loader: func(
+			_ context.Context, _ []manifests.PackageManifestRepository, _ ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
 			return idx, nil
 		},
 	}
@@ -49,7 +52,9 @@ func TestResolveBasic(t *testing.T) {
 	}))
 
 	r := packageresolving.BuildResolver{
-		Loader: func(_ context.Context, _ []manifests.PackageManifestRepository) (*packages.MultiRepositoryIndex, error) {
+		Loader: func(
+			_ context.Context, _ []manifests.PackageManifestRepository, _ ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
 			return idx, nil
 		},
 	}
@@ -79,7 +84,9 @@ func TestResolveNoCandidatesAnyVersion(t *testing.T) {
 	idx := packages.NewMultiRepositoryIndex()
 
 	r := packageresolving.BuildResolver{
-		Loader: func(_ context.Context, _ []manifests.PackageManifestRepository) (*packages.MultiRepositoryIndex, error) {
+		Loader: func(
+			_ context.Context, _ []manifests.PackageManifestRepository, _ ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
 			return idx, nil
 		},
 	}
@@ -104,7 +111,9 @@ func TestResolveNoCandidatesSpecificVersion(t *testing.T) {
 	idx := packages.NewMultiRepositoryIndex()
 
 	r := packageresolving.BuildResolver{
-		Loader: func(_ context.Context, _ []manifests.PackageManifestRepository) (*packages.MultiRepositoryIndex, error) {
+		Loader: func(
+			_ context.Context, _ []manifests.PackageManifestRepository, _ ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
 			return idx, nil
 		},
 	}
@@ -148,7 +157,9 @@ func TestResolvePlatformMatches(t *testing.T) {
 	}))
 
 	r := packageresolving.BuildResolver{
-		Loader: func(_ context.Context, _ []manifests.PackageManifestRepository) (*packages.MultiRepositoryIndex, error) {
+		Loader: func(
+			_ context.Context, _ []manifests.PackageManifestRepository, _ ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
 			return idx, nil
 		},
 	}
@@ -196,7 +207,9 @@ func TestResolvePlatformNotMatching(t *testing.T) {
 	}))
 
 	r := packageresolving.BuildResolver{
-		Loader: func(_ context.Context, _ []manifests.PackageManifestRepository) (*packages.MultiRepositoryIndex, error) {
+		Loader: func(
+			_ context.Context, _ []manifests.PackageManifestRepository, _ ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
 			return idx, nil
 		},
 	}
@@ -236,7 +249,9 @@ func TestResolvePlatformNotSupported(t *testing.T) {
 	}))
 
 	r := packageresolving.BuildResolver{
-		Loader: func(_ context.Context, _ []manifests.PackageManifestRepository) (*packages.MultiRepositoryIndex, error) {
+		Loader: func(
+			_ context.Context, _ []manifests.PackageManifestRepository, _ ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
 			return idx, nil
 		},
 	}
@@ -279,7 +294,9 @@ func TestResolvePlatformBrokenVersion(t *testing.T) {
 	}))
 
 	r := packageresolving.BuildResolver{
-		Loader: func(_ context.Context, _ []manifests.PackageManifestRepository) (*packages.MultiRepositoryIndex, error) {
+		Loader: func(
+			_ context.Context, _ []manifests.PackageManifestRepository, _ ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
 			return idx, nil
 		},
 	}
@@ -0,0 +1,114 @@
+package packageresolving
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages/internal/packagerepository"
+)
+
+// ErrTrackedPackageNotFound is returned when a tracked package is not part of the repository.
+var ErrTrackedPackageNotFound = errors.New("package not found in repository")
+
+// TrackResolver resolves the latest version of a package tracked from a package repository.
+type TrackResolver struct {
+	// Loader pulls package repositories.
+	// Intended for mock testing, nil value tells the resolver to use the default loader.
+	Loader RepoLoader
+}
+
+// ResolvedVersion is the version of a tracked package resolved from a package repository.
+type ResolvedVersion struct {
+	// Semver version of the package.
+	Version string
+	// Image of the package, referenced by digest.
+	Image string
+}
+
+// Resolve pulls the given repository image and resolves the latest version of the
+// given package matching the semver range using the dependency solver.
+// The latest version is resolved, if the version range is empty.
+func (r TrackResolver) Resolve(
+	ctx context.Context, repository, pkgName, versionRange string, opts ...crane.Option,
+) (ResolvedVersion, error) {
+	idx, err := defaultRepoLoaderIfNil(r.Loader)(
+		ctx, []manifests.PackageManifestRepository{{Image: repository}}, opts...)
+	if err != nil {
+		return ResolvedVersion{}, err
+	}
+
+	// Packages are referenced by their fully qualified name within the solver,
+	// which includes the repository name stored in the repository image.
+	var fqdn string
+	for _, entry := range idx.ListAllEntries() {
+		if entry.Data.Name == pkgName {
+			fqdn = entry.FQDN()
+			break
+		}
+	}
+	if len(fqdn) == 0 {
+		return ResolvedVersion{}, fmt.Errorf("%w: %s", ErrTrackedPackageNotFound, pkgName)
+	}
+
+	// Solve a package that depends on the tracked package only,
+	// so version ranges are interpreted exactly like package dependencies.
+	tracking := &manifests.PackageManifest{
+		ObjectMeta: metav1.ObjectMeta{Name: pkgName},
+		Spec: manifests.PackageManifestSpec{
+			Dependencies: []manifests.PackageManifestDependency{
+				{
+					Image: &manifests.PackageManifestDependencyImage{
+						Name:    pkgName,
+						Package: fqdn,
+						Range:   versionRange,
+					},
+				},
+			},
+		},
+	}
+
+	// Pass on authentication, in case the solver has to pull additional repositories.
+	br := BuildResolver{Loader: staticRepoLoader(idx), CraneOptions: opts}
+	locks, err := br.AddManifest(ctx, tracking)
+	if err != nil {
+		return ResolvedVersion{}, err
+	}
+	if err := br.Solve(); err != nil {
+		return ResolvedVersion{}, err
+	}
+	if len(*locks) == 0 {
+		return ResolvedVersion{}, fmt.Errorf("%w: %s", ErrTrackedPackageNotFound, pkgName)
+	}
+
+	lock := (*locks)[0]
+
+	return ResolvedVersion{
+		Version: lock.Version,
+		Image:   digestReference(lock.Image, lock.Digest),
+	}, nil
+}
+
+// digestReference references the given image by digest.
+// Repository entries added via `kubectl package repository add`
+// only contain the hex encoded sha256 part of the digest.
+func digestReference(image, digest string) string {
+	if !strings.Contains(digest, ":") {
+		digest = "sha256:" + digest
+	}
+	return image + "@" + digest
+}
+
+// staticRepoLoader returns a RepoLoader always returning the given index.
+func staticRepoLoader(idx *packagerepository.MultiRepositoryIndex) RepoLoader {
+	return func(
+		context.Context, []manifests.PackageManifestRepository, ...crane.Option,
+	) (*packagerepository.MultiRepositoryIndex, error) {
+		return idx, nil
+	}
+}
@@ -0,0 +1,136 @@
+package packageresolving_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/apis/manifests"
+	"package-operator.run/internal/packages"
+	"package-operator.run/internal/packages/internal/packageresolving"
+)
+
+func TestTrackResolver(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	idx := packages.NewMultiRepositoryIndex()
+	for _, entry := range []manifests.RepositoryEntryData{
+		{Image: "quay.io/pkg", Digest: "sha256:1", Name: "pkg", Versions: []string{"1.0.0", "1.1.0"}},
+		{Image: "quay.io/pkg", Digest: "sha256:2", Name: "pkg", Versions: []string{"1.2.0"}},
+		{Image: "quay.io/pkg", Digest: "sha256:3", Name: "pkg", Versions: []string{"2.0.0"}},
+		{Image: "quay.io/other", Digest: "sha256:4", Name: "other", Versions: []string{"3.0.0"}},
+		{Image: "quay.io/hex", Digest: "abc", Name: "hex", Versions: []string{"1.0.0"}},
+	} {
+		require.NoError(t, idx.Add(ctx, packages.Entry{
+			RepositoryName:  "repo",
+			RepositoryEntry: &manifests.RepositoryEntry{Data: entry},
+		}))
+	}
+
+	r := packageresolving.TrackResolver{
+		Loader: func(
+			_ context.Context, repos []manifests.PackageManifestRepository, _ ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
+			assert.Equal(t, []manifests.PackageManifestRepository{{Image: "quay.io/repo:latest"}}, repos)
+			return idx, nil
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		pkgName     string
+		rng         string
+		expected    packageresolving.ResolvedVersion
+		expectedErr error
+	}{
+		"latest": {
+			pkgName:  "pkg",
+			expected: packageresolving.ResolvedVersion{Version: "2.0.0", Image: "quay.io/pkg@sha256:3"},
+		},
+		"range": {
+			pkgName:  "pkg",
+			rng:      "~1.1",
+			expected: packageresolving.ResolvedVersion{Version: "1.1.0", Image: "quay.io/pkg@sha256:1"},
+		},
+		"major range": {
+			pkgName:  "pkg",
+			rng:      "1.x",
+			expected: packageresolving.ResolvedVersion{Version: "1.2.0", Image: "quay.io/pkg@sha256:2"},
+		},
+		"hex digest": {
+			pkgName:  "hex",
+			expected: packageresolving.ResolvedVersion{Version: "1.0.0", Image: "quay.io/hex@sha256:abc"},
+		},
+		"package not found": {
+			pkgName:     "banana",
+			expectedErr: packageresolving.ErrTrackedPackageNotFound,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resolved, err := r.Resolve(ctx, "quay.io/repo:latest", tc.pkgName, tc.rng)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, resolved)
+		})
+	}
+}
+
+func TestTrackResolver_noMatchingVersion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	idx := packages.NewMultiRepositoryIndex()
+	require.NoError(t, idx.Add(ctx, packages.Entry{
+		RepositoryName: "repo",
+		RepositoryEntry: &manifests.RepositoryEntry{Data: manifests.RepositoryEntryData{
+			Image: "quay.io/pkg", Digest: "sha256:1", Name: "pkg", Versions: []string{"1.0.0"},
+		}},
+	}))
+
+	r := packageresolving.TrackResolver{
+		Loader: func(
+			context.Context, []manifests.PackageManifestRepository, ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
+			return idx, nil
+		},
+	}
+
+	_, err := r.Resolve(ctx, "quay.io/repo:latest", "pkg", ">=2.0.0")
+	require.Error(t, err)
+}
+
+func TestTrackResolver_craneOptions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	idx := packages.NewMultiRepositoryIndex()
+	require.NoError(t, idx.Add(ctx, packages.Entry{
+		RepositoryName: "repo",
+		RepositoryEntry: &manifests.RepositoryEntry{Data: manifests.RepositoryEntryData{
+			Image: "quay.io/pkg", Digest: "sha256:1", Name: "pkg", Versions: []string{"1.0.0"},
+		}},
+	}))
+
+	var loaderOpts []crane.Option
+	r := packageresolving.TrackResolver{
+		Loader: func(
+			_ context.Context, _ []manifests.PackageManifestRepository, opts ...crane.Option,
+		) (*packages.MultiRepositoryIndex, error) {
+			loaderOpts = opts
+			return idx, nil
+		},
+	}
+
+	// Repository images have to be pulled with the credentials of the package.
+	_, err := r.Resolve(ctx, "quay.io/repo:latest", "pkg", "", crane.Insecure)
+	require.NoError(t, err)
+	assert.Len(t, loaderOpts, 1)
+}
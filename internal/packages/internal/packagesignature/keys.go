@@ -0,0 +1,107 @@
+package packagesignature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	// ErrInvalidKey is returned when a key could not be parsed.
+	ErrInvalidKey = errors.New("invalid key")
+	// ErrEncryptedKey is returned for password protected cosign keys.
+	ErrEncryptedKey = errors.New(
+		"encrypted private keys are not supported, provide an unencrypted PEM encoded key")
+)
+
+// Parses a PEM encoded ECDSA, RSA or ed25519 public key.
+// Public keys created by "cosign generate-key-pair" are supported.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrInvalidKey)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidKey, key)
+}
+
+// Loads PEM encoded public keys from the given file paths.
+func LoadPublicKeys(paths ...string) ([]crypto.PublicKey, error) {
+	keys := make([]crypto.PublicKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading public key: %w", err)
+		}
+
+		key, err := ParsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("loading public key %s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Parses an unencrypted PEM encoded ECDSA, RSA or ed25519 private key.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrInvalidKey)
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "ENCRYPTED COSIGN PRIVATE KEY", "ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED PRIVATE KEY":
+		return nil, ErrEncryptedKey
+	default:
+		return nil, fmt.Errorf("%w: unsupported PEM block type %q", ErrInvalidKey, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidKey, key)
+	}
+	return signer, nil
+}
+
+// Loads an unencrypted PEM encoded private key from the given file path.
+func LoadPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key: %w", err)
+	}
+
+	key, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("loading private key %s: %w", path, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,80 @@
+package packagesignature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadKeys(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	privDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	privPath := filepath.Join(dir, "key.pem")
+	pubPath := filepath.Join(dir, "key.pub")
+	require.NoError(t, os.WriteFile(privPath,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privDER}), 0o600))
+	require.NoError(t, os.WriteFile(pubPath,
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o600))
+
+	signer, err := LoadPrivateKey(privPath)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(signer))
+
+	pubKeys, err := LoadPublicKeys(pubPath)
+	require.NoError(t, err)
+	require.Len(t, pubKeys, 1)
+	assert.True(t, key.PublicKey.Equal(pubKeys[0]))
+}
+
+func TestParseKeys_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		data        []byte
+		expectedErr error
+	}{
+		"no pem": {
+			data:        []byte("banana"),
+			expectedErr: ErrInvalidKey,
+		},
+		"encrypted": {
+			data: pem.EncodeToMemory(&pem.Block{
+				Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: []byte("banana"),
+			}),
+			expectedErr: ErrEncryptedKey,
+		},
+		"garbage": {
+			data: pem.EncodeToMemory(&pem.Block{
+				Type: "EC PRIVATE KEY", Bytes: []byte("banana"),
+			}),
+			expectedErr: ErrInvalidKey,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParsePrivateKey(tc.data)
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+
+	_, err := ParsePublicKey([]byte("banana"))
+	require.ErrorIs(t, err, ErrInvalidKey)
+}
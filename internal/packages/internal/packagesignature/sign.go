@@ -0,0 +1,55 @@
+package packagesignature
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// Signs the image with the given digest and pushes the signature
+// next to the image, using the cosign signature tag scheme.
+// An existing signature of the image is replaced.
+func Sign(ctx context.Context, digest name.Digest, key crypto.Signer, opts ...crane.Option) error {
+	data, err := marshalPayload(digest)
+	if err != nil {
+		return fmt.Errorf("marshalling signature payload: %w", err)
+	}
+
+	sig, err := signPayload(key, data)
+	if err != nil {
+		return fmt.Errorf("signing payload: %w", err)
+	}
+
+	img, err := mutate.Append(
+		mutate.MediaType(empty.Image, types.OCIManifestSchema1),
+		mutate.Addendum{
+			Layer: static.NewLayer(data, SimpleSigningMediaType),
+			Annotations: map[string]string{
+				SignatureAnnotation: base64.StdEncoding.EncodeToString(sig),
+			},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("creating signature image: %w", err)
+	}
+	img = mutate.ConfigMediaType(img, types.OCIConfigJSON)
+
+	tag, err := SignatureTag(digest)
+	if err != nil {
+		return err
+	}
+
+	opts = append(opts, crane.WithContext(ctx))
+	if err := crane.Push(img, tag.String(), opts...); err != nil {
+		return fmt.Errorf("pushing signature: %w", err)
+	}
+	return nil
+}
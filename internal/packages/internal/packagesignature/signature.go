@@ -0,0 +1,95 @@
+package packagesignature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	containerregistrypkgv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	// Media type of the signed payload layers in a signature image.
+	SimpleSigningMediaType types.MediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// Layer annotation holding the base64 encoded signature of the payload.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	signatureTagSuffix = ".sig"
+	payloadType        = "cosign container image signature"
+)
+
+// Returns the tag that signatures of the image with the given digest are stored under.
+// Uses the same "sha256-<hex>.sig" scheme as cosign.
+func SignatureTag(digest name.Digest) (name.Tag, error) {
+	hash, err := containerregistrypkgv1.NewHash(digest.DigestStr())
+	if err != nil {
+		return name.Tag{}, fmt.Errorf("parsing digest: %w", err)
+	}
+
+	return digest.Context().Tag(fmt.Sprintf("%s-%s%s", hash.Algorithm, hash.Hex, signatureTagSuffix)), nil
+}
+
+// Simple signing payload, as created and understood by cosign.
+type payload struct {
+	Critical payloadCritical   `json:"critical"`
+	Optional map[string]string `json:"optional"`
+}
+
+type payloadCritical struct {
+	Identity payloadIdentity `json:"identity"`
+	Image    payloadImage    `json:"image"`
+	Type     string          `json:"type"`
+}
+
+type payloadIdentity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+type payloadImage struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+func newPayload(digest name.Digest) payload {
+	return payload{
+		Critical: payloadCritical{
+			Identity: payloadIdentity{DockerReference: digest.Context().String()},
+			Image:    payloadImage{DockerManifestDigest: digest.DigestStr()},
+			Type:     payloadType,
+		},
+	}
+}
+
+func marshalPayload(digest name.Digest) ([]byte, error) {
+	return json.Marshal(newPayload(digest))
+}
+
+func signPayload(key crypto.Signer, data []byte) ([]byte, error) {
+	if _, ok := key.Public().(ed25519.PublicKey); ok {
+		// ed25519 signs the message itself.
+		return key.Sign(rand.Reader, data, crypto.Hash(0))
+	}
+
+	digest := sha256.Sum256(data)
+	return key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+func verifyPayload(key crypto.PublicKey, data, sig []byte) bool {
+	digest := sha256.Sum256(data)
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, data, sig)
+	}
+	return false
+}
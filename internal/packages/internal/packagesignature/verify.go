@@ -0,0 +1,149 @@
+package packagesignature
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	containerregistrypkgv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+)
+
+// SignatureVerificationError is returned when an image
+// is not signed by any of the trusted keys.
+type SignatureVerificationError struct {
+	Image  string
+	Reason string
+}
+
+func (e *SignatureVerificationError) Error() string {
+	return fmt.Sprintf("verifying signature of image %s: %s", e.Image, e.Reason)
+}
+
+// Verifier checks image signatures against a set of trusted public keys.
+type Verifier struct {
+	keys []crypto.PublicKey
+}
+
+// Creates a new Verifier trusting the given public keys.
+func NewVerifier(keys ...crypto.PublicKey) *Verifier {
+	return &Verifier{keys: keys}
+}
+
+// Resolves the given image reference to its digest and ensures that the image
+// is signed by at least one of the trusted keys.
+// Returns the digest reference that must be used to pull the verified image,
+// so the image can not be swapped out after verification.
+func (v *Verifier) Verify(ctx context.Context, ref string, opts ...crane.Option) (name.Digest, error) {
+	opts = append(opts, crane.WithContext(ctx))
+
+	parsed, err := name.ParseReference(ref)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("parsing image reference: %w", err)
+	}
+
+	digest, ok := parsed.(name.Digest)
+	if !ok {
+		rawDigest, err := crane.Digest(ref, opts...)
+		if err != nil {
+			return name.Digest{}, fmt.Errorf("resolving image digest: %w", err)
+		}
+		digest = parsed.Context().Digest(rawDigest)
+	}
+
+	tag, err := SignatureTag(digest)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	sigImg, err := crane.Pull(tag.String(), opts...)
+	var terr *transport.Error
+	if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+		return name.Digest{}, &SignatureVerificationError{
+			Image: digest.String(), Reason: "no signatures found",
+		}
+	}
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("pulling signatures: %w", err)
+	}
+
+	verified, err := v.verifyImage(sigImg, digest)
+	if err != nil {
+		return name.Digest{}, err
+	}
+	if !verified {
+		return name.Digest{}, &SignatureVerificationError{
+			Image: digest.String(), Reason: "no signature matches any of the trusted keys",
+		}
+	}
+	return digest, nil
+}
+
+// Checks if any layer of the signature image is a valid signature for digest.
+func (v *Verifier) verifyImage(sigImg containerregistrypkgv1.Image, digest name.Digest) (bool, error) {
+	manifest, err := sigImg.Manifest()
+	if err != nil {
+		return false, fmt.Errorf("reading signature manifest: %w", err)
+	}
+
+	for _, desc := range manifest.Layers {
+		rawSig, ok := desc.Annotations[SignatureAnnotation]
+		if !ok || desc.MediaType != SimpleSigningMediaType {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(rawSig)
+		if err != nil {
+			continue
+		}
+
+		layer, err := sigImg.LayerByDigest(desc.Digest)
+		if err != nil {
+			return false, fmt.Errorf("reading signature layer: %w", err)
+		}
+		data, err := readLayer(layer)
+		if err != nil {
+			return false, fmt.Errorf("reading signature layer: %w", err)
+		}
+
+		if v.verifyPayload(data, sig, digest) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (v *Verifier) verifyPayload(data, sig []byte, digest name.Digest) bool {
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return false
+	}
+	if p.Critical.Type != payloadType ||
+		p.Critical.Image.DockerManifestDigest != digest.DigestStr() {
+		return false
+	}
+
+	for _, key := range v.keys {
+		if verifyPayload(key, data, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+func readLayer(layer containerregistrypkgv1.Layer) ([]byte, error) {
+	// Payload layers are stored as plain blobs.
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,109 @@
+package packagesignature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/internal/testutil"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		signKey       crypto.Signer
+		trustedKeys   []crypto.PublicKey
+		expectInvalid bool
+	}{
+		"ecdsa": {
+			signKey:     ecdsaKey,
+			trustedKeys: []crypto.PublicKey{ecdsaKey.Public()},
+		},
+		"rsa": {
+			signKey:     rsaKey,
+			trustedKeys: []crypto.PublicKey{rsaKey.Public()},
+		},
+		"ed25519": {
+			signKey:     ed25519Key,
+			trustedKeys: []crypto.PublicKey{ed25519Key.Public()},
+		},
+		"one of multiple keys": {
+			signKey:     ecdsaKey,
+			trustedKeys: []crypto.PublicKey{otherKey.Public(), ecdsaKey.Public()},
+		},
+		"untrusted key": {
+			signKey:       otherKey,
+			trustedKeys:   []crypto.PublicKey{ecdsaKey.Public()},
+			expectInvalid: true,
+		},
+		"unsigned": {
+			trustedKeys:   []crypto.PublicKey{ecdsaKey.Public()},
+			expectInvalid: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			reg := testutil.NewInMemoryRegistry()
+
+			const ref = "chickens:oldest"
+			img := testutil.BuildImage(t, map[string][]byte{"manifest.yaml": {1, 2}})
+			require.NoError(t, crane.Push(img, ref, reg.CraneOpt))
+
+			imgDigest, err := img.Digest()
+			require.NoError(t, err)
+			parsed, err := name.ParseReference(ref)
+			require.NoError(t, err)
+			digest := parsed.Context().Digest(imgDigest.String())
+
+			if tc.signKey != nil {
+				require.NoError(t, Sign(ctx, digest, tc.signKey, reg.CraneOpt))
+			}
+
+			verified, err := NewVerifier(tc.trustedKeys...).Verify(ctx, ref, reg.CraneOpt)
+			if tc.expectInvalid {
+				var verr *SignatureVerificationError
+				require.ErrorAs(t, err, &verr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, digest.String(), verified.String())
+		})
+	}
+}
+
+func TestSignatureTag(t *testing.T) {
+	t.Parallel()
+
+	digest, err := name.NewDigest(
+		"quay.io/test/pkg@sha256:" +
+			"0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+
+	tag, err := SignatureTag(digest)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"quay.io/test/pkg:sha256-0000000000000000000000000000000000000000000000000000000000000001.sig",
+		tag.String())
+}
@@ -9,4 +9,15 @@ const (
 	PackageManifestLockFilename = "manifest.lock"
 	// Name of the components folder for multi-components.
 	ComponentsFolder = "components"
+	// Annotation marking the package image in OCI image layouts containing multiple images.
+	// The value is the original reference of the package image.
+	OCILayoutPackageAnnotation = "package-operator.run/package"
+)
+
+const (
+	// Media type of the config of packages exported as OCI artifact.
+	// Registries supporting OCI 1.1 report it as the artifactType of the package.
+	PackageArtifactConfigMediaType = "application/vnd.package-operator.package.config.v1+json"
+	// Media type of the layer holding the package files of packages exported as OCI artifact.
+	PackageArtifactLayerMediaType = "application/vnd.package-operator.package.layer.v1.tar+gzip"
 )
@@ -3,24 +3,40 @@ package probing
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/pkg/celext"
 	"package-operator.run/pkg/probing"
 )
 
 // Parse takes a list of ObjectSetProbes (commonly defined within a ObjectSetPhaseSpec)
 // and compiles a single Prober to test objects with.
-func Parse(ctx context.Context, packageProbes []corev1alpha1.ObjectSetProbe) (probing.Prober, error) {
-	probeList := make(probing.And, len(packageProbes))
+func Parse(
+	ctx context.Context, packageProbes []corev1alpha1.ObjectSetProbe, opts ...ParseOption,
+) (probing.Prober, error) {
+	var cfg ParseConfig
+	cfg.Option(opts...)
+	cfg.Default()
+	// Endpoint probes are executed while reconciling,
+	// so they must neither outlive the reconcile nor block it for too long.
+	opts = append(slices.Clip(opts), WithEndpoints{Endpoints: &probing.BoundEndpoints{
+		Endpoints: cfg.Endpoints,
+		Context:   ctx,
+		Budget:    EndpointProbeBudget,
+	}})
+
+	probeList := make(probing.And, 0, len(packageProbes))
 	for i, pkgProbe := range packageProbes {
 		var (
 			probe probing.Prober
 			err   error
 		)
-		probe, err = ParseProbes(ctx, pkgProbe.Probes)
+		probe, err = ParseProbes(ctx, pkgProbe.Probes, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("parsing probe #%d: %w", i, err)
 		}
@@ -28,11 +44,56 @@ func Parse(ctx context.Context, packageProbes []corev1alpha1.ObjectSetProbe) (pr
 		if err != nil {
 			return nil, fmt.Errorf("parsing selector of probe #%d: %w", i, err)
 		}
-		probeList[i] = probe
+		probeList = append(probeList, probe)
+
+		for j, aggregate := range pkgProbe.Aggregate {
+			aggregateProbe, err := ParseAggregate(ctx, pkgProbe.Selector, aggregate)
+			if err != nil {
+				return nil, fmt.Errorf("parsing aggregate #%d of probe #%d: %w", j, i, err)
+			}
+			probeList = append(probeList, aggregateProbe)
+		}
 	}
 	return probeList, nil
 }
 
+// ParseAggregate compiles a corev1alpha1.ProbeAggregateSpec into a Prober,
+// that only probes objects matching the selector when used as probing.AggregateProber.
+func ParseAggregate(
+	_ context.Context, selector corev1alpha1.ProbeSelector, spec corev1alpha1.ProbeAggregateSpec,
+) (probing.Prober, error) {
+	refs := make([]probing.ObjectReference, len(spec.References))
+	for i, ref := range spec.References {
+		refs[i] = probing.ObjectReference{
+			Alias:            ref.Alias,
+			GroupVersionKind: schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind),
+			Namespace:        ref.Namespace,
+			Name:             ref.Name,
+		}
+	}
+
+	probe, err := probing.NewAggregateCELProbe(spec.Rule, spec.Message, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate := &probing.AggregateSelector{AggregateProber: probe}
+	if selector.Kind != nil {
+		aggregate.GroupKind = &schema.GroupKind{
+			Group: selector.Kind.Group,
+			Kind:  selector.Kind.Kind,
+		}
+	}
+	if selector.Selector != nil {
+		s, err := metav1.LabelSelectorAsSelector(selector.Selector)
+		if err != nil {
+			return nil, err
+		}
+		aggregate.Selector = s
+	}
+	return aggregate, nil
+}
+
 // ParseSelector reads a corev1alpha1.ProbeSelector and wraps a Prober,
 // only executing the Prober when the selector criteria match.
 func ParseSelector(
@@ -61,7 +122,13 @@ func ParseSelector(
 }
 
 // ParseProbes takes a []corev1alpha1.Probe and compiles it into a Prober.
-func ParseProbes(_ context.Context, probeSpecs []corev1alpha1.Probe) (probing.Prober, error) {
+func ParseProbes(
+	_ context.Context, probeSpecs []corev1alpha1.Probe, opts ...ParseOption,
+) (probing.Prober, error) {
+	var cfg ParseConfig
+	cfg.Option(opts...)
+	cfg.Default()
+
 	var probeList probing.And
 	for _, probeSpec := range probeSpecs {
 		var (
@@ -91,6 +158,19 @@ func ParseProbes(_ context.Context, probeSpecs []corev1alpha1.Probe) (probing.Pr
 				return nil, err
 			}
 
+		case probeSpec.HTTPGet != nil:
+			probe, err = parseHTTPGet(probeSpec.HTTPGet, cfg.Endpoints)
+			if err != nil {
+				return nil, err
+			}
+
+		case probeSpec.TCPSocket != nil:
+			probe = &probing.TCPSocketProbe{
+				Endpoints: cfg.Endpoints,
+				Port:      probeSpec.TCPSocket.Port,
+				Timeout:   durationOrZero(probeSpec.TCPSocket.Timeout),
+			}
+
 		default:
 			// probe has no known config
 			continue
@@ -101,3 +181,94 @@ func ParseProbes(_ context.Context, probeSpecs []corev1alpha1.Probe) (probing.Pr
 	// Always check .status.observedCondition, if present.
 	return &probing.ObservedGenerationProbe{Prober: probeList}, nil
 }
+
+func parseHTTPGet(spec *corev1alpha1.ProbeHTTPGetSpec, endpoints probing.Endpoints) (probing.Prober, error) {
+	probe := &probing.HTTPGetProbe{
+		Endpoints:           endpoints,
+		Scheme:              spec.Scheme,
+		Port:                spec.Port,
+		Path:                spec.Path,
+		ExpectedStatusCodes: spec.ExpectedStatusCodes,
+		Timeout:             durationOrZero(spec.Timeout),
+	}
+	if len(probe.Path) == 0 {
+		probe.Path = "/"
+	}
+	if spec.Body != nil {
+		body, err := probing.NewHTTPBodyProbe(spec.Body.Rule, spec.Body.Message)
+		if err != nil {
+			return nil, err
+		}
+		probe.Body = body
+	}
+	return probe, nil
+}
+
+func durationOrZero(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Duration
+}
+
+// RequiresPolling returns true, if the given probes check state that may change
+// without the probed objects changing, like HTTP endpoints, referenced objects
+// or the current time.
+// Such probes need to be re-evaluated periodically, even after they succeeded.
+func RequiresPolling(packageProbes []corev1alpha1.ObjectSetProbe) bool {
+	for _, pkgProbe := range packageProbes {
+		for _, probeSpec := range pkgProbe.Probes {
+			if probeSpec.HTTPGet != nil || probeSpec.TCPSocket != nil {
+				return true
+			}
+			if probeSpec.CEL != nil && celext.UsesTime(probeSpec.CEL.Rule) {
+				return true
+			}
+		}
+		for _, aggregate := range pkgProbe.Aggregate {
+			if len(aggregate.References) > 0 || celext.UsesTime(aggregate.Rule) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PollingInterval in which probes are re-evaluated,
+// when they check state that may change without the probed objects changing.
+const PollingInterval = 15 * time.Second
+
+// EndpointProbeBudget is the total time all HTTPGet and TCPSocket probes
+// compiled by a single call to Parse may take per probed object.
+// Endpoints not probed within the budget are reported as not probed yet.
+const EndpointProbeBudget = 10 * time.Second
+
+// ParseConfig configures how probes are compiled.
+type ParseConfig struct {
+	// Endpoints HTTPGet and TCPSocket probes connect to.
+	// Defaults to probing.DirectEndpoints.
+	Endpoints probing.Endpoints
+}
+
+func (c *ParseConfig) Option(opts ...ParseOption) {
+	for _, opt := range opts {
+		opt.ConfigureParse(c)
+	}
+}
+
+func (c *ParseConfig) Default() {
+	if c.Endpoints == nil {
+		c.Endpoints = &probing.DirectEndpoints{}
+	}
+}
+
+type ParseOption interface {
+	ConfigureParse(c *ParseConfig)
+}
+
+// WithEndpoints sets the endpoints HTTPGet and TCPSocket probes connect to.
+type WithEndpoints struct{ Endpoints probing.Endpoints }
+
+func (w WithEndpoints) ConfigureParse(c *ParseConfig) {
+	c.Endpoints = w.Endpoints
+}
@@ -3,10 +3,13 @@ package probing
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/pkg/probing"
@@ -41,6 +44,88 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParse_aggregate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	osp := []corev1alpha1.ObjectSetProbe{
+		{
+			Selector: corev1alpha1.ProbeSelector{
+				Kind: &corev1alpha1.PackageProbeKindSpec{
+					Kind:  "Deployment",
+					Group: "apps",
+				},
+			},
+			Aggregate: []corev1alpha1.ProbeAggregateSpec{
+				{
+					Rule:    `objects.size() >= 2`,
+					Message: "not enough Deployments",
+				},
+			},
+		},
+	}
+
+	p, err := Parse(ctx, osp)
+	require.NoError(t, err)
+	require.IsType(t, probing.And{}, p)
+
+	if assert.Len(t, p, 2) {
+		list := p.(probing.And)
+		require.IsType(t, &probing.GroupKindSelector{}, list[0])
+		require.IsType(t, &probing.AggregateSelector{}, list[1])
+	}
+
+	_, err = Parse(ctx, []corev1alpha1.ObjectSetProbe{{
+		Aggregate: []corev1alpha1.ProbeAggregateSpec{{Rule: `objects`}},
+	}})
+	require.Error(t, err)
+}
+
+func TestParseAggregate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	p, err := ParseAggregate(ctx, corev1alpha1.ProbeSelector{
+		Kind: &corev1alpha1.PackageProbeKindSpec{
+			Kind:  "Deployment",
+			Group: "apps",
+		},
+		Selector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"test": "test123",
+			},
+		},
+	}, corev1alpha1.ProbeAggregateSpec{
+		Rule:    `references.db != null`,
+		Message: "database missing",
+		References: []corev1alpha1.ProbeObjectReference{
+			{
+				Alias:      "db",
+				APIVersion: "apps/v1",
+				Kind:       "StatefulSet",
+				Name:       "database",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &probing.AggregateSelector{}, p)
+
+	as := p.(*probing.AggregateSelector)
+	assert.Equal(t, &schema.GroupKind{Group: "apps", Kind: "Deployment"}, as.GroupKind)
+	assert.Equal(t, "test=test123", as.Selector.String())
+	require.IsType(t, &probing.AggregateCELProbe{}, as.AggregateProber)
+
+	cel := as.AggregateProber.(*probing.AggregateCELProbe)
+	assert.Equal(t, "database missing", cel.Message)
+	assert.Equal(t, []probing.ObjectReference{
+		{
+			Alias: "db",
+			GroupVersionKind: schema.GroupVersionKind{
+				Group: "apps", Version: "v1", Kind: "StatefulSet",
+			},
+			Name: "database",
+		},
+	}, cel.References)
+}
+
 func TestParseSelector(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -107,3 +192,139 @@ func TestParseProbes(t *testing.T) {
 		}, nestedList[1])
 	}
 }
+
+func TestParse_endpoints(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	endpoints := &probing.DirectEndpoints{}
+
+	p, err := Parse(ctx, []corev1alpha1.ObjectSetProbe{{
+		Probes: []corev1alpha1.Probe{
+			{TCPSocket: &corev1alpha1.ProbeTCPSocketSpec{}},
+			{HTTPGet: &corev1alpha1.ProbeHTTPGetSpec{}},
+		},
+	}}, WithEndpoints{Endpoints: endpoints})
+	require.NoError(t, err)
+
+	nested := p.(probing.And)[0].(*probing.ObservedGenerationProbe).Prober.(probing.And)
+	require.Len(t, nested, 2)
+	tcp := nested[0].(*probing.TCPSocketProbe)
+	httpGet := nested[1].(*probing.HTTPGetProbe)
+
+	// All endpoint probes share the same context and the budget of each probed object.
+	bound, ok := tcp.Endpoints.(*probing.BoundEndpoints)
+	require.True(t, ok)
+	assert.Same(t, bound, httpGet.Endpoints)
+	assert.Same(t, endpoints, bound.Endpoints)
+	assert.Equal(t, ctx, bound.Context)
+	assert.Equal(t, EndpointProbeBudget, bound.Budget)
+}
+
+func TestParseProbes_endpoints(t *testing.T) {
+	t.Parallel()
+	port := intstr.FromString("http")
+	endpoints := &probing.DirectEndpoints{}
+
+	p, err := ParseProbes(context.Background(), []corev1alpha1.Probe{
+		{
+			HTTPGet: &corev1alpha1.ProbeHTTPGetSpec{
+				Port:                &port,
+				Scheme:              "HTTPS",
+				ExpectedStatusCodes: []int32{200},
+				Timeout:             &metav1.Duration{Duration: 5 * time.Second},
+			},
+		},
+		{
+			TCPSocket: &corev1alpha1.ProbeTCPSocketSpec{
+				Port: &port,
+			},
+		},
+	}, WithEndpoints{Endpoints: endpoints})
+	require.NoError(t, err)
+
+	nested := p.(*probing.ObservedGenerationProbe).Prober
+	if assert.Len(t, nested, 2) {
+		nestedList := nested.(probing.And)
+		assert.Equal(t, &probing.HTTPGetProbe{
+			Endpoints:           endpoints,
+			Scheme:              "HTTPS",
+			Port:                &port,
+			Path:                "/",
+			ExpectedStatusCodes: []int32{200},
+			Timeout:             5 * time.Second,
+		}, nestedList[0])
+		assert.Equal(t, &probing.TCPSocketProbe{
+			Endpoints: endpoints,
+			Port:      &port,
+		}, nestedList[1])
+	}
+
+	_, err = ParseProbes(context.Background(), []corev1alpha1.Probe{
+		{
+			HTTPGet: &corev1alpha1.ProbeHTTPGetSpec{
+				Body: &corev1alpha1.ProbeHTTPBodySpec{Rule: "body"},
+			},
+		},
+	})
+	require.ErrorIs(t, err, probing.ErrCELInvalidEvaluationType)
+}
+
+func TestRequiresPolling(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		probes   []corev1alpha1.ObjectSetProbe
+		expected bool
+	}{
+		"object probes": {
+			probes: []corev1alpha1.ObjectSetProbe{{
+				Probes: []corev1alpha1.Probe{{
+					Condition: &corev1alpha1.ProbeConditionSpec{Type: "Available", Status: "True"},
+				}},
+				Aggregate: []corev1alpha1.ProbeAggregateSpec{{Rule: "true"}},
+			}},
+		},
+		"http probe": {
+			probes: []corev1alpha1.ObjectSetProbe{{
+				Probes: []corev1alpha1.Probe{{HTTPGet: &corev1alpha1.ProbeHTTPGetSpec{}}},
+			}},
+			expected: true,
+		},
+		"tcp probe": {
+			probes: []corev1alpha1.ObjectSetProbe{{
+				Probes: []corev1alpha1.Probe{{TCPSocket: &corev1alpha1.ProbeTCPSocketSpec{}}},
+			}},
+			expected: true,
+		},
+		"aggregate with references": {
+			probes: []corev1alpha1.ObjectSetProbe{{
+				Aggregate: []corev1alpha1.ProbeAggregateSpec{{
+					Rule:       "true",
+					References: []corev1alpha1.ProbeObjectReference{{Alias: "a"}},
+				}},
+			}},
+			expected: true,
+		},
+		"cel probe using time": {
+			probes: []corev1alpha1.ObjectSetProbe{{
+				Probes: []corev1alpha1.Probe{{CEL: &corev1alpha1.ProbeCELSpec{
+					Rule: `time.since(self.metadata.creationTimestamp) > duration("5m")`,
+				}}},
+			}},
+			expected: true,
+		},
+		"aggregate using time": {
+			probes: []corev1alpha1.ObjectSetProbe{{
+				Aggregate: []corev1alpha1.ProbeAggregateSpec{{
+					Rule: `objects.all(o, time.until(o.status.notAfter) > duration("720h"))`,
+				}},
+			}},
+			expected: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, RequiresPolling(tc.probes))
+		})
+	}
+}
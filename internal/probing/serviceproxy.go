@@ -0,0 +1,74 @@
+package probing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	"package-operator.run/pkg/probing"
+)
+
+// ServiceProxyEndpoints sends HTTP requests to Services through the Kubernetes API server service proxy,
+// so Package Operator does not need to run within the cluster network to probe them.
+// Requests to Routes and TCP connections are handled by probing.DirectEndpoints.
+type ServiceProxyEndpoints struct {
+	probing.DirectEndpoints
+	client rest.Interface
+}
+
+var _ probing.Endpoints = (*ServiceProxyEndpoints)(nil)
+
+// NewServiceProxyEndpoints returns ServiceProxyEndpoints using the API server of the given config.
+func NewServiceProxyEndpoints(cfg *rest.Config) (*ServiceProxyEndpoints, error) {
+	cfg = rest.CopyConfig(cfg)
+	cfg.APIPath = "/api"
+	cfg.GroupVersion = &corev1.SchemeGroupVersion
+	cfg.NegotiatedSerializer = k8sscheme.Codecs.WithoutConversion()
+
+	client, err := rest.RESTClientFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating service proxy client: %w", err)
+	}
+	return &ServiceProxyEndpoints{client: client}, nil
+}
+
+// HTTPGet sends a GET request to the given port and path of a Service or Route.
+func (e *ServiceProxyEndpoints) HTTPGet(
+	ctx context.Context, obj *unstructured.Unstructured,
+	scheme string, port *intstr.IntOrString, path string,
+) (statusCode int, body []byte, err error) {
+	if obj.GroupVersionKind().GroupKind() != (schema.GroupKind{Kind: "Service"}) {
+		return e.DirectEndpoints.HTTPGet(ctx, obj, scheme, port, path)
+	}
+
+	portNumber, err := probing.ServicePort(obj, port)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(scheme) == 0 {
+		scheme = "http"
+	}
+
+	res := e.client.Get().
+		Namespace(obj.GetNamespace()).
+		Resource("services").
+		Name(fmt.Sprintf("%s:%s:%d", strings.ToLower(scheme), obj.GetName(), portNumber)).
+		SubResource("proxy").
+		Suffix(path).
+		Do(ctx)
+	res.StatusCode(&statusCode)
+	body, err = res.Raw()
+	if statusCode == 0 {
+		// Request failed without response.
+		return 0, nil, err
+	}
+	// Non 2xx responses are reported as error, but we are only interested in the status code.
+	return statusCode, body, nil
+}
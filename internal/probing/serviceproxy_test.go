@@ -0,0 +1,69 @@
+package probing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/rest"
+)
+
+func TestServiceProxyEndpoints_HTTPGet(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/namespaces/test/services/https:app:8443/proxy/healthz", r.URL.Path)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("starting"))
+	}))
+	defer server.Close()
+
+	e, err := NewServiceProxyEndpoints(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	svc := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]any{
+			"name":      "app",
+			"namespace": "test",
+		},
+		"spec": map[string]any{
+			"ports": []any{
+				map[string]any{"name": "https", "port": int64(8443)},
+			},
+		},
+	}}
+	port := intstr.FromString("https")
+
+	statusCode, body, err := e.HTTPGet(context.Background(), svc, "HTTPS", &port, "/healthz")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+	assert.Equal(t, "starting", string(body))
+}
+
+func TestServiceProxyEndpoints_HTTPGet_error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	e, err := NewServiceProxyEndpoints(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	svc := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]any{"name": "app", "namespace": "test"},
+		"spec": map[string]any{
+			"ports": []any{map[string]any{"port": int64(80)}},
+		},
+	}}
+	_, _, err = e.HTTPGet(context.Background(), svc, "", nil, "/")
+	require.Error(t, err)
+}
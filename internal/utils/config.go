@@ -0,0 +1,26 @@
+package utils
+
+// MergeConfig deep merges the given configuration maps into a new map.
+// Values of later maps take precedence, nested maps are merged recursively.
+func MergeConfig(configs ...map[string]any) map[string]any {
+	out := map[string]any{}
+	for _, config := range configs {
+		mergeConfigInto(out, config)
+	}
+	return out
+}
+
+func mergeConfigInto(dst, src map[string]any) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]any)
+		dstMap, dstIsMap := dst[k].(map[string]any)
+		switch {
+		case srcIsMap && dstIsMap:
+			mergeConfigInto(dstMap, srcMap)
+		case srcIsMap:
+			dst[k] = MergeConfig(srcMap)
+		default:
+			dst[k] = v
+		}
+	}
+}
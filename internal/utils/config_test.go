@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeConfig(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]any{
+		"replicas": 1,
+		"database": map[string]any{
+			"host": "db",
+			"port": 5432,
+		},
+	}
+	overlay := map[string]any{
+		"replicas": 3,
+		"database": map[string]any{
+			"password": "hunter2",
+		},
+	}
+
+	merged := MergeConfig(base, overlay)
+	assert.Equal(t, map[string]any{
+		"replicas": 3,
+		"database": map[string]any{
+			"host":     "db",
+			"port":     5432,
+			"password": "hunter2",
+		},
+	}, merged)
+
+	// inputs are not modified.
+	assert.Equal(t, map[string]any{"host": "db", "port": 5432}, base["database"])
+}
@@ -0,0 +1,38 @@
+// Package celext contains the CEL library shared by all CEL expressions Package Operator evaluates,
+// like availability probes, "package-operator.run/condition" annotations and conditional paths.
+package celext
+
+import (
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apiserver/pkg/cel/library"
+)
+
+// EnvOptions returns the options every CEL environment of Package Operator is created with.
+// Variables need to be declared in addition.
+//
+// Besides the CEL standard library, expressions have access to:
+//   - optional field access via `obj.?field` and `obj.lookup(path)`
+//   - string, list, regex and URL functions of the Kubernetes CEL library
+//   - quantities via `quantity(string)` of the Kubernetes CEL library
+//   - version comparison via `semver.*` functions
+//
+// Results only depend on the evaluated data, so rendering stays reproducible.
+// Time helpers are not included, see Time.
+func EnvOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.HomogeneousAggregateLiterals(),
+		cel.EagerlyValidateDeclarations(true),
+		cel.DefaultUTCTimeZone(true),
+		cel.OptionalTypes(),
+
+		ext.Strings(ext.StringsVersion(0)),
+		library.URLs(),
+		library.Regex(),
+		library.Lists(),
+		library.Quantity(),
+
+		Semver(),
+		Lookup(),
+	}
+}
@@ -0,0 +1,65 @@
+package celext
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/require"
+)
+
+// Compiles and evaluates the given expression with the shared environment and the given options.
+func eval(t *testing.T, expr string, vars map[string]any, extraOpts ...cel.EnvOption) (any, error) {
+	t.Helper()
+
+	opts := append(EnvOptions(), extraOpts...)
+	for name := range vars {
+		opts = append(opts, cel.Variable(name, cel.DynType))
+	}
+	env, err := cel.NewEnv(opts...)
+	require.NoError(t, err)
+
+	ast, issues := env.Compile(expr)
+	require.NoError(t, issues.Err())
+
+	prgm, err := env.Program(ast)
+	require.NoError(t, err)
+
+	if vars == nil {
+		vars = map[string]any{}
+	}
+	out, _, err := prgm.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	return out.Value(), nil
+}
+
+func TestEnvOptions(t *testing.T) {
+	t.Parallel()
+
+	for name, expr := range map[string]string{
+		"strings":  `"a,b".split(",") == ["a", "b"]`,
+		"lists":    `[1, 3, 2].isSorted() == false`,
+		"regex":    `"banana".find("n.n") == "nan"`,
+		"urls":     `url("https://example.com/path").getHost() == "example.com"`,
+		"quantity": `quantity("1Gi").isGreaterThan(quantity("512Mi"))`,
+		"optional": `{"a": 1}.?b.orValue(2) == 2`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := eval(t, expr, nil)
+			require.NoError(t, err)
+			require.Equal(t, true, out)
+		})
+	}
+}
+
+func TestEnvOptions_noTime(t *testing.T) {
+	t.Parallel()
+
+	env, err := cel.NewEnv(EnvOptions()...)
+	require.NoError(t, err)
+	_, issues := env.Compile(`time.now() > timestamp("2024-01-01T00:00:00Z")`)
+	require.Error(t, issues.Err())
+}
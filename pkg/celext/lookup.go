@@ -0,0 +1,154 @@
+package celext
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+)
+
+// Lookup returns a cel.EnvOption for jsonpath-like optional field access.
+// The path consists of field names separated by dots, list indices and
+// bracket-quoted keys for field names containing dots. The leading dot is optional.
+// An empty optional is returned when any element of the path does not exist.
+//
+//	<dyn>.lookup(<string>) -> <optional(dyn)>
+//
+// Examples:
+//
+//	self.lookup(".status.conditions[0].type").orValue("") == "Available"
+//	self.lookup("metadata.annotations['example.com/ready']").hasValue()
+func Lookup() cel.EnvOption {
+	return cel.Lib(lookupLib{})
+}
+
+type lookupLib struct{}
+
+func (lookupLib) LibraryName() string {
+	return "package-operator.run.lookup"
+}
+
+func (lookupLib) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function("lookup",
+			cel.MemberOverload("dyn_lookup_string",
+				[]*cel.Type{cel.DynType, cel.StringType}, cel.OptionalType(cel.DynType),
+				cel.BinaryBinding(lookup),
+			),
+		),
+	}
+}
+
+func (lookupLib) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+func lookup(obj, pathVal ref.Val) ref.Val {
+	pathStr, ok := pathVal.(types.String)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(pathVal)
+	}
+
+	path, err := parseLookupPath(string(pathStr))
+	if err != nil {
+		return types.NewErr("lookup %q: %v", string(pathStr), err)
+	}
+
+	current := obj
+	for _, elem := range path {
+		next, found := lookupElement(current, elem)
+		if !found {
+			return types.OptionalNone
+		}
+		current = next
+	}
+
+	return types.OptionalOf(current)
+}
+
+func lookupElement(obj ref.Val, elem lookupPathElement) (ref.Val, bool) {
+	if elem.isIndex {
+		lister, ok := obj.(traits.Lister)
+		if !ok {
+			return nil, false
+		}
+		size, ok := lister.Size().(types.Int)
+		if !ok || elem.index >= int64(size) {
+			return nil, false
+		}
+		return lister.Get(types.Int(elem.index)), true
+	}
+
+	mapper, ok := obj.(traits.Mapper)
+	if !ok {
+		return nil, false
+	}
+	return mapper.Find(types.String(elem.key))
+}
+
+var errInvalidLookupPath = errors.New("invalid lookup path")
+
+type lookupPathElement struct {
+	key     string
+	index   int64
+	isIndex bool
+}
+
+// Parses paths like `.status.conditions[0].type` or `.metadata.labels['app.kubernetes.io/name']`.
+func parseLookupPath(path string) ([]lookupPathElement, error) {
+	var elems []lookupPathElement
+
+	rest := strings.TrimPrefix(path, ".")
+	for len(rest) > 0 {
+		if rest[0] == '[' {
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("%w: unterminated bracket", errInvalidLookupPath)
+			}
+			elem, err := parseBracket(rest[1:end])
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+			rest = strings.TrimPrefix(rest[end+1:], ".")
+			continue
+		}
+
+		end := strings.IndexAny(rest, ".[")
+		if end == -1 {
+			end = len(rest)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("%w: empty field name", errInvalidLookupPath)
+		}
+		elems = append(elems, lookupPathElement{key: rest[:end]})
+		rest = rest[end:]
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+			if len(rest) == 0 {
+				return nil, fmt.Errorf("%w: trailing dot", errInvalidLookupPath)
+			}
+		}
+	}
+
+	return elems, nil
+}
+
+func parseBracket(content string) (lookupPathElement, error) {
+	if len(content) >= 2 {
+		if q := content[0]; (q == '\'' || q == '"') && content[len(content)-1] == q {
+			return lookupPathElement{key: content[1 : len(content)-1]}, nil
+		}
+	}
+
+	index, err := strconv.ParseInt(content, 10, 64)
+	if err != nil || index < 0 {
+		return lookupPathElement{}, fmt.Errorf("%w: invalid index %q", errInvalidLookupPath, content)
+	}
+	return lookupPathElement{index: index, isIndex: true}, nil
+}
@@ -0,0 +1,80 @@
+package celext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	obj := map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{
+				"example.com/ready": "true",
+			},
+		},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Available", "status": "True"},
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		expr     string
+		expected any
+		err      string
+	}{
+		"nested field": {
+			expr:     `self.lookup(".status.conditions[0].type").orValue("") == "Available"`,
+			expected: true,
+		},
+		"without leading dot": {
+			expr:     `self.lookup("status.conditions[0].status").hasValue()`,
+			expected: true,
+		},
+		"quoted key": {
+			expr:     `self.lookup("metadata.annotations['example.com/ready']").orValue("") == "true"`,
+			expected: true,
+		},
+		"missing field": {
+			expr:     `self.lookup(".status.readyReplicas").hasValue()`,
+			expected: false,
+		},
+		"index out of range": {
+			expr:     `self.lookup(".status.conditions[1].type").hasValue()`,
+			expected: false,
+		},
+		"field of scalar": {
+			expr:     `self.lookup(".status.conditions[0].type.banana").hasValue()`,
+			expected: false,
+		},
+		"invalid index": {
+			expr: `self.lookup(".status.conditions[-1]").hasValue()`,
+			err:  "invalid index",
+		},
+		"unterminated bracket": {
+			expr: `self.lookup(".status.conditions[0").hasValue()`,
+			err:  "unterminated bracket",
+		},
+		"trailing dot": {
+			expr: `self.lookup(".status.").hasValue()`,
+			err:  "trailing dot",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := eval(t, tc.expr, map[string]any{"self": obj})
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
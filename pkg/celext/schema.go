@@ -0,0 +1,259 @@
+package celext
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// SchemaKind describes the shape of a value in a Schema.
+type SchemaKind string
+
+const (
+	// SchemaDyn values are not checked.
+	SchemaDyn SchemaKind = "dyn"
+	// SchemaObject values only allow access to known fields.
+	SchemaObject SchemaKind = "object"
+	// SchemaMap values allow access to any key.
+	SchemaMap SchemaKind = "map"
+	// SchemaList values allow index access.
+	SchemaList SchemaKind = "list"
+	// SchemaScalar values have no fields.
+	SchemaScalar SchemaKind = "scalar"
+)
+
+// Schema describes the structure of a variable exposed to CEL expressions,
+// so expressions can be type checked before any data is available, see Variables.
+type Schema struct {
+	Kind SchemaKind
+	// Type of scalar values, dyn if unknown.
+	Type *cel.Type
+	// Fields of an object.
+	Fields map[string]*Schema
+	// Elem describes the values of maps and lists.
+	Elem *Schema
+}
+
+var dynSchema = &Schema{Kind: SchemaDyn}
+
+// SchemaOf derives a Schema from the JSON representation of the given Go type.
+func SchemaOf(t reflect.Type) *Schema {
+	return schemaOf(t, map[reflect.Type]*Schema{})
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+func schemaOf(t reflect.Type, seen map[reflect.Type]*Schema) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if s, ok := seen[t]; ok {
+		return s
+	}
+	// Custom JSON serialization may not match the Go type.
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return dynSchema
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return dynSchema
+
+	case reflect.Map:
+		return &Schema{Kind: SchemaMap, Elem: schemaOf(t.Elem(), seen)}
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is serialized as base64 string.
+			return &Schema{Kind: SchemaScalar, Type: cel.StringType}
+		}
+		return &Schema{Kind: SchemaList, Elem: schemaOf(t.Elem(), seen)}
+
+	case reflect.Struct:
+		s := &Schema{Kind: SchemaObject, Fields: map[string]*Schema{}}
+		seen[t] = s
+		addStructFields(s, t, seen)
+		return s
+
+	case reflect.String:
+		return &Schema{Kind: SchemaScalar, Type: cel.StringType}
+
+	case reflect.Bool:
+		return &Schema{Kind: SchemaScalar, Type: cel.BoolType}
+
+	default:
+		// Numbers are decoded from JSON as double, but are commonly compared to int literals.
+		return &Schema{Kind: SchemaScalar}
+	}
+}
+
+func addStructFields(s *Schema, t reflect.Type, seen map[reflect.Type]*Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		inline := opts == "inline" || (field.Anonymous && name == "")
+		if inline && fieldType.Kind() == reflect.Struct {
+			addStructFields(s, fieldType, seen)
+			continue
+		}
+		if !field.IsExported() && (!field.Anonymous || fieldType.Kind() != reflect.Struct) {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		s.Fields[name] = schemaOf(field.Type, seen)
+	}
+}
+
+// Variables returns a cel.EnvOption declaring variables with CEL types derived from the given schemas,
+// so the CEL type checker rejects access to undefined fields and operations on mismatching types.
+// Objects are declared as object types named after their path within the given container name,
+// while their values are provided as their JSON representation, e.g. map[string]any.
+// Scalar values without known type and dyn values are declared as dyn.
+func Variables(container string, vars map[string]*Schema) cel.EnvOption {
+	return func(env *cel.Env) (*cel.Env, error) {
+		p := &schemaTypeProvider{
+			Provider: env.CELTypeProvider(),
+			objects:  map[string]map[string]*types.Type{},
+			names:    map[*Schema]string{},
+		}
+
+		names := make([]string, 0, len(vars))
+		for name := range vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		opts := []cel.EnvOption{cel.CustomTypeProvider(p)}
+		for _, name := range names {
+			opts = append(opts, cel.Variable(name, p.typeOf(vars[name], container+"."+name)))
+		}
+
+		var err error
+		for _, opt := range opts {
+			if env, err = opt(env); err != nil {
+				return nil, err
+			}
+		}
+		return env, nil
+	}
+}
+
+// schemaTypeProvider resolves object types derived from schemas
+// and falls back to the types.Provider it wraps.
+type schemaTypeProvider struct {
+	types.Provider
+	// field types by object type name.
+	objects map[string]map[string]*types.Type
+	// object type names by schema.
+	names map[*Schema]string
+}
+
+// Returns the CEL type of the given schema, registering object types under the given name.
+func (p *schemaTypeProvider) typeOf(s *Schema, name string) *types.Type {
+	switch s.Kind {
+	case SchemaObject:
+		if existing, ok := p.names[s]; ok {
+			// Recursive or shared schemas resolve to the same type.
+			return types.NewObjectType(existing)
+		}
+		p.names[s] = name
+		fields := make(map[string]*types.Type, len(s.Fields))
+		p.objects[name] = fields
+		for fieldName, field := range s.Fields {
+			fields[fieldName] = p.typeOf(field, name+"."+fieldName)
+		}
+		return types.NewObjectType(name)
+
+	case SchemaMap:
+		return types.NewMapType(types.StringType, p.typeOf(s.Elem, name+"@value"))
+
+	case SchemaList:
+		return types.NewListType(p.typeOf(s.Elem, name+"@item"))
+
+	case SchemaScalar:
+		if s.Type != nil {
+			return s.Type
+		}
+	}
+	return types.DynType
+}
+
+// FindStructType returns the object type with the given name.
+func (p *schemaTypeProvider) FindStructType(typeName string) (*types.Type, bool) {
+	if _, ok := p.objects[typeName]; ok {
+		return types.NewTypeTypeWithParam(types.NewObjectType(typeName)), true
+	}
+	return p.Provider.FindStructType(typeName)
+}
+
+// FindStructFieldType returns the type of the given field of an object type.
+// Field values are looked up in the JSON representation of the object.
+func (p *schemaTypeProvider) FindStructFieldType(typeName, fieldName string) (*types.FieldType, bool) {
+	fields, ok := p.objects[typeName]
+	if !ok {
+		return p.Provider.FindStructFieldType(typeName, fieldName)
+	}
+	fieldType, ok := fields[fieldName]
+	if !ok {
+		return nil, false
+	}
+
+	return &types.FieldType{
+		Type: fieldType,
+		IsSet: func(target any) bool {
+			_, ok := lookupField(target, fieldName)
+			return ok
+		},
+		GetFrom: func(target any) (any, error) {
+			v, ok := lookupField(target, fieldName)
+			if !ok {
+				return nil, fmt.Errorf("no such key: %s", fieldName)
+			}
+			return v, nil
+		},
+	}, true
+}
+
+// Returns the value of the given key of a map with string keys, like map[string]any.
+func lookupField(target any, key string) (any, bool) {
+	if obj, ok := target.(map[string]any); ok {
+		v, ok := obj[key]
+		return v, ok
+	}
+
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	v := rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+	if !v.IsValid() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// NewValue does not support creating object types derived from schemas.
+func (p *schemaTypeProvider) NewValue(typeName string, fields map[string]ref.Val) ref.Val {
+	if _, ok := p.objects[typeName]; ok {
+		return types.NewErr("creating %s is not supported", typeName)
+	}
+	return p.Provider.NewValue(typeName, fields)
+}
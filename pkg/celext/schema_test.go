@@ -0,0 +1,145 @@
+package celext
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type testMeta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+}
+
+type testContext struct {
+	Package struct {
+		testMeta `json:"metadata"`
+		Image    string `json:"image"`
+	} `json:"pkg"`
+	Environment struct {
+		Kubernetes struct {
+			Version string `json:"version"`
+		} `json:"kubernetes"`
+		Proxy *struct {
+			HTTPProxy string `json:"httpProxy,omitempty"`
+		} `json:"proxy,omitempty"`
+	} `json:"environment"`
+	Config  map[string]any `json:"config"`
+	Nodes   []testNode     `json:"nodes"`
+	Created metav1.Time    `json:"created"`
+	Ignored string         `json:"-"`
+}
+
+type testNode struct {
+	testMeta `json:",inline"`
+	Data     []byte     `json:"data"`
+	Children []testNode `json:"children"`
+}
+
+func TestSchemaOf(t *testing.T) {
+	t.Parallel()
+
+	s := SchemaOf(reflect.TypeOf(testContext{}))
+	require.Equal(t, SchemaObject, s.Kind)
+
+	assert.ElementsMatch(t,
+		[]string{"pkg", "environment", "config", "nodes", "created"}, keys(s.Fields))
+	assert.ElementsMatch(t,
+		[]string{"metadata", "image"}, keys(s.Fields["pkg"].Fields))
+	assert.ElementsMatch(t,
+		[]string{"name", "labels"}, keys(s.Fields["pkg"].Fields["metadata"].Fields))
+	assert.Equal(t, SchemaObject, s.Fields["environment"].Fields["proxy"].Kind)
+	assert.Equal(t, SchemaMap, s.Fields["config"].Kind)
+	assert.Equal(t, SchemaDyn, s.Fields["config"].Elem.Kind)
+	assert.Equal(t, SchemaDyn, s.Fields["created"].Kind)
+
+	node := s.Fields["nodes"].Elem
+	assert.ElementsMatch(t, []string{"name", "labels", "data", "children"}, keys(node.Fields))
+	assert.Equal(t, SchemaScalar, node.Fields["data"].Kind)
+	assert.Equal(t, cel.StringType, node.Fields["data"].Type)
+	assert.Equal(t, cel.StringType, node.Fields["name"].Type)
+	// Recursive types resolve to the same schema.
+	assert.Same(t, node, node.Fields["children"].Elem)
+}
+
+func TestVariables(t *testing.T) {
+	t.Parallel()
+
+	vars := SchemaOf(reflect.TypeOf(testContext{})).Fields
+	data := map[string]any{
+		"pkg": map[string]any{
+			"metadata": map[string]any{
+				"name":   "test",
+				"labels": map[string]any{"app": "test"},
+			},
+			"image": "quay.io/test:v1",
+		},
+		"environment": map[string]any{
+			// Objects may be provided as any map with string keys.
+			"kubernetes": map[string]string{"version": "v1.30.3"},
+		},
+		"config": map[string]any{"replicas": float64(3)},
+		"nodes": []any{
+			map[string]any{"name": "a", "children": []any{map[string]any{"name": "b"}}},
+		},
+		"created": "2024-01-01T00:00:00Z",
+	}
+
+	for name, tc := range map[string]struct {
+		expr string
+		err  string
+	}{
+		"known field":          {expr: `environment.kubernetes.version == "v1.30.3"`},
+		"leading dot":          {expr: `!has(.environment.proxy)`},
+		"embedded metadata":    {expr: `pkg.metadata.name == "test"`},
+		"map key":              {expr: `pkg.metadata.labels.app == "test"`},
+		"map index":            {expr: `pkg.metadata.labels["app"] == "test"`},
+		"dyn config":           {expr: `config.replicas == 3`},
+		"dyn scalar":           {expr: `created.startsWith("2024")`},
+		"index":                {expr: `nodes[0].children[0].name == "b"`},
+		"optional select":      {expr: `environment.?proxy.?httpProxy.orValue("none") == "none"`},
+		"comprehension":        {expr: `nodes.all(n, n.name != "")`},
+		"in function args":     {expr: `semver.atLeast(environment.kubernetes.version, "1.29")`},
+		"unknown field":        {expr: `environment.kubernetes.versoin == ""`, err: `undefined field 'versoin'`},
+		"unknown optional":     {expr: `environment.?banana.hasValue()`, err: `undefined field 'banana'`},
+		"unknown in has":       {expr: `has(.environment.openShift)`, err: `undefined field 'openShift'`},
+		"unknown in list elem": {expr: `nodes[0].banana == ""`, err: `undefined field 'banana'`},
+		"unknown in args":      {expr: `semver.atLeast(environment.kubernetes.vers, "1.29")`, err: `undefined field 'vers'`},
+		"field of scalar":      {expr: `pkg.image.tag == ""`, err: "does not support field selection"},
+		"field of list":        {expr: `nodes.name == ""`, err: "does not support field selection"},
+		"mismatching type":     {expr: `environment.kubernetes.version > 3`, err: "found no matching overload for '_>_'"},
+		"non bool condition":   {expr: `pkg.metadata.labels.app == true`, err: "found no matching overload for '_==_'"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			env, err := cel.NewEnv(append(EnvOptions(), Variables("test", vars))...)
+			require.NoError(t, err)
+
+			ast, issues := env.Compile(tc.expr)
+			if tc.err != "" {
+				require.ErrorContains(t, issues.Err(), tc.err)
+				return
+			}
+			require.NoError(t, issues.Err())
+
+			prg, err := env.Program(ast)
+			require.NoError(t, err)
+			out, _, err := prg.Eval(data)
+			require.NoError(t, err)
+			assert.Equal(t, true, out.Value())
+		})
+	}
+}
+
+func keys(m map[string]*Schema) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
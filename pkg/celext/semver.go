@@ -0,0 +1,135 @@
+package celext
+
+import (
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Semver returns a cel.EnvOption to compare versions, like `environment.kubernetes.version`.
+// Versions may be prefixed with "v" and may omit the patch version.
+// Invalid versions result in an evaluation error.
+//
+//	semver.isValid(<string>) -> <bool>
+//	semver.compare(<string>, <string>) -> <int>   // -1, 0 or 1
+//	semver.atLeast(<string>, <string>) -> <bool>  // true if the first version is >= the second
+//	semver.major(<string>) -> <int>
+//	semver.minor(<string>) -> <int>
+//	semver.patch(<string>) -> <int>
+//
+// Examples:
+//
+//	semver.atLeast(environment.kubernetes.version, "1.29")  // true for "v1.30.3"
+//	semver.compare("4.15.0-rc.1", "4.15.0")                 // -1
+//	semver.minor("v1.30.3") >= 29                           // true
+func Semver() cel.EnvOption {
+	return cel.Lib(semverLib{})
+}
+
+type semverLib struct{}
+
+func (semverLib) LibraryName() string {
+	return "package-operator.run.semver"
+}
+
+func (semverLib) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function("semver.isValid",
+			cel.Overload("semver_is_valid_string", []*cel.Type{cel.StringType}, cel.BoolType,
+				cel.UnaryBinding(func(arg ref.Val) ref.Val {
+					s, ok := arg.(types.String)
+					if !ok {
+						return types.MaybeNoSuchOverloadErr(arg)
+					}
+					_, err := parseVersion(string(s))
+					return types.Bool(err == nil)
+				}),
+			),
+		),
+		cel.Function("semver.compare",
+			cel.Overload("semver_compare_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.IntType,
+				cel.BinaryBinding(func(lhs, rhs ref.Val) ref.Val {
+					return withVersions(lhs, rhs, func(a, b *version.Version) ref.Val {
+						return types.Int(compareVersions(a, b))
+					})
+				}),
+			),
+		),
+		cel.Function("semver.atLeast",
+			cel.Overload("semver_at_least_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(func(lhs, rhs ref.Val) ref.Val {
+					return withVersions(lhs, rhs, func(a, b *version.Version) ref.Val {
+						return types.Bool(compareVersions(a, b) >= 0)
+					})
+				}),
+			),
+		),
+		versionComponentFunction("major", (*version.Version).Major),
+		versionComponentFunction("minor", (*version.Version).Minor),
+		versionComponentFunction("patch", (*version.Version).Patch),
+	}
+}
+
+func (semverLib) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+func versionComponentFunction(name string, component func(*version.Version) uint) cel.EnvOption {
+	return cel.Function("semver."+name,
+		cel.Overload("semver_"+name+"_string", []*cel.Type{cel.StringType}, cel.IntType,
+			cel.UnaryBinding(func(arg ref.Val) ref.Val {
+				s, ok := arg.(types.String)
+				if !ok {
+					return types.MaybeNoSuchOverloadErr(arg)
+				}
+				v, err := parseVersion(string(s))
+				if err != nil {
+					return types.NewErr("%v", err)
+				}
+				return types.Int(component(v)) //nolint:gosec // version components are small.
+			}),
+		),
+	)
+}
+
+func withVersions(lhs, rhs ref.Val, fn func(a, b *version.Version) ref.Val) ref.Val {
+	ls, ok := lhs.(types.String)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(lhs)
+	}
+	rs, ok := rhs.(types.String)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(rhs)
+	}
+
+	a, err := parseVersion(string(ls))
+	if err != nil {
+		return types.NewErr("%v", err)
+	}
+	b, err := parseVersion(string(rs))
+	if err != nil {
+		return types.NewErr("%v", err)
+	}
+	return fn(a, b)
+}
+
+// Parses strict semantic versions, including pre-release information,
+// and falls back to lenient parsing to support versions like "1.29" or "v1.30.3+k3s1".
+func parseVersion(s string) (*version.Version, error) {
+	if v, err := version.ParseSemantic(s); err == nil {
+		return v, nil
+	}
+	return version.ParseGeneric(s)
+}
+
+func compareVersions(a, b *version.Version) int {
+	switch {
+	case !a.AtLeast(b):
+		return -1
+	case !b.AtLeast(a):
+		return 1
+	default:
+		return 0
+	}
+}
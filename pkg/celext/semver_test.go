@@ -0,0 +1,45 @@
+package celext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemver(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		expr     string
+		expected any
+		err      string
+	}{
+		"valid":                {expr: `semver.isValid("v1.30.3")`, expected: true},
+		"valid without patch":  {expr: `semver.isValid("1.29")`, expected: true},
+		"invalid":              {expr: `semver.isValid("banana")`, expected: false},
+		"compare less":         {expr: `semver.compare("1.29.0", "v1.30.3")`, expected: int64(-1)},
+		"compare equal":        {expr: `semver.compare("v1.30.0", "1.30")`, expected: int64(0)},
+		"compare greater":      {expr: `semver.compare("1.30.3", "1.30.2")`, expected: int64(1)},
+		"compare pre-release":  {expr: `semver.compare("4.15.0-rc.1", "4.15.0")`, expected: int64(-1)},
+		"at least":             {expr: `semver.atLeast("v1.30.3", "1.29")`, expected: true},
+		"not at least":         {expr: `semver.atLeast("v1.28.3", "1.29")`, expected: false},
+		"major":                {expr: `semver.major("v1.30.3")`, expected: int64(1)},
+		"minor":                {expr: `semver.minor("v1.30.3")`, expected: int64(30)},
+		"patch":                {expr: `semver.patch("v1.30.3")`, expected: int64(3)},
+		"compare invalid":      {expr: `semver.compare("banana", "1.0.0")`, err: "banana"},
+		"component of invalid": {expr: `semver.minor("")`, err: "version"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := eval(t, tc.expr, nil)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
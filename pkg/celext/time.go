@@ -0,0 +1,117 @@
+package celext
+
+import (
+	"time"
+
+	"github.com/google/cel-go/cel"
+	celast "github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+)
+
+// Time returns a cel.EnvOption with helpers to work with timestamps, like the ones found in object status.
+// Timestamps may be given as CEL timestamps or as RFC3339 strings.
+//
+//	time.now() -> <timestamp>
+//	time.since(<timestamp|string>) -> <duration>
+//	time.until(<timestamp|string>) -> <duration>
+//
+// Examples:
+//
+//	time.since(self.metadata.creationTimestamp) > duration("5m")
+//	time.until(self.status.notAfter) > duration("720h")
+//
+// Results change without the evaluated data changing, so expressions using these helpers
+// need to be re-evaluated periodically, see UsesTime.
+// They are not part of EnvOptions and only available where that is the case.
+func Time() cel.EnvOption {
+	return cel.Lib(timeLib{})
+}
+
+var timeFunctions = []string{"now", "since", "until"}
+
+// UsesTime returns true, if the given expression calls any of the Time helpers.
+// Expressions that can't be parsed return false.
+func UsesTime(expr string) bool {
+	env, err := cel.NewEnv(Time())
+	if err != nil {
+		return false
+	}
+	ast, issues := env.Parse(expr)
+	if issues.Err() != nil {
+		return false
+	}
+
+	root := celast.NavigateCheckedAST(&celast.CheckedAST{Expr: ast.Expr()})
+	calls := celast.MatchDescendants(root, celast.KindMatcher(celast.CallKind))
+	for _, e := range calls {
+		call := e.AsCall()
+		name := call.FunctionName()
+		// Parsed expressions reference namespaced functions as receiver calls.
+		if target := call.Target(); target != nil &&
+			target.Kind() == celast.IdentKind && target.AsIdent() == "time" {
+			name = "time." + name
+		}
+		for _, fn := range timeFunctions {
+			if name == "time."+fn {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type timeLib struct{}
+
+func (timeLib) LibraryName() string {
+	return "package-operator.run.time"
+}
+
+func (timeLib) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function("time.now",
+			cel.Overload("time_now", []*cel.Type{}, cel.TimestampType,
+				cel.FunctionBinding(func(...ref.Val) ref.Val {
+					return types.Timestamp{Time: time.Now().UTC()}
+				}),
+			),
+		),
+		cel.Function("time.since",
+			cel.Overload("time_since_timestamp", []*cel.Type{cel.TimestampType}, cel.DurationType,
+				cel.UnaryBinding(timeBinding(time.Since)),
+			),
+			cel.Overload("time_since_string", []*cel.Type{cel.StringType}, cel.DurationType,
+				cel.UnaryBinding(timeBinding(time.Since)),
+			),
+		),
+		cel.Function("time.until",
+			cel.Overload("time_until_timestamp", []*cel.Type{cel.TimestampType}, cel.DurationType,
+				cel.UnaryBinding(timeBinding(time.Until)),
+			),
+			cel.Overload("time_until_string", []*cel.Type{cel.StringType}, cel.DurationType,
+				cel.UnaryBinding(timeBinding(time.Until)),
+			),
+		),
+	}
+}
+
+func (timeLib) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+func timeBinding(fn func(time.Time) time.Duration) func(ref.Val) ref.Val {
+	return func(arg ref.Val) ref.Val {
+		switch v := arg.(type) {
+		case types.Timestamp:
+			return types.Duration{Duration: fn(v.Time)}
+		case types.String:
+			t, err := time.Parse(time.RFC3339, string(v))
+			if err != nil {
+				return types.NewErr("parsing timestamp: %v", err)
+			}
+			return types.Duration{Duration: fn(t)}
+		default:
+			return types.MaybeNoSuchOverloadErr(arg)
+		}
+	}
+}
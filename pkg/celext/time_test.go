@@ -0,0 +1,57 @@
+package celext
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTime(t *testing.T) {
+	t.Parallel()
+
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	for name, tc := range map[string]struct {
+		expr     string
+		expected any
+		err      string
+	}{
+		"now":               {expr: `time.now() > timestamp("2024-01-01T00:00:00Z")`, expected: true},
+		"since string":      {expr: `time.since(ts) > duration("30m")`, expected: true},
+		"since timestamp":   {expr: `time.since(timestamp(ts)) < duration("2h")`, expected: true},
+		"until string":      {expr: `time.until(future) > duration("30m")`, expected: true},
+		"until in past":     {expr: `time.until(ts) < duration("0s")`, expected: true},
+		"invalid timestamp": {expr: `time.since("yesterday") > duration("1h")`, err: "parsing timestamp"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := eval(t, tc.expr, map[string]any{"ts": past, "future": future}, Time())
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestUsesTime(t *testing.T) {
+	t.Parallel()
+
+	for expr, expected := range map[string]bool{
+		`time.now() > timestamp("2024-01-01T00:00:00Z")`:                                    true,
+		`self.status.ready && time.since(self.metadata.creationTimestamp) > duration("5m")`: true,
+		`objects.all(o, time.until(o.status.notAfter) > duration("720h"))`:                  true,
+		`self.status.ready`:            false,
+		`self.time.since == "1h"`:      false,
+		`timestamp(self.at) > self.to`: false,
+		`invalid (`:                    false,
+	} {
+		assert.Equal(t, expected, UsesTime(expr), expr)
+	}
+}
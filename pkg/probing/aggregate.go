@@ -0,0 +1,213 @@
+package probing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	celast "github.com/google/cel-go/common/ast"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// AggregateProber checks a set of Kubernetes objects at once
+// and reports success or failure with a failure message.
+type AggregateProber interface {
+	ProbeAggregate(objs []*unstructured.Unstructured, resolve ReferenceResolver) (success bool, message string)
+}
+
+// ObjectReference references an object by kind and name.
+type ObjectReference struct {
+	// Alias the object is made available as.
+	Alias string
+	schema.GroupVersionKind
+	Namespace, Name string
+}
+
+// ReferenceResolver looks up referenced objects.
+// Objects that do not exist have to be reported with a NotFound error,
+// as returned by Kubernetes clients.
+type ReferenceResolver func(ref ObjectReference) (*unstructured.Unstructured, error)
+
+var _ AggregateProber = (And)(nil)
+
+// ProbeAggregate executes all AggregateProbers contained in the list.
+// Messages of failing Probers will be joined with ", ", returning a single string.
+func (p And) ProbeAggregate(
+	objs []*unstructured.Unstructured, resolve ReferenceResolver,
+) (success bool, message string) {
+	var messages []string
+	for _, probe := range p {
+		ap, ok := probe.(AggregateProber)
+		if !ok {
+			continue
+		}
+		if success, message := ap.ProbeAggregate(objs, resolve); !success {
+			messages = append(messages, message)
+		}
+	}
+	if len(messages) > 0 {
+		return false, strings.Join(messages, ", ")
+	}
+	return true, ""
+}
+
+// AggregateSelector wraps an AggregateProber and only passes objects
+// matching the given GroupKind and label selector to it.
+// If no object matches, AggregateProbers depending on the selected objects are not executed.
+// Probes only checking referenced objects always run.
+// When probing individual objects, it always succeeds,
+// so it can be combined with other Probers via And.
+type AggregateSelector struct {
+	AggregateProber
+	// Optional GroupKind objects need to match.
+	GroupKind *schema.GroupKind
+	// Optional label selector objects need to match.
+	Selector labels.Selector
+}
+
+var (
+	_ Prober          = (*AggregateSelector)(nil)
+	_ AggregateProber = (*AggregateSelector)(nil)
+)
+
+// Probe executes the probe.
+func (as *AggregateSelector) Probe(*unstructured.Unstructured) (success bool, message string) {
+	// Aggregate probes only run via ProbeAggregate.
+	return true, ""
+}
+
+// ProbeAggregate executes the probe.
+func (as *AggregateSelector) ProbeAggregate(
+	objs []*unstructured.Unstructured, resolve ReferenceResolver,
+) (success bool, message string) {
+	selected := as.Select(objs)
+	if len(selected) == 0 && dependsOnObjects(as.AggregateProber) {
+		return true, ""
+	}
+	return as.AggregateProber.ProbeAggregate(selected, resolve)
+}
+
+// Implemented by AggregateProbers that might not access the probed objects.
+// AggregateProbers not implementing it are assumed to depend on them.
+type objectsDependency interface {
+	DependsOnObjects() bool
+}
+
+func dependsOnObjects(p AggregateProber) bool {
+	d, ok := p.(objectsDependency)
+	return !ok || d.DependsOnObjects()
+}
+
+// Select returns all objects matching the GroupKind and label selector.
+func (as *AggregateSelector) Select(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+	selected := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		if as.GroupKind != nil &&
+			*as.GroupKind != obj.GetObjectKind().GroupVersionKind().GroupKind() {
+			continue
+		}
+		if as.Selector != nil && !as.Selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		selected = append(selected, obj)
+	}
+	return selected
+}
+
+// AggregateCELProbe uses the common expression language
+// to probe a set of objects and referenced objects at once.
+type AggregateCELProbe struct {
+	Program    cel.Program
+	Message    string
+	References []ObjectReference
+	// Whether the rule accesses `objects`.
+	usesObjects bool
+}
+
+var (
+	_ AggregateProber   = (*AggregateCELProbe)(nil)
+	_ objectsDependency = (*AggregateCELProbe)(nil)
+)
+
+// NewAggregateCELProbe creates a new aggregate CEL (Common Expression Language) Probe.
+// The CEL expression needs to evaluate to a bool and has access to
+// all probed objects as `objects` and to referenced objects as `references.<alias>`.
+func NewAggregateCELProbe(rule, message string, refs []ObjectReference) (
+	*AggregateCELProbe, error,
+) {
+	prgm, err := compileCELRule(rule,
+		cel.Variable("objects", cel.ListType(cel.DynType)),
+		cel.Variable("references", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AggregateCELProbe{
+		Program:     prgm,
+		Message:     message,
+		References:  refs,
+		usesObjects: usesVariable(rule, "objects"),
+	}, nil
+}
+
+// DependsOnObjects returns true if the rule accesses the probed objects.
+func (p *AggregateCELProbe) DependsOnObjects() bool {
+	return p.usesObjects
+}
+
+// ProbeAggregate executes the probe.
+func (p *AggregateCELProbe) ProbeAggregate(
+	objs []*unstructured.Unstructured, resolve ReferenceResolver,
+) (success bool, message string) {
+	objects := make([]any, len(objs))
+	for i, obj := range objs {
+		objects[i] = obj.Object
+	}
+
+	references := map[string]any{}
+	for _, ref := range p.References {
+		obj, err := resolve(ref)
+		switch {
+		case apimachineryerrors.IsNotFound(err):
+			references[ref.Alias] = nil
+		case err != nil:
+			return false, fmt.Sprintf("resolving reference %q: %v", ref.Alias, err)
+		default:
+			references[ref.Alias] = obj.Object
+		}
+	}
+
+	val, _, err := p.Program.Eval(map[string]any{
+		"objects":    objects,
+		"references": references,
+	})
+	if err != nil {
+		return false, fmt.Sprintf("CEL program failed: %v", err)
+	}
+
+	return val.Value().(bool), p.Message
+}
+
+// Returns true if the given expression accesses the given variable.
+func usesVariable(expr, name string) bool {
+	env, err := cel.NewEnv()
+	if err != nil {
+		return true
+	}
+	ast, issues := env.Parse(expr)
+	if issues.Err() != nil {
+		return true
+	}
+
+	root := celast.NavigateCheckedAST(&celast.CheckedAST{Expr: ast.Expr()})
+	for _, e := range celast.MatchDescendants(root, celast.KindMatcher(celast.IdentKind)) {
+		if e.AsIdent() == name {
+			return true
+		}
+	}
+	return false
+}
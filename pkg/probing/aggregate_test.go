@@ -0,0 +1,212 @@
+package probing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func deploymentWithReplicas(name string, available int64) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":   name,
+			"labels": map[string]any{"app": name},
+		},
+		"status": map[string]any{
+			"availableReplicas": available,
+		},
+	}}
+	return obj
+}
+
+func noReferences(ref ObjectReference) (*unstructured.Unstructured, error) {
+	return nil, apimachineryerrors.NewNotFound(schema.GroupResource{Group: ref.Group}, ref.Name)
+}
+
+func Test_NewAggregateCELProbe(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAggregateCELProbe(`objects.size()`, "", nil)
+	require.ErrorIs(t, err, ErrCELInvalidEvaluationType)
+
+	_, err = NewAggregateCELProbe(`self.test`, "", nil)
+	require.Error(t, err)
+}
+
+func TestAggregateCELProbe(t *testing.T) {
+	t.Parallel()
+
+	objs := []*unstructured.Unstructured{
+		deploymentWithReplicas("a", 1),
+		deploymentWithReplicas("b", 0),
+		deploymentWithReplicas("c", 3),
+	}
+
+	for name, tc := range map[string]struct {
+		rule    string
+		success bool
+	}{
+		"at least 2 available": {
+			rule:    `objects.filter(o, o.status.availableReplicas > 0).size() >= 2`,
+			success: true,
+		},
+		"all available": {
+			rule:    `objects.all(o, o.status.availableReplicas > 0)`,
+			success: false,
+		},
+		"specific object": {
+			rule:    `objects.exists(o, o.metadata.name == "c" && o.status.availableReplicas >= 3)`,
+			success: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := NewAggregateCELProbe(tc.rule, "nope", nil)
+			require.NoError(t, err)
+
+			success, message := p.ProbeAggregate(objs, noReferences)
+			assert.Equal(t, tc.success, success)
+			assert.Equal(t, "nope", message)
+		})
+	}
+}
+
+func TestAggregateCELProbe_references(t *testing.T) {
+	t.Parallel()
+
+	ref := ObjectReference{
+		Alias:            "database",
+		GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		Namespace:        "test",
+		Name:             "database",
+	}
+	p, err := NewAggregateCELProbe(
+		`references.database != null && references.database.status.availableReplicas > 0`,
+		"database not available", []ObjectReference{ref})
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		success, _ := p.ProbeAggregate(nil, func(r ObjectReference) (*unstructured.Unstructured, error) {
+			assert.Equal(t, ref, r)
+			return deploymentWithReplicas("database", 1), nil
+		})
+		assert.True(t, success)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		success, message := p.ProbeAggregate(nil, noReferences)
+		assert.False(t, success)
+		assert.Equal(t, "database not available", message)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		success, message := p.ProbeAggregate(nil, func(ObjectReference) (*unstructured.Unstructured, error) {
+			return nil, errors.New("explosion")
+		})
+		assert.False(t, success)
+		assert.Equal(t, `resolving reference "database": explosion`, message)
+	})
+}
+
+func TestAggregateSelector(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewAggregateCELProbe(`objects.size() == 1 && objects[0].metadata.name == "b"`, "nope", nil)
+	require.NoError(t, err)
+
+	cm := &unstructured.Unstructured{}
+	cm.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+	cm.SetLabels(map[string]string{"app": "b"})
+
+	s := &AggregateSelector{
+		AggregateProber: p,
+		GroupKind:       &schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Selector:        labels.SelectorFromSet(labels.Set{"app": "b"}),
+	}
+
+	success, _ := s.ProbeAggregate([]*unstructured.Unstructured{
+		deploymentWithReplicas("a", 1),
+		deploymentWithReplicas("b", 0),
+		cm,
+	}, noReferences)
+	assert.True(t, success)
+
+	// Nothing selected, e.g. in another phase.
+	success, message := s.ProbeAggregate([]*unstructured.Unstructured{cm}, noReferences)
+	assert.True(t, success)
+	assert.Empty(t, message)
+
+	// Individual objects are never probed.
+	success, message = s.Probe(cm)
+	assert.True(t, success)
+	assert.Empty(t, message)
+}
+
+func TestAggregateSelector_referencesOnly(t *testing.T) {
+	t.Parallel()
+
+	ref := ObjectReference{
+		Alias:            "issuer",
+		GroupVersionKind: schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "ClusterIssuer"},
+		Name:             "letsencrypt",
+	}
+	p, err := NewAggregateCELProbe(`references.issuer != null`, "issuer missing", []ObjectReference{ref})
+	require.NoError(t, err)
+	assert.False(t, p.DependsOnObjects())
+
+	s := &AggregateSelector{
+		AggregateProber: p,
+		GroupKind:       &schema.GroupKind{Group: "cert-manager.io", Kind: "Certificate"},
+	}
+
+	// Probes only checking references run without any selected objects.
+	success, message := s.ProbeAggregate(nil, noReferences)
+	assert.False(t, success)
+	assert.Equal(t, "issuer missing", message)
+
+	success, _ = s.ProbeAggregate(nil, func(ObjectReference) (*unstructured.Unstructured, error) {
+		issuer := &unstructured.Unstructured{}
+		issuer.SetGroupVersionKind(ref.GroupVersionKind)
+		issuer.SetName(ref.Name)
+		return issuer, nil
+	})
+	assert.True(t, success)
+}
+
+func TestAnd_ProbeAggregate(t *testing.T) {
+	t.Parallel()
+
+	failing, err := NewAggregateCELProbe(`false`, "fails", nil)
+	require.NoError(t, err)
+	failing2, err := NewAggregateCELProbe(`objects.size() > 5`, "too few", nil)
+	require.NoError(t, err)
+
+	prober := &proberMock{}
+	a := And{
+		prober,
+		&AggregateSelector{AggregateProber: failing},
+		&AggregateSelector{AggregateProber: failing2},
+	}
+
+	success, message := a.ProbeAggregate([]*unstructured.Unstructured{
+		deploymentWithReplicas("a", 1),
+	}, noReferences)
+	assert.False(t, success)
+	assert.Equal(t, "fails, too few", message)
+	prober.AssertNotCalled(t, "Probe")
+}
@@ -5,9 +5,9 @@ import (
 	"fmt"
 
 	"github.com/google/cel-go/cel"
-	"github.com/google/cel-go/ext"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apiserver/pkg/cel/library"
+
+	"package-operator.run/pkg/celext"
 )
 
 // CELProbe uses the common expression language for probing.
@@ -26,32 +26,9 @@ var ErrCELInvalidEvaluationType = errors.New("cel expression must evaluate to a
 func NewCELProbe(rule, message string) (
 	*CELProbe, error,
 ) {
-	env, err := cel.NewEnv(
-		cel.Variable("self", cel.DynType),
-		cel.HomogeneousAggregateLiterals(),
-		cel.EagerlyValidateDeclarations(true),
-		cel.DefaultUTCTimeZone(true),
-
-		ext.Strings(ext.StringsVersion(0)),
-		library.URLs(),
-		library.Regex(),
-		library.Lists(),
-	)
+	prgm, err := compileCELRule(rule, cel.Variable("self", cel.DynType))
 	if err != nil {
-		return nil, fmt.Errorf("creating CEL env: %w", err)
-	}
-
-	ast, issues := env.Compile(rule)
-	if issues != nil {
-		return nil, fmt.Errorf("compiling CEL: %w", issues.Err())
-	}
-	if ast.OutputType() != cel.BoolType {
-		return nil, ErrCELInvalidEvaluationType
-	}
-
-	prgm, err := env.Program(ast)
-	if err != nil {
-		return nil, fmt.Errorf("CEL program failed: %w", err)
+		return nil, err
 	}
 
 	return &CELProbe{
@@ -71,3 +48,29 @@ func (p *CELProbe) Probe(obj *unstructured.Unstructured) (success bool, message
 
 	return val.Value().(bool), p.Message
 }
+
+// Compiles a CEL rule, that needs to evaluate to a bool,
+// into a program with the given variables declared.
+func compileCELRule(rule string, variables ...cel.EnvOption) (cel.Program, error) {
+	// Unlike other CEL expressions, probes are re-evaluated periodically
+	// and may use time helpers, see celext.UsesTime.
+	opts := append(celext.EnvOptions(), celext.Time())
+	env, err := cel.NewEnv(append(opts, variables...)...)
+	if err != nil {
+		return nil, fmt.Errorf("creating CEL env: %w", err)
+	}
+
+	ast, issues := env.Compile(rule)
+	if issues != nil {
+		return nil, fmt.Errorf("compiling CEL: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, ErrCELInvalidEvaluationType
+	}
+
+	prgm, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("CEL program failed: %w", err)
+	}
+	return prgm, nil
+}
@@ -107,6 +107,43 @@ func Test_celProbe(t *testing.T) {
 			},
 			success: false,
 		},
+		{
+			name:    "optional lookup of missing field",
+			rule:    `self.lookup(".status.readyReplicas").orValue(0) >= 1`,
+			message: "aaaaaah!",
+			obj: &unstructured.Unstructured{
+				Object: map[string]any{
+					"status": map[string]any{},
+				},
+			},
+			success: false,
+		},
+		{
+			name:    "quantity comparison",
+			rule:    `quantity(self.spec.memory).isGreaterThan(quantity("1Gi"))`,
+			message: "aaaaaah!",
+			obj: &unstructured.Unstructured{
+				Object: map[string]any{
+					"spec": map[string]any{
+						"memory": "2Gi",
+					},
+				},
+			},
+			success: true,
+		},
+		{
+			name:    "time since",
+			rule:    `time.since(self.metadata.creationTimestamp) > duration("1h")`,
+			message: "aaaaaah!",
+			obj: &unstructured.Unstructured{
+				Object: map[string]any{
+					"metadata": map[string]any{
+						"creationTimestamp": "2024-01-01T00:00:00Z",
+					},
+				},
+			},
+			success: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,113 @@
+package probing
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ProbeType identifies the kind of probe a Failure originates from.
+type ProbeType string
+
+// Types of the probes in this package.
+const (
+	ProbeTypeCondition          ProbeType = "Condition"
+	ProbeTypeFieldsEqual        ProbeType = "FieldsEqual"
+	ProbeTypeCEL                ProbeType = "CEL"
+	ProbeTypeHTTPGet            ProbeType = "HTTPGet"
+	ProbeTypeTCPSocket          ProbeType = "TCPSocket"
+	ProbeTypeObservedGeneration ProbeType = "ObservedGeneration"
+	ProbeTypeAggregate          ProbeType = "Aggregate"
+	// Probers not known to this package.
+	ProbeTypeCustom ProbeType = "Custom"
+)
+
+// Failure of a single probe.
+type Failure struct {
+	// Type of the failing probe.
+	Type ProbeType
+	// Message of the failing probe.
+	Message string
+}
+
+// DetailedProber is implemented by Probers combining or wrapping other Probers,
+// to report every failing probe individually, instead of a single joined message.
+type DetailedProber interface {
+	Prober
+	ProbeDetailed(obj *unstructured.Unstructured) []Failure
+}
+
+var (
+	_ DetailedProber = (And)(nil)
+	_ DetailedProber = (*GroupKindSelector)(nil)
+	_ DetailedProber = (*LabelSelector)(nil)
+	_ DetailedProber = (*ObservedGenerationProbe)(nil)
+)
+
+// ProbeDetailed executes the given Prober and returns every failing probe.
+// Probers not implementing DetailedProber are reported as a single Failure.
+// The messages of all failures are the same as the ones
+// joined by Prober.Probe, in the same order.
+func ProbeDetailed(probe Prober, obj *unstructured.Unstructured) []Failure {
+	if dp, ok := probe.(DetailedProber); ok {
+		return dp.ProbeDetailed(obj)
+	}
+	if success, message := probe.Probe(obj); !success {
+		return []Failure{{Type: TypeOf(probe), Message: message}}
+	}
+	return nil
+}
+
+// TypeOf returns the ProbeType of the given Prober.
+func TypeOf(probe Prober) ProbeType {
+	switch probe.(type) {
+	case *ConditionProbe:
+		return ProbeTypeCondition
+	case *FieldsEqualProbe:
+		return ProbeTypeFieldsEqual
+	case *CELProbe:
+		return ProbeTypeCEL
+	case *HTTPGetProbe:
+		return ProbeTypeHTTPGet
+	case *TCPSocketProbe:
+		return ProbeTypeTCPSocket
+	case *ObservedGenerationProbe:
+		return ProbeTypeObservedGeneration
+	case *AggregateSelector:
+		return ProbeTypeAggregate
+	default:
+		return ProbeTypeCustom
+	}
+}
+
+// ProbeDetailed executes all probes and returns every failing probe.
+func (p And) ProbeDetailed(obj *unstructured.Unstructured) []Failure {
+	var failures []Failure
+	for _, probe := range p {
+		failures = append(failures, ProbeDetailed(probe, obj)...)
+	}
+	return failures
+}
+
+// ProbeDetailed executes the probe, if the object matches the selector.
+func (kp *GroupKindSelector) ProbeDetailed(obj *unstructured.Unstructured) []Failure {
+	if kp.GroupKind != obj.GetObjectKind().GroupVersionKind().GroupKind() {
+		return nil
+	}
+	return ProbeDetailed(kp.Prober, obj)
+}
+
+// ProbeDetailed executes the probe, if the object matches the selector.
+func (ss *LabelSelector) ProbeDetailed(obj *unstructured.Unstructured) []Failure {
+	if !ss.Selector.Matches(labels.Set(obj.GetLabels())) {
+		return nil
+	}
+	return ProbeDetailed(ss.Prober, obj)
+}
+
+// ProbeDetailed executes the probe, if .status.observedGeneration is up-to-date.
+func (cg *ObservedGenerationProbe) ProbeDetailed(obj *unstructured.Unstructured) []Failure {
+	if success, message := probeObservedGeneration(obj); !success {
+		return []Failure{{Type: ProbeTypeObservedGeneration, Message: message}}
+	}
+	return ProbeDetailed(cg.Prober, obj)
+}
@@ -0,0 +1,112 @@
+package probing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestProbeDetailed(t *testing.T) {
+	t.Parallel()
+
+	failing := &proberMock{}
+	failing.On("Probe", mock.Anything).Return(false, "custom failure")
+
+	probe := &ObservedGenerationProbe{
+		Prober: And{
+			&GroupKindSelector{
+				GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+				Prober: And{
+					&ConditionProbe{Type: "Available", Status: "True"},
+					&FieldsEqualProbe{FieldA: ".spec.replicas", FieldB: ".status.replicas"},
+				},
+			},
+			&LabelSelector{
+				Selector: labels.SelectorFromSet(labels.Set{"probe": "custom"}),
+				Prober:   failing,
+			},
+			&GroupKindSelector{
+				GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+				Prober:    failing,
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		obj      map[string]any
+		failures []Failure
+	}{
+		"passing": {
+			obj: map[string]any{
+				"spec":   map[string]any{"replicas": int64(1)},
+				"status": map[string]any{"replicas": int64(1), "conditions": []any{availableCondition}},
+			},
+		},
+		"outdated": {
+			obj: map[string]any{
+				"metadata": map[string]any{"generation": int64(2)},
+				"status":   map[string]any{"observedGeneration": int64(1)},
+			},
+			failures: []Failure{
+				{Type: ProbeTypeObservedGeneration, Message: ".status outdated"},
+			},
+		},
+		"failing": {
+			obj: map[string]any{
+				"metadata": map[string]any{"labels": map[string]any{"probe": "custom"}},
+				"spec":     map[string]any{"replicas": int64(2)},
+				"status":   map[string]any{"replicas": int64(1)},
+			},
+			failures: []Failure{
+				{Type: ProbeTypeCondition, Message: `condition "Available" == "True": missing .status.conditions`},
+				{Type: ProbeTypeFieldsEqual, Message: `".spec.replicas" == ".status.replicas": "2" != "1"`},
+				{Type: ProbeTypeCustom, Message: "custom failure"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			obj := &unstructured.Unstructured{Object: test.obj}
+			obj.SetAPIVersion("apps/v1")
+			obj.SetKind("Deployment")
+
+			failures := ProbeDetailed(probe, obj)
+			assert.Equal(t, test.failures, failures)
+
+			// Messages must match the non-detailed probe.
+			success, message := probe.Probe(obj)
+			assert.Equal(t, len(test.failures) == 0, success)
+			messages := make([]string, len(failures))
+			for i, f := range failures {
+				messages[i] = f.Message
+			}
+			assert.Equal(t, message, strings.Join(messages, ", "))
+		})
+	}
+}
+
+func TestTypeOf(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ProbeTypeCondition, TypeOf(&ConditionProbe{}))
+	assert.Equal(t, ProbeTypeFieldsEqual, TypeOf(&FieldsEqualProbe{}))
+	assert.Equal(t, ProbeTypeCEL, TypeOf(&CELProbe{}))
+	assert.Equal(t, ProbeTypeHTTPGet, TypeOf(&HTTPGetProbe{}))
+	assert.Equal(t, ProbeTypeTCPSocket, TypeOf(&TCPSocketProbe{}))
+	assert.Equal(t, ProbeTypeObservedGeneration, TypeOf(&ObservedGenerationProbe{}))
+	assert.Equal(t, ProbeTypeAggregate, TypeOf(&AggregateSelector{}))
+	assert.Equal(t, ProbeTypeCustom, TypeOf(&proberMock{}))
+}
+
+var availableCondition = map[string]any{
+	"type":   "Available",
+	"status": "True",
+}
@@ -0,0 +1,293 @@
+package probing
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Endpoints connects to endpoints exposed by Services and Routes.
+type Endpoints interface {
+	// HTTPGet sends a GET request to the given port and path of a Service or Route
+	// and returns the status code and body of the response.
+	HTTPGet(
+		ctx context.Context, obj *unstructured.Unstructured,
+		scheme string, port *intstr.IntOrString, path string,
+	) (statusCode int, body []byte, err error)
+	// DialTCP opens a TCP connection to the given port of a Service or Route.
+	DialTCP(ctx context.Context, obj *unstructured.Unstructured, port *intstr.IntOrString) (net.Conn, error)
+}
+
+var (
+	// ErrUnsupportedEndpoint is returned when probing endpoints of objects other than Services and Routes.
+	ErrUnsupportedEndpoint = errors.New("endpoint probes only support Services and Routes")
+	// ErrServicePortNotFound is returned when a Service does not expose the requested port.
+	ErrServicePortNotFound = errors.New("service port not found")
+	// ErrRouteHostNotFound is returned when a Route has no host assigned.
+	ErrRouteHostNotFound = errors.New("route has no host")
+	// ErrEndpointTimeBudgetExhausted is returned by BoundEndpoints,
+	// when the time available for connections to the endpoints of an object has been used up.
+	// Endpoint probes report it as not probed yet, instead of as a failure of the endpoint.
+	ErrEndpointTimeBudgetExhausted = errors.New("time budget for endpoint probes exhausted")
+)
+
+var (
+	serviceGroupKind = schema.GroupKind{Kind: "Service"}
+	routeGroupKind   = schema.GroupKind{Group: "route.openshift.io", Kind: "Route"}
+)
+
+// Upper limit of response bodies read for probing.
+const maxResponseBodyBytes = 1 << 20
+
+// DirectEndpoints connects to Services via their cluster DNS name and to Routes via their host.
+// Connecting to Services requires running within the cluster network.
+type DirectEndpoints struct {
+	// Client sending HTTP requests.
+	// Defaults to a client that, like kubelet probes, does not verify TLS certificates.
+	Client *http.Client
+}
+
+var _ Endpoints = (*DirectEndpoints)(nil)
+
+// HTTPGet sends a GET request to the given port and path of a Service or Route.
+func (e *DirectEndpoints) HTTPGet(
+	ctx context.Context, obj *unstructured.Unstructured,
+	scheme string, port *intstr.IntOrString, path string,
+) (statusCode int, body []byte, err error) {
+	scheme, address, err := directAddress(obj, scheme, port)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheme+"://"+address+path, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	resp, err := e.client().Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyBytes))
+	if err != nil {
+		return 0, nil, fmt.Errorf("reading response body: %w", err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// DialTCP opens a TCP connection to the given port of a Service or Route.
+func (e *DirectEndpoints) DialTCP(
+	ctx context.Context, obj *unstructured.Unstructured, port *intstr.IntOrString,
+) (net.Conn, error) {
+	_, address, err := directAddress(obj, "", port)
+	if err != nil {
+		return nil, err
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "tcp", address)
+}
+
+func (e *DirectEndpoints) client() *http.Client {
+	if e.Client != nil {
+		return e.Client
+	}
+	return defaultEndpointClient
+}
+
+var defaultEndpointClient = &http.Client{
+	Transport: &http.Transport{
+		//nolint:gosec // Like kubelet probes, certificates of probed endpoints are not verified.
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	},
+}
+
+// Returns the scheme and host:port to connect to the given port of a Service or Route.
+func directAddress(
+	obj *unstructured.Unstructured, scheme string, port *intstr.IntOrString,
+) (string, string, error) {
+	switch gk := obj.GroupVersionKind().GroupKind(); gk {
+	case serviceGroupKind:
+		portNumber, err := ServicePort(obj, port)
+		if err != nil {
+			return "", "", err
+		}
+		if len(scheme) == 0 {
+			scheme = "http"
+		}
+		host := fmt.Sprintf("%s.%s.svc", obj.GetName(), obj.GetNamespace())
+		return strings.ToLower(scheme), net.JoinHostPort(host, strconv.FormatInt(portNumber, 10)), nil
+
+	case routeGroupKind:
+		host, _, _ := unstructured.NestedString(obj.Object, "spec", "host")
+		if len(host) == 0 {
+			return "", "", ErrRouteHostNotFound
+		}
+		if routeTLS, ok, _ := unstructured.NestedMap(obj.Object, "spec", "tls"); ok && routeTLS != nil {
+			return "https", net.JoinHostPort(host, "443"), nil
+		}
+		return "http", net.JoinHostPort(host, "80"), nil
+
+	default:
+		return "", "", fmt.Errorf("%w: %s", ErrUnsupportedEndpoint, gk)
+	}
+}
+
+// ServicePort returns the number of the given port of a Service, referenced by name or number.
+// Defaults to the first port of the Service.
+func ServicePort(svc *unstructured.Unstructured, port *intstr.IntOrString) (int64, error) {
+	ports, _, err := unstructured.NestedSlice(svc.Object, "spec", "ports")
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range ports {
+		portSpec, ok := p.(map[string]any)
+		if !ok {
+			continue
+		}
+		number, _, _ := unstructured.NestedInt64(portSpec, "port")
+		name, _, _ := unstructured.NestedString(portSpec, "name")
+
+		switch {
+		case port == nil,
+			port.Type == intstr.Int && int64(port.IntVal) == number,
+			port.Type == intstr.String && port.StrVal == name:
+			return number, nil
+		}
+	}
+
+	if port == nil {
+		return 0, ErrServicePortNotFound
+	}
+	return 0, fmt.Errorf("%w: %s", ErrServicePortNotFound, port.String())
+}
+
+// BoundEndpoints binds all connections made via the wrapped Endpoints to a common context
+// and optionally caps the time spent connecting to the endpoints of each object.
+// Endpoint probes are executed one after another,
+// so the time needed for probing many slow endpoints adds up.
+type BoundEndpoints struct {
+	Endpoints
+	// Context all connections are bound to, e.g. of the current reconcile.
+	Context context.Context
+	// Total time all connections to the endpoints of a single object may take combined.
+	// Zero means no limit.
+	Budget time.Duration
+
+	mux   sync.Mutex
+	spent map[endpointObjectKey]time.Duration
+}
+
+type endpointObjectKey struct {
+	schema.GroupKind
+	Namespace, Name string
+}
+
+var _ Endpoints = (*BoundEndpoints)(nil)
+
+// HTTPGet sends a GET request to the given port and path of a Service or Route.
+func (e *BoundEndpoints) HTTPGet(
+	ctx context.Context, obj *unstructured.Unstructured,
+	scheme string, port *intstr.IntOrString, path string,
+) (statusCode int, body []byte, err error) {
+	boundCtx, done, err := e.bind(ctx, obj)
+	if err != nil {
+		return 0, nil, err
+	}
+	statusCode, body, err = e.Endpoints.HTTPGet(boundCtx, obj, scheme, port, path)
+	return statusCode, body, done(ctx, err)
+}
+
+// DialTCP opens a TCP connection to the given port of a Service or Route.
+func (e *BoundEndpoints) DialTCP(
+	ctx context.Context, obj *unstructured.Unstructured, port *intstr.IntOrString,
+) (net.Conn, error) {
+	boundCtx, done, err := e.bind(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := e.Endpoints.DialTCP(boundCtx, obj, port)
+	return conn, done(ctx, err)
+}
+
+// Derives a context from ctx, that is also cancelled with the bound context
+// or when the remaining budget of the given object runs out.
+// The returned func must be called with the result of the connection attempt
+// and reports attempts aborted by the budget as ErrEndpointTimeBudgetExhausted.
+func (e *BoundEndpoints) bind(
+	ctx context.Context, obj *unstructured.Unstructured,
+) (context.Context, func(ctx context.Context, err error) error, error) {
+	if e.Context != nil {
+		if err := e.Context.Err(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	key := endpointObjectKey{
+		GroupKind: obj.GroupVersionKind().GroupKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+	e.mux.Lock()
+	remaining := e.Budget - e.spent[key]
+	e.mux.Unlock()
+	if e.Budget > 0 && remaining <= 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrEndpointTimeBudgetExhausted, e.Budget)
+	}
+
+	start := time.Now()
+	boundCtx, cancel := context.WithCancel(ctx)
+	stop := func() bool { return false }
+	if e.Context != nil {
+		stop = context.AfterFunc(e.Context, cancel)
+	}
+	var budgetCtx context.Context
+	if e.Budget > 0 {
+		var cancelDeadline context.CancelFunc
+		budgetCtx, cancelDeadline = context.WithDeadline(boundCtx, start.Add(remaining))
+		boundCtx = budgetCtx
+		cancelParent := cancel
+		cancel = func() {
+			cancelDeadline()
+			cancelParent()
+		}
+	}
+
+	return boundCtx, func(ctx context.Context, err error) error {
+		budgetExceeded := budgetCtx != nil &&
+			errors.Is(budgetCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+		stop()
+		cancel()
+
+		e.mux.Lock()
+		defer e.mux.Unlock()
+		if e.spent == nil {
+			e.spent = map[endpointObjectKey]time.Duration{}
+		}
+		e.spent[key] += time.Since(start)
+
+		if err != nil && budgetExceeded {
+			return fmt.Errorf("%w: %s", ErrEndpointTimeBudgetExhausted, e.Budget)
+		}
+		return err
+	}, nil
+}
+
+// Endpoints not probed, because the time budget was exhausted, are not failing,
+// but can't be reported as available either.
+func notProbedMessage(err error) string {
+	return "not probed yet, " + err.Error()
+}
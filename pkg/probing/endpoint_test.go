@@ -0,0 +1,276 @@
+package probing
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ Endpoints = (*endpointsMock)(nil)
+
+type endpointsMock struct {
+	mock.Mock
+}
+
+func (m *endpointsMock) HTTPGet(
+	ctx context.Context, obj *unstructured.Unstructured,
+	scheme string, port *intstr.IntOrString, path string,
+) (statusCode int, body []byte, err error) {
+	args := m.Called(ctx, obj, scheme, port, path)
+	body, _ = args.Get(1).([]byte)
+	return args.Int(0), body, args.Error(2)
+}
+
+func (m *endpointsMock) DialTCP(
+	ctx context.Context, obj *unstructured.Unstructured, port *intstr.IntOrString,
+) (net.Conn, error) {
+	args := m.Called(ctx, obj, port)
+	conn, _ := args.Get(0).(net.Conn)
+	return conn, args.Error(1)
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func testService() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]any{
+			"name":      "app",
+			"namespace": "test",
+		},
+		"spec": map[string]any{
+			"ports": []any{
+				map[string]any{"name": "http", "port": int64(8080)},
+				map[string]any{"name": "metrics", "port": int64(9090)},
+			},
+		},
+	}}
+}
+
+func testRoute(tls bool) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "route.openshift.io/v1",
+		"kind":       "Route",
+		"metadata": map[string]any{
+			"name":      "app",
+			"namespace": "test",
+		},
+		"spec": map[string]any{
+			"host": "app.example.com",
+		},
+	}}
+	if tls {
+		route.Object["spec"].(map[string]any)["tls"] = map[string]any{"termination": "edge"}
+	}
+	return route
+}
+
+func TestServicePort(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		port        *intstr.IntOrString
+		expected    int64
+		expectedErr error
+	}{
+		"default": {
+			expected: 8080,
+		},
+		"by name": {
+			port:     ptrTo(intstr.FromString("metrics")),
+			expected: 9090,
+		},
+		"by number": {
+			port:     ptrTo(intstr.FromInt32(9090)),
+			expected: 9090,
+		},
+		"not found": {
+			port:        ptrTo(intstr.FromString("banana")),
+			expectedErr: ErrServicePortNotFound,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			port, err := ServicePort(testService(), tc.port)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, port)
+		})
+	}
+}
+
+func TestDirectEndpoints_HTTPGet(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		obj         *unstructured.Unstructured
+		scheme      string
+		port        *intstr.IntOrString
+		expectedURL string
+	}{
+		"service": {
+			obj:         testService(),
+			port:        ptrTo(intstr.FromString("metrics")),
+			expectedURL: "http://app.test.svc:9090/healthz",
+		},
+		"service https": {
+			obj:         testService(),
+			scheme:      "HTTPS",
+			expectedURL: "https://app.test.svc:8080/healthz",
+		},
+		"route": {
+			obj:         testRoute(false),
+			scheme:      "HTTPS",
+			expectedURL: "http://app.example.com:80/healthz",
+		},
+		"route tls": {
+			obj:         testRoute(true),
+			expectedURL: "https://app.example.com:443/healthz",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			e := &DirectEndpoints{
+				Client: &http.Client{
+					Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+						assert.Equal(t, tc.expectedURL, req.URL.String())
+						return &http.Response{
+							StatusCode: http.StatusTeapot,
+							Body:       io.NopCloser(strings.NewReader("short and stout")),
+						}, nil
+					}),
+				},
+			}
+
+			statusCode, body, err := e.HTTPGet(context.Background(), tc.obj, tc.scheme, tc.port, "/healthz")
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusTeapot, statusCode)
+			assert.Equal(t, "short and stout", string(body))
+		})
+	}
+}
+
+func TestDirectEndpoints_unsupported(t *testing.T) {
+	t.Parallel()
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+
+	e := &DirectEndpoints{}
+	_, _, err := e.HTTPGet(context.Background(), obj, "", nil, "/")
+	require.ErrorIs(t, err, ErrUnsupportedEndpoint)
+
+	_, err = e.DialTCP(context.Background(), obj, nil)
+	require.ErrorIs(t, err, ErrUnsupportedEndpoint)
+
+	route := testRoute(false)
+	unstructured.RemoveNestedField(route.Object, "spec", "host")
+	_, err = e.DialTCP(context.Background(), route, nil)
+	require.ErrorIs(t, err, ErrRouteHostNotFound)
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+func TestBoundEndpoints(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		boundCtx, cancel := context.WithCancel(context.Background())
+		endpoints := &endpointsMock{}
+		endpoints.
+			On("DialTCP", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				// Cancelling the bound context aborts running connection attempts.
+				cancel()
+				<-args.Get(0).(context.Context).Done()
+			}).
+			Return(nil, context.Canceled)
+
+		e := &BoundEndpoints{Endpoints: endpoints, Context: boundCtx}
+		_, err := e.DialTCP(context.Background(), testService(), nil)
+		require.ErrorIs(t, err, context.Canceled)
+
+		// No further connection attempts.
+		_, err = e.DialTCP(context.Background(), testService(), nil)
+		require.ErrorIs(t, err, context.Canceled)
+		endpoints.AssertNumberOfCalls(t, "DialTCP", 1)
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints := &endpointsMock{}
+		endpoints.
+			On("HTTPGet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+			}).
+			Return(0, nil, context.DeadlineExceeded)
+
+		e := &BoundEndpoints{
+			Endpoints: endpoints,
+			Context:   context.Background(),
+			Budget:    10 * time.Millisecond,
+		}
+		// Connection attempts aborted by the budget report it as exhausted.
+		_, _, err := e.HTTPGet(context.Background(), testService(), "", nil, "/")
+		require.ErrorIs(t, err, ErrEndpointTimeBudgetExhausted)
+
+		_, _, err = e.HTTPGet(context.Background(), testService(), "", nil, "/")
+		require.ErrorIs(t, err, ErrEndpointTimeBudgetExhausted)
+		endpoints.AssertNumberOfCalls(t, "HTTPGet", 1)
+
+		// Other objects have their own budget.
+		_, _, err = e.HTTPGet(context.Background(), testRoute(false), "", nil, "/")
+		require.ErrorIs(t, err, ErrEndpointTimeBudgetExhausted)
+		endpoints.AssertNumberOfCalls(t, "HTTPGet", 2)
+	})
+
+	t.Run("timeout within budget", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints := &endpointsMock{}
+		endpoints.
+			On("DialTCP", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+			}).
+			Return(nil, context.DeadlineExceeded)
+
+		e := &BoundEndpoints{
+			Endpoints: endpoints,
+			Context:   context.Background(),
+			Budget:    time.Minute,
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := e.DialTCP(ctx, testService(), nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.NotErrorIs(t, err, ErrEndpointTimeBudgetExhausted)
+	})
+}
@@ -0,0 +1,122 @@
+package probing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DefaultEndpointProbeTimeout is used by endpoint probes without explicit timeout.
+const DefaultEndpointProbeTimeout = time.Second
+
+// HTTPGetProbe sends an HTTP GET request to a Service or Route and checks the response.
+type HTTPGetProbe struct {
+	Endpoints Endpoints
+	Scheme    string
+	Port      *intstr.IntOrString
+	Path      string
+	// Status codes considered successful, defaults to 200-399.
+	ExpectedStatusCodes []int32
+	// Optional check of the response body.
+	Body *HTTPBodyProbe
+	// Defaults to DefaultEndpointProbeTimeout.
+	Timeout time.Duration
+}
+
+var _ Prober = (*HTTPGetProbe)(nil)
+
+// Probe executes the probe.
+func (p *HTTPGetProbe) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	defer func() {
+		if success {
+			return
+		}
+		// add request as context to error message.
+		message = fmt.Sprintf("GET %s: %s", p.Path, message)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), endpointProbeTimeout(p.Timeout))
+	defer cancel()
+
+	statusCode, body, err := p.Endpoints.HTTPGet(ctx, obj, p.Scheme, p.Port, p.Path)
+	if errors.Is(err, ErrEndpointTimeBudgetExhausted) {
+		return false, notProbedMessage(err)
+	}
+	if err != nil {
+		return false, err.Error()
+	}
+	if !p.isExpectedStatusCode(statusCode) {
+		return false, fmt.Sprintf("unexpected status code %d", statusCode)
+	}
+	if p.Body == nil {
+		return true, ""
+	}
+	return p.Body.ProbeBody(body)
+}
+
+func (p *HTTPGetProbe) isExpectedStatusCode(statusCode int) bool {
+	if len(p.ExpectedStatusCodes) == 0 {
+		return statusCode >= http.StatusOK && statusCode < http.StatusBadRequest
+	}
+	return slices.ContainsFunc(p.ExpectedStatusCodes, func(expected int32) bool {
+		return int(expected) == statusCode
+	})
+}
+
+func endpointProbeTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return DefaultEndpointProbeTimeout
+	}
+	return timeout
+}
+
+// HTTPBodyProbe uses the common expression language to probe HTTP response bodies.
+type HTTPBodyProbe struct {
+	Program cel.Program
+	Message string
+}
+
+// NewHTTPBodyProbe creates a new CEL (Common Expression Language) Probe for HTTP response bodies.
+// The CEL expression needs to evaluate to a bool and has access to the response body
+// as string `body` and, if the body is valid JSON, to the parsed body as `json`.
+func NewHTTPBodyProbe(rule, message string) (*HTTPBodyProbe, error) {
+	prgm, err := compileCELRule(rule,
+		cel.Variable("body", cel.StringType),
+		cel.Variable("json", cel.DynType),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTTPBodyProbe{
+		Program: prgm,
+		Message: message,
+	}, nil
+}
+
+// ProbeBody executes the probe.
+func (p *HTTPBodyProbe) ProbeBody(body []byte) (success bool, message string) {
+	var parsed any
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		// Body is not JSON.
+		parsed = nil
+	}
+
+	val, _, err := p.Program.Eval(map[string]any{
+		"body": string(body),
+		"json": parsed,
+	})
+	if err != nil {
+		return false, fmt.Sprintf("CEL program failed: %v", err)
+	}
+
+	return val.Value().(bool), p.Message
+}
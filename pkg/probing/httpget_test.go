@@ -0,0 +1,114 @@
+package probing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPGetProbe(t *testing.T) {
+	t.Parallel()
+
+	statusBody, err := NewHTTPBodyProbe(`json.status == "ok"`, "application not ready")
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		expectedStatusCodes []int32
+		body                *HTTPBodyProbe
+		statusCode          int
+		responseBody        string
+		err                 error
+
+		success bool
+		message string
+	}{
+		"success": {
+			statusCode: http.StatusOK,
+			success:    true,
+		},
+		"redirect": {
+			statusCode: http.StatusFound,
+			success:    true,
+		},
+		"unavailable": {
+			statusCode: http.StatusServiceUnavailable,
+			message:    "GET /healthz: unexpected status code 503",
+		},
+		"expected status code": {
+			expectedStatusCodes: []int32{http.StatusServiceUnavailable},
+			statusCode:          http.StatusServiceUnavailable,
+			success:             true,
+		},
+		"unexpected status code": {
+			expectedStatusCodes: []int32{http.StatusNoContent},
+			statusCode:          http.StatusOK,
+			message:             "GET /healthz: unexpected status code 200",
+		},
+		"body success": {
+			body:         statusBody,
+			statusCode:   http.StatusOK,
+			responseBody: `{"status":"ok"}`,
+			success:      true,
+		},
+		"body failure": {
+			body:         statusBody,
+			statusCode:   http.StatusOK,
+			responseBody: `{"status":"starting"}`,
+			message:      "GET /healthz: application not ready",
+		},
+		"request error": {
+			err:     errors.New("connection refused"),
+			message: "GET /healthz: connection refused",
+		},
+		"budget exhausted": {
+			err:     fmt.Errorf("%w: 10s", ErrEndpointTimeBudgetExhausted),
+			message: "GET /healthz: not probed yet, time budget for endpoint probes exhausted: 10s",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := testService()
+			endpoints := &endpointsMock{}
+			endpoints.
+				On("HTTPGet", mock.Anything, svc, "HTTP", mock.Anything, "/healthz").
+				Return(tc.statusCode, []byte(tc.responseBody), tc.err)
+
+			p := &HTTPGetProbe{
+				Endpoints:           endpoints,
+				Scheme:              "HTTP",
+				Path:                "/healthz",
+				ExpectedStatusCodes: tc.expectedStatusCodes,
+				Body:                tc.body,
+			}
+			success, message := p.Probe(svc)
+			assert.Equal(t, tc.success, success)
+			if !tc.success {
+				assert.Equal(t, tc.message, message)
+			}
+		})
+	}
+}
+
+func TestHTTPBodyProbe(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewHTTPBodyProbe(`body.size()`, "")
+	require.ErrorIs(t, err, ErrCELInvalidEvaluationType)
+
+	p, err := NewHTTPBodyProbe(`body.contains("ready") || json != null`, "not ready")
+	require.NoError(t, err)
+
+	success, _ := p.ProbeBody([]byte("ready"))
+	assert.True(t, success)
+	success, _ = p.ProbeBody([]byte("[1, 2]"))
+	assert.True(t, success)
+	success, message := p.ProbeBody([]byte("starting"))
+	assert.False(t, success)
+	assert.Equal(t, "not ready", message)
+}
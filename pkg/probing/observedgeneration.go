@@ -13,10 +13,17 @@ var _ Prober = (*ObservedGenerationProbe)(nil)
 
 // Probe executes the probe.
 func (cg *ObservedGenerationProbe) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	if success, message := probeObservedGeneration(obj); !success {
+		return false, message
+	}
+	return cg.Prober.Probe(obj)
+}
+
+func probeObservedGeneration(obj *unstructured.Unstructured) (success bool, message string) {
 	if observedGeneration, ok, err := unstructured.NestedInt64(
 		obj.Object, "status", "observedGeneration",
 	); err == nil && ok && observedGeneration != obj.GetGeneration() {
 		return false, ".status outdated"
 	}
-	return cg.Prober.Probe(obj)
+	return true, ""
 }
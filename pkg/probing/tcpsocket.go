@@ -0,0 +1,37 @@
+package probing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// TCPSocketProbe checks that a TCP connection to a Service or Route can be opened.
+type TCPSocketProbe struct {
+	Endpoints Endpoints
+	Port      *intstr.IntOrString
+	// Defaults to DefaultEndpointProbeTimeout.
+	Timeout time.Duration
+}
+
+var _ Prober = (*TCPSocketProbe)(nil)
+
+// Probe executes the probe.
+func (p *TCPSocketProbe) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	ctx, cancel := context.WithTimeout(context.Background(), endpointProbeTimeout(p.Timeout))
+	defer cancel()
+
+	conn, err := p.Endpoints.DialTCP(ctx, obj, p.Port)
+	if errors.Is(err, ErrEndpointTimeBudgetExhausted) {
+		return false, notProbedMessage(err)
+	}
+	if err != nil {
+		return false, fmt.Sprintf("TCP connection failed: %v", err)
+	}
+	_ = conn.Close()
+	return true, ""
+}
@@ -0,0 +1,63 @@
+package probing
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestTCPSocketProbe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		client, server := net.Pipe()
+		defer server.Close()
+
+		svc := testService()
+		port := intstr.FromString("http")
+		endpoints := &endpointsMock{}
+		endpoints.
+			On("DialTCP", mock.Anything, svc, &port).
+			Return(client, nil)
+
+		p := &TCPSocketProbe{Endpoints: endpoints, Port: &port}
+		success, message := p.Probe(svc)
+		assert.True(t, success)
+		assert.Empty(t, message)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints := &endpointsMock{}
+		endpoints.
+			On("DialTCP", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("connection refused"))
+
+		p := &TCPSocketProbe{Endpoints: endpoints}
+		success, message := p.Probe(testService())
+		assert.False(t, success)
+		assert.Equal(t, "TCP connection failed: connection refused", message)
+	})
+
+	t.Run("budget exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints := &endpointsMock{}
+		endpoints.
+			On("DialTCP", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("%w: 10s", ErrEndpointTimeBudgetExhausted))
+
+		p := &TCPSocketProbe{Endpoints: endpoints}
+		success, message := p.Probe(testService())
+		assert.False(t, success)
+		assert.Equal(t, "not probed yet, time budget for endpoint probes exhausted: 10s", message)
+	})
+}